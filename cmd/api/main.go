@@ -5,10 +5,14 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"recipe-processor/internal/app"
+	"recipe-processor/internal/application/recipe"
 	"recipe-processor/internal/config"
 	"recipe-processor/internal/infrastructure/http"
-	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
 	"recipe-processor/internal/shared/logger"
+	"recipe-processor/internal/shared/outbox"
+	"recipe-processor/internal/shared/scheduler"
 	"syscall"
 	"time"
 )
@@ -30,19 +34,73 @@ func main() {
 	appLogger.Info("Starting application",
 		logger.String("environment", cfg.Environment),
 		logger.String("port", cfg.Port),
+		logger.String("mode", cfg.Mode),
 	)
 
-	// Initialize event bus (in-memory implementation)
-	eventBus := events.NewMemoryEventBus(appLogger)
+	switch cfg.Mode {
+	case config.ModeAll:
+	case config.ModeAPI:
+		// Events are handled by cmd/worker, which must see what we publish
+		if err := app.RequireSharedEventBus(cfg); err != nil {
+			appLogger.Fatal("MODE=api needs a shared event bus", logger.Error(err))
+		}
+	default:
+		appLogger.Fatal("Unknown MODE, expected all or api", logger.String("mode", cfg.Mode))
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Initialize recipe repository (SQLite implementation)
+	db, err := sqlite.Open(ctx, cfg.DatabasePath)
+	if err != nil {
+		appLogger.Fatal("Failed to open database", logger.Error(err), logger.String("path", cfg.DatabasePath))
+	}
+	defer func() { _ = db.Close() }()
+
+	// Event codecs for everything stored or dispatched outside the process
+	eventRegistry, err := recipe.NewEventRegistry()
+	if err != nil {
+		appLogger.Fatal("Failed to register events", logger.Error(err))
+	}
+
+	recipeRepository := sqlite.NewRecipeRepository(db, eventRegistry)
+
+	// Initialize event bus (in-memory, NATS JetStream or Redis Streams, dead letters and
+	// event history kept in SQLite)
+	eventBus, err := app.NewEventBus(cfg, db, eventRegistry, appLogger)
+	if err != nil {
+		appLogger.Fatal("Failed to create event bus", logger.Error(err), logger.String("event_bus", cfg.EventBus))
+	}
+
+	// Register event handlers, unless cmd/worker runs them; the scheduler
+	// publishes scheduled events, step timeouts included, claiming each one
+	// so the workers polling the same table do not publish it as well
+	var eventScheduler *scheduler.Scheduler
+	if cfg.Mode == config.ModeAll {
+		eventScheduler, err = app.SubscribeHandlers(cfg, eventBus, db, eventRegistry, appLogger)
+		if err != nil {
+			appLogger.Fatal("Failed to subscribe handlers", logger.Error(err))
+		}
+	} else {
+		eventScheduler = scheduler.NewScheduler(sqlite.NewScheduleStore(db, eventRegistry), eventBus, appLogger, scheduler.Config{})
+	}
+
 	// Start event bus
 	if err := eventBus.Start(ctx); err != nil {
 		appLogger.Fatal("Failed to start event bus", logger.Error(err))
 	}
 
-	server := http.NewServer(cfg, appLogger, eventBus)
+	// Dispatch submitted recipes from the outbox, including any left over
+	// from a previous run
+	relay := outbox.NewRelay(sqlite.NewOutboxStore(db), eventBus, eventRegistry, appLogger, outbox.Config{})
+	relay.Start(ctx)
+
+	// Publish scheduled events as they come due, including those that came
+	// due while the API was down
+	eventScheduler.Start(ctx)
+
+	server := http.NewServer(cfg, appLogger, recipeRepository, eventBus, app.HealthChecks(db, eventBus)...)
 
 	go func() {
 		appLogger.Info("Starting server", logger.String("port", cfg.Port))
@@ -68,5 +126,14 @@ func main() {
 		appLogger.Error("Server shutdown error", logger.Error(err))
 	}
 
+	// Stop dispatching; undelivered messages stay in the outbox for the next start
+	relay.Stop()
+	eventScheduler.Stop()
+
+	// Let handlers finish queued events; leftovers are dead-lettered for redrive
+	if err := eventBus.Stop(shutdownCtx); err != nil {
+		appLogger.Error("Event bus shutdown error", logger.Error(err))
+	}
+
 	appLogger.Info("Server exited")
 }
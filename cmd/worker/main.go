@@ -0,0 +1,112 @@
+// Command worker runs the event handlers (parsing, Notion export) without
+// serving the API, so LLM throughput scales independently of it
+//
+// It needs a shared event bus (EVENT_BUS=nats or redis) and the database of
+// the API (DATABASE_PATH); run the API with MODE=api next to it. Health and
+// readiness are served on HEALTH_PORT
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"recipe-processor/internal/app"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/config"
+	"recipe-processor/internal/infrastructure/http"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"recipe-processor/internal/shared/logger"
+	"syscall"
+	"time"
+)
+
+func main() {
+	cfg := config.Load()
+
+	appLogger, err := logger.NewZapLogger(cfg.Environment)
+	if err != nil {
+		log.Fatal("Failed to initialize logger:", err)
+	}
+
+	defer func() {
+		if zapLogger, ok := appLogger.(*logger.ZapLogger); ok {
+			_ = zapLogger.Sync()
+		}
+	}()
+
+	appLogger.Info("Starting worker",
+		logger.String("environment", cfg.Environment),
+		logger.String("event_bus", cfg.EventBus),
+		logger.String("health_port", cfg.HealthPort),
+	)
+
+	if err := app.RequireSharedEventBus(cfg); err != nil {
+		appLogger.Fatal("The worker needs a shared event bus", logger.Error(err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := sqlite.Open(ctx, cfg.DatabasePath)
+	if err != nil {
+		appLogger.Fatal("Failed to open database", logger.Error(err), logger.String("path", cfg.DatabasePath))
+	}
+	defer func() { _ = db.Close() }()
+
+	eventRegistry, err := recipe.NewEventRegistry()
+	if err != nil {
+		appLogger.Fatal("Failed to register events", logger.Error(err))
+	}
+
+	eventBus, err := app.NewEventBus(cfg, db, eventRegistry, appLogger)
+	if err != nil {
+		appLogger.Fatal("Failed to create event bus", logger.Error(err), logger.String("event_bus", cfg.EventBus))
+	}
+
+	timeouts, err := app.SubscribeHandlers(cfg, eventBus, db, eventRegistry, appLogger)
+	if err != nil {
+		appLogger.Fatal("Failed to subscribe handlers", logger.Error(err))
+	}
+
+	if err := eventBus.Start(ctx); err != nil {
+		appLogger.Fatal("Failed to start event bus", logger.Error(err))
+	}
+
+	// Publish step timeouts, including those that came due while no worker
+	// ran; every worker and the API share them, each event is claimed by one
+	timeouts.Start(ctx)
+
+	healthServer := http.NewHealthServer(cfg.HealthPort, app.HealthChecks(db, eventBus)...)
+
+	go func() {
+		appLogger.Info("Starting health server", logger.String("port", cfg.HealthPort))
+
+		if err := healthServer.Start(); err != nil {
+			appLogger.Fatal("Failed to start health server", logger.Error(err))
+		}
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	appLogger.Info("Shutting down worker...")
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer shutdownCancel()
+
+	timeouts.Stop()
+
+	// Let running handlers finish; unacknowledged messages are redelivered
+	if err := eventBus.Stop(shutdownCtx); err != nil {
+		appLogger.Error("Event bus shutdown error", logger.Error(err))
+	}
+
+	if err := healthServer.Shutdown(shutdownCtx); err != nil {
+		appLogger.Error("Health server shutdown error", logger.Error(err))
+	}
+
+	appLogger.Info("Worker exited")
+}
@@ -0,0 +1,202 @@
+// Package app wires the event pipeline shared by the binaries in cmd
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/config"
+	apphttp "recipe-processor/internal/infrastructure/http"
+	"recipe-processor/internal/infrastructure/jetstream"
+	"recipe-processor/internal/infrastructure/llm"
+	"recipe-processor/internal/infrastructure/notion"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"recipe-processor/internal/infrastructure/redisstream"
+	"recipe-processor/internal/infrastructure/webhook"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"recipe-processor/internal/shared/scheduler"
+	"time"
+)
+
+// EventBus is an event bus whose dead letters can be inspected and redriven
+type EventBus interface {
+	events.EventBus
+	events.DeadLetterQueue
+	// Ping reports whether the bus can deliver events
+	Ping(ctx context.Context) error
+}
+
+// RequireSharedEventBus fails unless cfg selects a bus that delivers events
+// to other processes, which splitting the API and the workers relies on
+func RequireSharedEventBus(cfg *config.Config) error {
+	if cfg.EventBus == config.EventBusMemory {
+		return fmt.Errorf("EVENT_BUS=%s only delivers events within one process, use %s or %s",
+			cfg.EventBus, config.EventBusNATS, config.EventBusRedis)
+	}
+	return nil
+}
+
+// HealthChecks are the readiness checks of every binary
+func HealthChecks(db *sql.DB, bus EventBus) []apphttp.HealthCheck {
+	return []apphttp.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "event_bus", Check: bus.Ping},
+	}
+}
+
+// NewEventBus creates the event bus selected by cfg.EventBus, with dead
+// letters and the event history kept in SQLite
+func NewEventBus(cfg *config.Config, db *sql.DB, registry *events.Registry, log logger.Logger) (EventBus, error) {
+	switch cfg.EventBus {
+	case config.EventBusMemory:
+		overflow := events.OverflowPolicy(cfg.EventQueueOverflow)
+		if overflow != events.OverflowBlock && overflow != events.OverflowFail && overflow != events.OverflowSpill {
+			return nil, fmt.Errorf("unknown EVENT_QUEUE_OVERFLOW %q, expected %q, %q or %q", cfg.EventQueueOverflow,
+				events.OverflowBlock, events.OverflowFail, events.OverflowSpill)
+		}
+		return events.NewMemoryEventBusWithConfig(log, events.Config{
+			WorkerCount:    events.DefaultWorkerCount,
+			ChannelBuffer:  cfg.EventQueueSize,
+			HandlerTimeout: events.DefaultHandlerTimeout,
+			RetryPolicy:    events.DefaultRetryPolicy(),
+			DeadLetters:    sqlite.NewDeadLetterStore(db, registry),
+			Store:          sqlite.NewEventStore(db, registry),
+			// Events of one recipe are handled in the order they were published
+			OrderingKey:  events.OrderingKeyOf,
+			Overflow:     overflow,
+			BlockTimeout: cfg.EventQueueBlockTimeout,
+			Spill:        sqlite.NewSpillStore(db, registry),
+		}), nil
+	case config.EventBusNATS:
+		return jetstream.NewEventBus(registry, log, jetstream.Config{
+			URL:            cfg.NATSURL,
+			Stream:         cfg.NATSStream,
+			MaxDeliver:     cfg.NATSMaxDeliver,
+			HandlerTimeout: events.DefaultHandlerTimeout,
+			RetryPolicy:    events.DefaultRetryPolicy(),
+			DeadLetters:    sqlite.NewDeadLetterStore(db, registry),
+			Store:          sqlite.NewEventStore(db, registry),
+		})
+	case config.EventBusRedis:
+		return redisstream.NewEventBus(registry, log, redisstream.Config{
+			Addr:           cfg.RedisAddr,
+			Password:       cfg.RedisPassword,
+			MaxLen:         int64(cfg.RedisMaxLen),
+			HandlerTimeout: events.DefaultHandlerTimeout,
+			RetryPolicy:    events.DefaultRetryPolicy(),
+			DeadLetters:    sqlite.NewDeadLetterStore(db, registry),
+			Store:          sqlite.NewEventStore(db, registry),
+		})
+	default:
+		return nil, fmt.Errorf("unknown EVENT_BUS %q, expected %q, %q or %q", cfg.EventBus,
+			config.EventBusMemory, config.EventBusNATS, config.EventBusRedis)
+	}
+}
+
+// SubscribeHandlers registers the recipe processing handlers and their
+// process manager on bus, keeping workflows and step timeouts in db
+// Notion export and notifications are only enabled when they are configured.
+// It returns the scheduler publishing the step timeouts: start it once the bus
+// runs and stop it before the bus, or no step ever times out
+func SubscribeHandlers(cfg *config.Config, bus events.EventBus, db *sql.DB, registry *events.Registry, log logger.Logger) (*scheduler.Scheduler, error) {
+	ollamaClient := llm.NewOllamaClient(llm.OllamaConfig{
+		BaseURL: cfg.OllamaBaseUrl,
+		Model:   cfg.OllamaModel,
+		Timeout: cfg.OllamaTimeout,
+	})
+
+	timeouts := scheduler.NewScheduler(sqlite.NewScheduleStore(db, registry), bus, log, scheduler.Config{})
+	pipeline := Pipeline{
+		Repository:    sqlite.NewRecipeRepository(db, registry),
+		Workflows:     sqlite.NewWorkflowStore(db),
+		Timeouts:      timeouts,
+		Parser:        llm.NewRecipeParser(ollamaClient),
+		ParserTimeout: cfg.OllamaTimeout,
+		ParseTimeout:  cfg.WorkflowParseTimeout,
+		ExportTimeout: cfg.WorkflowExportTimeout,
+		NotifyTimeout: cfg.WorkflowNotifyTimeout,
+	}
+
+	if cfg.NotionToken != "" && cfg.NotionDatabaseId != "" {
+		notionClient := notion.NewClient(notion.ClientConfig{Token: cfg.NotionToken})
+		pipeline.Exporter = notion.NewRecipeExporter(notionClient, cfg.NotionDatabaseId)
+	} else {
+		log.Warn("Notion export disabled: NOTION_TOKEN or NOTION_DATABASE_ID not set")
+	}
+
+	if cfg.NotifyWebhookURL != "" {
+		pipeline.Notifier = webhook.NewNotifier(webhook.NotifierConfig{URL: cfg.NotifyWebhookURL})
+	}
+
+	if err := SubscribePipeline(bus, pipeline, log); err != nil {
+		return nil, err
+	}
+	return timeouts, nil
+}
+
+// Pipeline is what the recipe processing handlers and their process manager need
+type Pipeline struct {
+	Repository recipe.RecipeRepository
+	Workflows  recipe.WorkflowStore
+	Timeouts   recipe.TimeoutScheduler
+	Parser     recipe.RecipeParser
+	// ParserTimeout bounds one attempt of the parse handler, which waits on
+	// the LLM much longer than other handlers; zero uses the bus default
+	ParserTimeout time.Duration
+	// Exporter and Notifier are optional, workflows end before their step
+	// without them. An exporter implementing recipe.RecipeValidator and
+	// recipe.ExportArchiver also validates recipes and undoes exports
+	Exporter recipe.RecipeExporter
+	Notifier recipe.RecipeNotifier
+	// Step timeouts, zero values use the process manager's defaults
+	ParseTimeout  time.Duration
+	ExportTimeout time.Duration
+	NotifyTimeout time.Duration
+}
+
+// SubscribePipeline registers the recipe process manager and the handlers of
+// the commands it sends
+func SubscribePipeline(bus events.EventBus, p Pipeline, log logger.Logger) error {
+	workflow := recipe.ProcessManagerConfig{
+		ParseTimeout:  p.ParseTimeout,
+		ExportTimeout: p.ExportTimeout,
+		NotifyTimeout: p.NotifyTimeout,
+		Export:        p.Exporter != nil,
+		Notify:        p.Exporter != nil && p.Notifier != nil,
+	}
+
+	parseHandler := recipe.NewParseRecipeHandler(p.Parser, p.Repository, bus, log)
+	if _, err := events.SubscribeTyped(bus, "parse-recipe", parseHandler.Handle, events.WithHandlerTimeout(p.ParserTimeout)); err != nil {
+		return err
+	}
+
+	if p.Exporter != nil {
+		exportHandler := recipe.NewExportRecipeHandler(p.Exporter, p.Repository, bus, log)
+		if _, err := events.SubscribeTyped(bus, "export-recipe-notion", exportHandler.Handle); err != nil {
+			return err
+		}
+
+		if validator, ok := p.Exporter.(recipe.RecipeValidator); ok {
+			workflow.Validator = validator
+		}
+
+		if archiver, ok := p.Exporter.(recipe.ExportArchiver); ok {
+			archiveHandler := recipe.NewArchiveExportHandler(archiver, bus, log)
+			if _, err := events.SubscribeTyped(bus, "archive-recipe-notion", archiveHandler.Handle); err != nil {
+				return err
+			}
+			workflow.Archive = true
+		}
+	}
+
+	if workflow.Notify {
+		notifyHandler := recipe.NewNotifyRecipeHandler(p.Notifier, bus, log)
+		if _, err := events.SubscribeTyped(bus, "notify-recipe", notifyHandler.Handle); err != nil {
+			return err
+		}
+	}
+
+	return recipe.NewRecipeProcessManager(p.Workflows, p.Repository, bus, p.Timeouts, log, workflow).Subscribe()
+}
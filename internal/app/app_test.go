@@ -0,0 +1,109 @@
+package app_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"recipe-processor/internal/app"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/config"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSubscribeHandlers_StuckStepTimesOut(t *testing.T) {
+	// Arrange: Ollama accepts the parse request but never answers
+	ctx := context.Background()
+	log := logger.NewNoopLogger()
+
+	db, err := sqlite.Open(ctx, filepath.Join(t.TempDir(), "recipes.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	release := make(chan struct{})
+	ollama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(ollama.Close)
+
+	cfg := &config.Config{
+		OllamaBaseUrl:        ollama.URL,
+		EventBus:             config.EventBusMemory,
+		EventQueueSize:       10,
+		EventQueueOverflow:   string(events.OverflowBlock),
+		WorkflowParseTimeout: 10 * time.Millisecond,
+	}
+
+	registry, err := recipe.NewEventRegistry()
+	if err != nil {
+		t.Fatalf("NewEventRegistry() error = %v", err)
+	}
+	bus, err := app.NewEventBus(cfg, db, registry, log)
+	if err != nil {
+		t.Fatalf("NewEventBus() error = %v", err)
+	}
+
+	timeouts, err := app.SubscribeHandlers(cfg, bus, db, registry, log)
+	if err != nil {
+		t.Fatalf("SubscribeHandlers() error = %v", err)
+	}
+	if err := bus.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	timeouts.Start(ctx)
+	t.Cleanup(func() {
+		timeouts.Stop()
+		close(release)
+		stopCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_ = bus.Stop(stopCtx)
+	})
+
+	repo := sqlite.NewRecipeRepository(db, registry)
+	text, _ := domain.NewRecipeText("Pancakes...")
+	lifecycle, _ := domain.NewRecipeLifecycle("recipe-1", text, time.Now())
+	if err := repo.Save(ctx, lifecycle); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Act
+	if err := bus.Publish(ctx, domain.NewRecipeSubmitted("recipe-1", "Pancakes...")); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	// Assert
+	workflows := sqlite.NewWorkflowStore(db)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		workflow, err := workflows.Find(ctx, "recipe-1")
+		if err == nil && workflow.Status == recipe.WorkflowFailed {
+			if !strings.Contains(workflow.FailureReason, "timed out") {
+				t.Errorf("Expected a timeout reason, got %q", workflow.FailureReason)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the parse step to time out, got %+v, %v", workflow, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	stored, err := repo.FindByID(ctx, "recipe-1")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if stored.Status() != domain.StatusFailed {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusFailed, stored.Status())
+	}
+}
@@ -0,0 +1,270 @@
+package app_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"recipe-processor/internal/app"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/config"
+	"recipe-processor/internal/domain"
+	apphttp "recipe-processor/internal/infrastructure/http"
+	"recipe-processor/internal/infrastructure/http/handlers"
+	"recipe-processor/internal/infrastructure/persistence/memory"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/events/eventstest"
+	"recipe-processor/internal/shared/logger"
+	"recipe-processor/internal/shared/outbox"
+	"recipe-processor/internal/shared/scheduler"
+	"testing"
+)
+
+type parserFunc func(ctx context.Context, recipeID, text string) (*domain.Recipe, error)
+
+func (f parserFunc) Parse(ctx context.Context, recipeID, text string) (*domain.Recipe, error) {
+	return f(ctx, recipeID, text)
+}
+
+type exporterFunc func(ctx context.Context, r *domain.Recipe) (*domain.ExportReference, error)
+
+func (f exporterFunc) Export(ctx context.Context, r *domain.Recipe) (*domain.ExportReference, error) {
+	return f(ctx, r)
+}
+
+// pipeline is the API, the recipe handlers and their process manager running
+// on a synchronous bus
+type pipeline struct {
+	router    http.Handler
+	relay     *outbox.Relay
+	bus       *events.SyncEventBus
+	recorder  *eventstest.Recorder
+	workflows *memory.WorkflowStore
+}
+
+func newPipeline(t *testing.T, parser recipe.RecipeParser, exporter recipe.RecipeExporter) *pipeline {
+	t.Helper()
+
+	log := logger.NewNoopLogger()
+	bus, recorder := eventstest.NewBus(t)
+
+	registry, err := recipe.NewEventRegistry()
+	if err != nil {
+		t.Fatalf("NewEventRegistry() error = %v", err)
+	}
+	repo := memory.NewRecipeRepository(registry)
+
+	workflows := memory.NewWorkflowStore()
+	if err := app.SubscribePipeline(bus, app.Pipeline{
+		Repository: repo,
+		Workflows:  workflows,
+		Timeouts:   scheduler.NewScheduler(memory.NewScheduleStore(), bus, log, scheduler.Config{}),
+		Parser:     parser,
+		Exporter:   exporter,
+	}, log); err != nil {
+		t.Fatalf("SubscribePipeline() error = %v", err)
+	}
+
+	return &pipeline{
+		router:    apphttp.NewServer(&config.Config{}, log, repo, bus).Handler(),
+		relay:     outbox.NewRelay(repo.Outbox(), bus, registry, log, outbox.Config{}),
+		bus:       bus,
+		recorder:  recorder,
+		workflows: workflows,
+	}
+}
+
+func (p *pipeline) workflow(t *testing.T, id string) *recipe.Workflow {
+	t.Helper()
+
+	workflow, err := p.workflows.Find(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	return workflow
+}
+
+// submit posts a recipe and relays the outbox, which runs the whole pipeline
+func (p *pipeline) submit(t *testing.T, text string) string {
+	t.Helper()
+
+	body, _ := json.Marshal(handlers.SubmitRecipeRequest{RecipeText: text})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	p.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+	}
+
+	var resp handlers.SubmitRecipeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if _, err := p.relay.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("DispatchPending() error = %v", err)
+	}
+
+	return resp.RecipeID
+}
+
+func (p *pipeline) get(t *testing.T, id string) handlers.GetRecipeResponse {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recipes/"+id, nil)
+	w := httptest.NewRecorder()
+	p.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp handlers.GetRecipeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func parseAs(title string) parserFunc {
+	return func(ctx context.Context, recipeID, text string) (*domain.Recipe, error) {
+		flour, _ := domain.NewIngredient(domain.IngredientParams{Name: "flour"})
+		mix, _ := domain.NewStep(domain.StepParams{Text: "mix"})
+		return domain.NewRecipe(domain.RecipeParams{
+			ID:          recipeID,
+			Title:       title,
+			Ingredients: []domain.Ingredient{*flour},
+			Steps:       []domain.Step{*mix},
+		})
+	}
+}
+
+func TestPipeline_SubmitToNotionExport(t *testing.T) {
+	// Arrange
+	var exported string
+	p := newPipeline(t, parseAs("Pancakes"), exporterFunc(func(ctx context.Context, r *domain.Recipe) (*domain.ExportReference, error) {
+		exported = r.Title()
+		return &domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}, nil
+	}))
+
+	// Act
+	id := p.submit(t, "Pancakes: mix flour with milk and fry")
+
+	// Assert
+	submitted := p.recorder.AssertPublished(t, domain.EventTypeRecipeSubmitted, id)
+	p.recorder.WaitHandledBy(t, "parse-recipe", domain.CommandTypeParseRecipe, id)
+	p.recorder.WaitHandledBy(t, "export-recipe-notion", domain.CommandTypeExportRecipe, id)
+	exportedEvent := p.recorder.AssertPublished(t, domain.EventTypeRecipeExported, id)
+	p.recorder.AssertNotPublished(t, domain.EventTypeRecipeFailed, id)
+
+	if exportedEvent.Envelope.CorrelationID != submitted.Envelope.CorrelationID {
+		t.Errorf("expected one correlation ID across the pipeline, got %q and %q",
+			submitted.Envelope.CorrelationID, exportedEvent.Envelope.CorrelationID)
+	}
+
+	if exported != "Pancakes" {
+		t.Errorf("expected the parsed recipe to be exported, got %q", exported)
+	}
+
+	resp := p.get(t, id)
+	if resp.Status != string(domain.StatusExported) {
+		t.Errorf("expected status %q, got %q", domain.StatusExported, resp.Status)
+	}
+	if resp.NotionPageURL != "https://notion.so/page-1" {
+		t.Errorf("expected Notion page URL, got %q", resp.NotionPageURL)
+	}
+
+	if workflow := p.workflow(t, id); workflow.Status != recipe.WorkflowCompleted || workflow.Step != domain.StepExport {
+		t.Errorf("expected the workflow to complete after export, got %+v", workflow)
+	}
+}
+
+func TestPipeline_ExportFailureFailsRecipe(t *testing.T) {
+	// Arrange
+	p := newPipeline(t, parseAs("Pancakes"), exporterFunc(func(ctx context.Context, r *domain.Recipe) (*domain.ExportReference, error) {
+		return nil, errors.New("notion unavailable")
+	}))
+
+	// Act
+	id := p.submit(t, "Pancakes: mix flour with milk and fry")
+
+	// Assert
+	failed := p.recorder.WaitFailed(t, domain.CommandTypeExportRecipe, id)
+	if failed.Handler != "export-recipe-notion" {
+		t.Errorf("expected the export handler to fail, got %q", failed.Handler)
+	}
+	p.recorder.AssertPublished(t, domain.EventTypeRecipeFailed, id)
+	p.recorder.AssertNotPublished(t, domain.EventTypeRecipeExported, id)
+
+	letters, _ := p.bus.DeadLetters(context.Background())
+	if len(letters) != 1 || letters[0].Handler != "export-recipe-notion" {
+		t.Errorf("expected the export command to be dead-lettered, got %+v", letters)
+	}
+
+	resp := p.get(t, id)
+	if resp.Status != string(domain.StatusFailed) {
+		t.Errorf("expected status %q, got %q", domain.StatusFailed, resp.Status)
+	}
+	if workflow := p.workflow(t, id); workflow.Status != recipe.WorkflowFailed || workflow.Step != domain.StepExport {
+		t.Errorf("expected the workflow to fail in export, got %+v", workflow)
+	}
+}
+
+func TestPipeline_WithoutExporterStopsAfterParsing(t *testing.T) {
+	// Arrange
+	p := newPipeline(t, parseAs("Pancakes"), nil)
+
+	// Act
+	id := p.submit(t, "Pancakes: mix flour with milk and fry")
+
+	// Assert
+	p.recorder.AssertPublished(t, domain.EventTypeRecipeParsed, id)
+	p.recorder.AssertNotPublished(t, domain.EventTypeRecipeExported, id)
+
+	resp := p.get(t, id)
+	if resp.Status != string(domain.StatusParsed) {
+		t.Errorf("expected status %q, got %q", domain.StatusParsed, resp.Status)
+	}
+}
+
+// notionExporter is an exporter that archives pages like the Notion one
+type notionExporter struct {
+	exporterFunc
+	archived []string
+}
+
+func (e *notionExporter) Archive(ctx context.Context, ref domain.ExportReference) error {
+	e.archived = append(e.archived, ref.PageID)
+	return nil
+}
+
+func TestPipeline_HalfWrittenExportIsArchived(t *testing.T) {
+	// Arrange
+	exporter := &notionExporter{exporterFunc: func(ctx context.Context, r *domain.Recipe) (*domain.ExportReference, error) {
+		return nil, &domain.IncompleteExportError{
+			Reference: domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"},
+			Err:       events.Permanent(errors.New("validation error")),
+		}
+	}}
+	p := newPipeline(t, parseAs("Pancakes"), exporter)
+
+	// Act
+	id := p.submit(t, "Pancakes: mix flour with milk and fry")
+
+	// Assert
+	p.recorder.WaitHandledBy(t, "archive-recipe-notion", domain.CommandTypeArchiveRecipeExport, id)
+	if len(exporter.archived) != 1 || exporter.archived[0] != "page-1" {
+		t.Errorf("expected the half-written page to be archived, got %v", exporter.archived)
+	}
+
+	if workflow := p.workflow(t, id); workflow.Status != recipe.WorkflowFailed || workflow.PendingCompensations != 0 {
+		t.Errorf("expected the workflow to fail once compensated, got %+v", workflow)
+	}
+	if resp := p.get(t, id); resp.Status != string(domain.StatusFailed) {
+		t.Errorf("expected status %q, got %q", domain.StatusFailed, resp.Status)
+	}
+}
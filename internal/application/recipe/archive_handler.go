@@ -0,0 +1,48 @@
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+)
+
+// ExportArchiver undoes an export, complete or partial, by archiving it
+type ExportArchiver interface {
+	Archive(ctx context.Context, ref domain.ExportReference) error
+}
+
+// ArchiveExportHandler archives exports on the process manager's
+// ArchiveRecipeExport command, compensating a failed workflow
+type ArchiveExportHandler struct {
+	archiver ExportArchiver
+	eventBus events.EventBus
+	logger   logger.Logger
+}
+
+// NewArchiveExportHandler creates a new export archiving handler
+func NewArchiveExportHandler(archiver ExportArchiver, eventBus events.EventBus, log logger.Logger) *ArchiveExportHandler {
+	return &ArchiveExportHandler{
+		archiver: archiver,
+		eventBus: eventBus,
+		logger:   log,
+	}
+}
+
+// Handle processes an ArchiveRecipeExport command
+func (h *ArchiveExportHandler) Handle(ctx context.Context, cmd *domain.ArchiveRecipeExport) error {
+	if err := h.archiver.Archive(ctx, cmd.Reference); err != nil {
+		return fmt.Errorf("failed to archive export %s of recipe %s: %w", cmd.Reference.PageID, cmd.RecipeID, err)
+	}
+
+	if err := h.eventBus.Publish(ctx, domain.NewRecipeExportArchived(cmd.RecipeID, cmd.Reference)); err != nil {
+		return fmt.Errorf("failed to publish export archived event: %w", err)
+	}
+
+	h.logger.Info("Recipe export archived",
+		logger.String("recipe_id", cmd.RecipeID),
+		logger.String("page_id", cmd.Reference.PageID),
+	)
+	return nil
+}
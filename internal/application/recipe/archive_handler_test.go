@@ -0,0 +1,57 @@
+package recipe_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/logger"
+	"testing"
+)
+
+// failingArchiver is an ExportArchiver that always fails
+type failingArchiver struct{ err error }
+
+func (a failingArchiver) Archive(ctx context.Context, ref domain.ExportReference) error { return a.err }
+
+func TestArchiveExportHandler_Handle_PublishesArchivedEvent(t *testing.T) {
+	// Arrange
+	mockBus := &mockEventBus{}
+	archiver := &archivingExporter{}
+	handler := recipe.NewArchiveExportHandler(archiver, mockBus, logger.NewNoopLogger())
+	ref := domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewArchiveRecipeExport("recipe-1", ref))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(archiver.archived) != 1 || archiver.archived[0] != ref {
+		t.Errorf("Expected %+v to be archived, got %+v", ref, archiver.archived)
+	}
+
+	archived, ok := mockBus.lastEvent.(*domain.RecipeExportArchived)
+	if !ok || archived.Reference != ref {
+		t.Errorf("Expected RecipeExportArchived event for %+v, got %+v", ref, mockBus.lastEvent)
+	}
+}
+
+func TestArchiveExportHandler_Handle_ArchiverError(t *testing.T) {
+	// Arrange
+	archiveErr := errors.New("notion unavailable")
+	mockBus := &mockEventBus{}
+	handler := recipe.NewArchiveExportHandler(failingArchiver{err: archiveErr}, mockBus, logger.NewNoopLogger())
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewArchiveRecipeExport("recipe-1", domain.ExportReference{PageID: "page-1"}))
+
+	// Assert
+	if !errors.Is(err, archiveErr) {
+		t.Fatalf("Expected error to wrap archiver error, got: %v", err)
+	}
+	if mockBus.lastEvent != nil {
+		t.Errorf("Expected no event, got %T", mockBus.lastEvent)
+	}
+}
@@ -0,0 +1,39 @@
+package recipe
+
+import (
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+)
+
+// RegisterEvents adds the recipe events and commands to the registry so they
+// can be stored and published outside the process
+func RegisterEvents(registry *events.Registry) error {
+	for _, newEvent := range []func() events.Event{
+		func() events.Event { return &domain.RecipeSubmitted{} },
+		func() events.Event { return &domain.RecipeParsed{} },
+		func() events.Event { return &domain.RecipeExported{} },
+		func() events.Event { return &domain.RecipeProcessingFailed{} },
+		func() events.Event { return &domain.RecipeNotified{} },
+		func() events.Event { return &domain.RecipeExportArchived{} },
+		func() events.Event { return &domain.RecipeStepTimedOut{} },
+		func() events.Event { return &domain.ParseRecipe{} },
+		func() events.Event { return &domain.ExportRecipe{} },
+		func() events.Event { return &domain.NotifyRecipe{} },
+		func() events.Event { return &domain.ArchiveRecipeExport{} },
+	} {
+		if err := registry.Register(newEvent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewEventRegistry returns a registry holding the recipe events
+func NewEventRegistry() (*events.Registry, error) {
+	registry := events.NewRegistry()
+	if err := RegisterEvents(registry); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
@@ -0,0 +1,52 @@
+package recipe_test
+
+import (
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"testing"
+)
+
+func TestNewEventRegistry_RoundTripsRecipeEvents(t *testing.T) {
+	// Arrange
+	registry, err := recipe.NewEventRegistry()
+	if err != nil {
+		t.Fatalf("NewEventRegistry() error = %v", err)
+	}
+
+	evts := []events.Event{
+		domain.NewRecipeSubmitted("recipe-1", "Pancakes"),
+		domain.NewRecipeParsed("recipe-1", newTestRecipe(t, "recipe-1")),
+		domain.NewRecipeExported("recipe-1", domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}),
+		domain.NewRecipeProcessingFailed("recipe-1", domain.StatusParsing, "ollama unavailable"),
+		domain.NewRecipeNotified("recipe-1", domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}),
+		domain.NewRecipeExportArchived("recipe-1", domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}),
+		domain.NewRecipeStepTimedOut("recipe-1", domain.StepParse, 1),
+		domain.NewParseRecipe("recipe-1", "Pancakes"),
+		domain.NewExportRecipe("recipe-1", newTestRecipe(t, "recipe-1")),
+		domain.NewNotifyRecipe("recipe-1", domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}),
+		domain.NewArchiveRecipeExport("recipe-1", domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}),
+	}
+
+	for _, event := range evts {
+		t.Run(event.EventType(), func(t *testing.T) {
+			// Act
+			payload, version, err := registry.Encode(event)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			decoded, err := registry.Decode(event.EventType(), version, payload)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if decoded.EventType() != event.EventType() {
+				t.Errorf("Expected event type '%s', got '%s'", event.EventType(), decoded.EventType())
+			}
+			if !decoded.OccurredAt().Equal(event.OccurredAt()) {
+				t.Errorf("Expected occurred at %v, got %v", event.OccurredAt(), decoded.OccurredAt())
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"time"
+)
+
+// RecipeExporter writes a parsed recipe to an external destination
+// An export failing after it started writing returns a *domain.IncompleteExportError
+type RecipeExporter interface {
+	Export(ctx context.Context, recipe *domain.Recipe) (*domain.ExportReference, error)
+}
+
+// ExportRecipeHandler exports recipes on the process manager's ExportRecipe command
+type ExportRecipeHandler struct {
+	exporter   RecipeExporter
+	repository RecipeRepository
+	eventBus   events.EventBus
+	logger     logger.Logger
+}
+
+// NewExportRecipeHandler creates a new recipe export handler
+func NewExportRecipeHandler(exporter RecipeExporter, repository RecipeRepository, eventBus events.EventBus, log logger.Logger) *ExportRecipeHandler {
+	return &ExportRecipeHandler{
+		exporter:   exporter,
+		repository: repository,
+		eventBus:   eventBus,
+		logger:     log,
+	}
+}
+
+// Handle processes an ExportRecipe command
+// Subscribe it with events.SubscribeTyped
+func (h *ExportRecipeHandler) Handle(ctx context.Context, cmd *domain.ExportRecipe) error {
+	lifecycle, err := startStage(ctx, h.repository, cmd.RecipeID, (*domain.RecipeLifecycle).StartExporting)
+	if err != nil {
+		return err
+	}
+
+	ref, err := h.exporter.Export(ctx, cmd.Recipe)
+	if err != nil {
+		return failRecipe(ctx, h.repository, h.eventBus, h.logger, lifecycle,
+			fmt.Errorf("failed to export recipe %s: %w", cmd.RecipeID, err))
+	}
+
+	// The page exists from here on and a retry would write a second one, so
+	// later errors are permanent and report the page for the process manager
+	// to archive
+	if err := h.recordExport(ctx, lifecycle, *ref); err != nil {
+		return failRecipe(ctx, h.repository, h.eventBus, h.logger, lifecycle,
+			events.Permanent(&domain.IncompleteExportError{Reference: *ref, Err: err}))
+	}
+
+	h.logger.Info("Recipe exported successfully",
+		logger.String("recipe_id", cmd.RecipeID),
+		logger.String("page_id", ref.PageID),
+	)
+
+	return nil
+}
+
+// recordExport persists and announces an export that succeeded
+func (h *ExportRecipeHandler) recordExport(ctx context.Context, lifecycle *domain.RecipeLifecycle, ref domain.ExportReference) error {
+	if err := lifecycle.MarkExported(ref, time.Now()); err != nil {
+		return fmt.Errorf("recipe %s: %w", lifecycle.ID(), err)
+	}
+
+	if err := h.repository.Save(ctx, lifecycle); err != nil {
+		return fmt.Errorf("failed to save recipe %s: %w", lifecycle.ID(), err)
+	}
+
+	if err := h.eventBus.Publish(ctx, domain.NewRecipeExported(lifecycle.ID(), ref)); err != nil {
+		return fmt.Errorf("failed to publish exported event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package recipe_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/memory"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/events/eventstest"
+	"recipe-processor/internal/shared/logger"
+	"testing"
+	"time"
+)
+
+// mockRecipeExporter is a mock implementation of RecipeExporter
+type mockRecipeExporter struct {
+	exportFunc func(ctx context.Context, recipe *domain.Recipe) (*domain.ExportReference, error)
+}
+
+func (m *mockRecipeExporter) Export(ctx context.Context, recipe *domain.Recipe) (*domain.ExportReference, error) {
+	return m.exportFunc(ctx, recipe)
+}
+
+// newParsedRepository returns a repository holding one parsed recipe
+func newParsedRepository(t *testing.T, id string) *memory.RecipeRepository {
+	t.Helper()
+
+	repo := newSubmittedRepository(t, id)
+	lifecycle, _ := repo.FindByID(context.Background(), id)
+	_ = lifecycle.StartParsing(time.Now())
+	_ = lifecycle.MarkParsed(newTestRecipe(t, id), time.Now())
+	if err := repo.Save(context.Background(), lifecycle); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	return repo
+}
+
+func TestExportRecipeHandler_Handle_PublishesExportedEvent(t *testing.T) {
+	// Arrange
+	mockBus := &mockEventBus{}
+	repo := newParsedRepository(t, "recipe-1")
+	exporter := &mockRecipeExporter{
+		exportFunc: func(ctx context.Context, recipe *domain.Recipe) (*domain.ExportReference, error) {
+			return &domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}, nil
+		},
+	}
+	handler := recipe.NewExportRecipeHandler(exporter, repo, mockBus, logger.NewNoopLogger())
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewExportRecipe("recipe-1", newTestRecipe(t, "recipe-1")))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	exported, ok := mockBus.lastEvent.(*domain.RecipeExported)
+	if !ok {
+		t.Fatalf("Expected RecipeExported event, got %T", mockBus.lastEvent)
+	}
+
+	if exported.RecipeID != "recipe-1" {
+		t.Errorf("Expected recipe ID 'recipe-1', got '%s'", exported.RecipeID)
+	}
+
+	if exported.Reference.PageID != "page-1" {
+		t.Errorf("Expected page ID 'page-1', got '%s'", exported.Reference.PageID)
+	}
+
+	lifecycle, _ := repo.FindByID(context.Background(), "recipe-1")
+	if lifecycle.Status() != domain.StatusExported {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusExported, lifecycle.Status())
+	}
+
+	if lifecycle.ExportReference().URL != "https://notion.so/page-1" {
+		t.Errorf("Expected export URL to be stored, got '%s'", lifecycle.ExportReference().URL)
+	}
+}
+
+func TestExportRecipeHandler_Handle_ExporterError(t *testing.T) {
+	// Arrange
+	exportErr := errors.New("notion unavailable")
+	mockBus := &mockEventBus{}
+	repo := newParsedRepository(t, "recipe-1")
+	exporter := &mockRecipeExporter{
+		exportFunc: func(ctx context.Context, recipe *domain.Recipe) (*domain.ExportReference, error) {
+			return nil, exportErr
+		},
+	}
+	handler := recipe.NewExportRecipeHandler(exporter, repo, mockBus, logger.NewNoopLogger())
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewExportRecipe("recipe-1", newTestRecipe(t, "recipe-1")))
+
+	// Assert
+	if !errors.Is(err, exportErr) {
+		t.Fatalf("Expected error to wrap exporter error, got: %v", err)
+	}
+
+	if _, ok := mockBus.lastEvent.(*domain.RecipeProcessingFailed); !ok {
+		t.Errorf("Expected RecipeProcessingFailed event, got %T", mockBus.lastEvent)
+	}
+
+	lifecycle, _ := repo.FindByID(context.Background(), "recipe-1")
+	if lifecycle.Status() != domain.StatusFailed {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusFailed, lifecycle.Status())
+	}
+}
+
+func TestExportRecipeHandler_Handle_IncompleteExportReportsPartialPage(t *testing.T) {
+	// Arrange
+	mockBus := &mockEventBus{}
+	repo := newParsedRepository(t, "recipe-1")
+	partial := domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}
+	exporter := &mockRecipeExporter{
+		exportFunc: func(ctx context.Context, recipe *domain.Recipe) (*domain.ExportReference, error) {
+			return nil, &domain.IncompleteExportError{Reference: partial, Err: errors.New("append failed")}
+		},
+	}
+	handler := recipe.NewExportRecipeHandler(exporter, repo, mockBus, logger.NewNoopLogger())
+	ctx := events.WithHandlerInfo(context.Background(), events.HandlerInfo{Attempt: 1, MaxAttempts: 3})
+
+	// Act
+	err := handler.Handle(ctx, domain.NewExportRecipe("recipe-1", newTestRecipe(t, "recipe-1")))
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	failed, ok := mockBus.lastEvent.(*domain.RecipeProcessingFailed)
+	if !ok {
+		t.Fatalf("Expected RecipeProcessingFailed event, got %T", mockBus.lastEvent)
+	}
+	if failed.PartialExport == nil || *failed.PartialExport != partial {
+		t.Errorf("Expected partial export %+v, got %+v", partial, failed.PartialExport)
+	}
+	if !failed.WillRetry {
+		t.Error("Expected the failure to be retried")
+	}
+}
+
+// exportedSaveFailingRepository fails to save exported recipes
+type exportedSaveFailingRepository struct {
+	*memory.RecipeRepository
+	err error
+}
+
+func (r *exportedSaveFailingRepository) Save(ctx context.Context, lifecycle *domain.RecipeLifecycle) error {
+	if lifecycle.Status() == domain.StatusExported {
+		return r.err
+	}
+	return r.RecipeRepository.Save(ctx, lifecycle)
+}
+
+func TestExportRecipeHandler_Handle_SaveErrorAfterExportIsNotRetried(t *testing.T) {
+	// Arrange
+	saveErr := errors.New("database is locked")
+	repo := &exportedSaveFailingRepository{RecipeRepository: newParsedRepository(t, "recipe-1"), err: saveErr}
+	page := domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}
+	exports := 0
+	exporter := &mockRecipeExporter{
+		exportFunc: func(ctx context.Context, recipe *domain.Recipe) (*domain.ExportReference, error) {
+			exports++
+			return &page, nil
+		},
+	}
+
+	recorder := eventstest.NewRecorder()
+	bus := events.NewSyncEventBusWithConfig(logger.NewNoopLogger(), events.SyncConfig{
+		RetryPolicy: events.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		Store:       recorder,
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	handler := recipe.NewExportRecipeHandler(exporter, repo, bus, logger.NewNoopLogger())
+	if _, err := events.SubscribeTyped(bus, "export-recipe", handler.Handle); err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+
+	// Act
+	err := bus.Publish(context.Background(), domain.NewExportRecipe("recipe-1", newTestRecipe(t, "recipe-1")))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if exports != 1 {
+		t.Errorf("Expected 1 export, got %d", exports)
+	}
+
+	failed := recorder.AssertPublished(t, domain.EventTypeRecipeFailed, "recipe-1").Event.(*domain.RecipeProcessingFailed)
+	if failed.WillRetry {
+		t.Error("Expected the failure not to be retried")
+	}
+	if failed.PartialExport == nil || *failed.PartialExport != page {
+		t.Errorf("Expected page %+v to be reported for archiving, got %+v", page, failed.PartialExport)
+	}
+
+	lifecycle, _ := repo.FindByID(context.Background(), "recipe-1")
+	if lifecycle.Status() != domain.StatusFailed {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusFailed, lifecycle.Status())
+	}
+}
@@ -0,0 +1,40 @@
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"recipe-processor/internal/domain"
+)
+
+// GetRecipeQuery represents the input for looking up a recipe
+type GetRecipeQuery struct {
+	RecipeID string
+}
+
+type RecipeGetter interface {
+	Execute(ctx context.Context, query GetRecipeQuery) (*domain.RecipeLifecycle, error)
+}
+
+// GetRecipeService looks up the current processing state of a recipe
+type GetRecipeService struct {
+	repository RecipeRepository
+}
+
+// NewGetRecipeService creates a new recipe lookup service
+func NewGetRecipeService(repository RecipeRepository) *GetRecipeService {
+	return &GetRecipeService{
+		repository: repository,
+	}
+}
+
+// Execute returns the recipe lifecycle for the given ID
+func (s *GetRecipeService) Execute(ctx context.Context, query GetRecipeQuery) (*domain.RecipeLifecycle, error) {
+	lifecycle, err := s.repository.FindByID(ctx, query.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recipe %s: %w", query.RecipeID, err)
+	}
+
+	return lifecycle, nil
+}
+
+var _ RecipeGetter = (*GetRecipeService)(nil)
@@ -0,0 +1,43 @@
+package recipe_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"testing"
+)
+
+func TestGetRecipeService_Execute_Found(t *testing.T) {
+	// Arrange
+	service := recipe.NewGetRecipeService(newSubmittedRepository(t, "recipe-1"))
+
+	// Act
+	lifecycle, err := service.Execute(context.Background(), recipe.GetRecipeQuery{RecipeID: "recipe-1"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if lifecycle.Status() != domain.StatusSubmitted {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusSubmitted, lifecycle.Status())
+	}
+}
+
+func TestGetRecipeService_Execute_NotFound(t *testing.T) {
+	// Arrange
+	service := recipe.NewGetRecipeService(newRepository(t))
+
+	// Act
+	lifecycle, err := service.Execute(context.Background(), recipe.GetRecipeQuery{RecipeID: "missing"})
+
+	// Assert
+	if !errors.Is(err, recipe.ErrRecipeNotFound) {
+		t.Fatalf("Expected ErrRecipeNotFound, got: %v", err)
+	}
+
+	if lifecycle != nil {
+		t.Error("Expected nil lifecycle on error")
+	}
+}
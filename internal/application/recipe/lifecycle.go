@@ -0,0 +1,92 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"time"
+)
+
+// failRecipe marks the lifecycle as failed, persists it and announces the failure
+// The original cause is always returned so the event bus sees the handler error
+func failRecipe(
+	ctx context.Context,
+	repository RecipeRepository,
+	eventBus events.EventBus,
+	log logger.Logger,
+	lifecycle *domain.RecipeLifecycle,
+	cause error,
+) error {
+	stage := lifecycle.Status()
+
+	if err := lifecycle.Fail(cause.Error(), time.Now()); err != nil {
+		log.Error("Failed to mark recipe as failed",
+			logger.String("recipe_id", lifecycle.ID()),
+			logger.Error(err),
+		)
+		return cause
+	}
+
+	if err := repository.Save(ctx, lifecycle); err != nil {
+		log.Error("Failed to save failed recipe",
+			logger.String("recipe_id", lifecycle.ID()),
+			logger.Error(err),
+		)
+		return cause
+	}
+
+	if err := eventBus.Publish(ctx, processingFailed(ctx, lifecycle.ID(), stage, cause)); err != nil {
+		log.Error("Failed to publish recipe failed event",
+			logger.String("recipe_id", lifecycle.ID()),
+			logger.Error(err),
+		)
+	}
+
+	return cause
+}
+
+// processingFailed builds the failure event of a handler failing with cause
+// It tells whether the bus retries the handler and which partial export the
+// failure left behind, so the process manager knows what to undo
+func processingFailed(ctx context.Context, recipeID string, stage domain.RecipeStatus, cause error) *domain.RecipeProcessingFailed {
+	failed := domain.NewRecipeProcessingFailed(recipeID, stage, cause.Error())
+	failed.WillRetry = events.WillRetry(ctx, cause)
+
+	var incomplete *domain.IncompleteExportError
+	if errors.As(cause, &incomplete) {
+		ref := incomplete.Reference
+		failed.PartialExport = &ref
+	}
+
+	return failed
+}
+
+// startStage loads the lifecycle and persists the transition into a processing stage
+// Missing recipes and out-of-order events are permanent failures, retrying cannot fix them
+func startStage(
+	ctx context.Context,
+	repository RecipeRepository,
+	recipeID string,
+	start func(l *domain.RecipeLifecycle, at time.Time) error,
+) (*domain.RecipeLifecycle, error) {
+	lifecycle, err := repository.FindByID(ctx, recipeID)
+	if errors.Is(err, ErrRecipeNotFound) {
+		return nil, events.Permanent(fmt.Errorf("failed to load recipe %s: %w", recipeID, err))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recipe %s: %w", recipeID, err)
+	}
+
+	if err := start(lifecycle, time.Now()); err != nil {
+		return nil, events.Permanent(fmt.Errorf("recipe %s: %w", recipeID, err))
+	}
+
+	if err := repository.Save(ctx, lifecycle); err != nil {
+		return nil, fmt.Errorf("failed to save recipe %s: %w", recipeID, err)
+	}
+
+	return lifecycle, nil
+}
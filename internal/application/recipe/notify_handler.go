@@ -0,0 +1,53 @@
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+)
+
+// RecipeNotifier tells someone that a recipe was exported
+type RecipeNotifier interface {
+	Notify(ctx context.Context, recipeID string, ref domain.ExportReference) error
+}
+
+// NotifyRecipeHandler sends notifications on the process manager's NotifyRecipe command
+type NotifyRecipeHandler struct {
+	notifier RecipeNotifier
+	eventBus events.EventBus
+	logger   logger.Logger
+}
+
+// NewNotifyRecipeHandler creates a new recipe notification handler
+func NewNotifyRecipeHandler(notifier RecipeNotifier, eventBus events.EventBus, log logger.Logger) *NotifyRecipeHandler {
+	return &NotifyRecipeHandler{
+		notifier: notifier,
+		eventBus: eventBus,
+		logger:   log,
+	}
+}
+
+// Handle processes a NotifyRecipe command
+// A failed notification leaves the lifecycle alone; the process manager
+// decides what to undo once the failure is final
+func (h *NotifyRecipeHandler) Handle(ctx context.Context, cmd *domain.NotifyRecipe) error {
+	if err := h.notifier.Notify(ctx, cmd.RecipeID, cmd.Reference); err != nil {
+		cause := fmt.Errorf("failed to notify about recipe %s: %w", cmd.RecipeID, err)
+		if err := h.eventBus.Publish(ctx, processingFailed(ctx, cmd.RecipeID, domain.StatusExported, cause)); err != nil {
+			h.logger.Error("Failed to publish recipe failed event",
+				logger.String("recipe_id", cmd.RecipeID),
+				logger.Error(err),
+			)
+		}
+		return cause
+	}
+
+	if err := h.eventBus.Publish(ctx, domain.NewRecipeNotified(cmd.RecipeID, cmd.Reference)); err != nil {
+		return fmt.Errorf("failed to publish notified event: %w", err)
+	}
+
+	h.logger.Info("Recipe notification sent", logger.String("recipe_id", cmd.RecipeID))
+	return nil
+}
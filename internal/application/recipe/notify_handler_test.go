@@ -0,0 +1,60 @@
+package recipe_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/logger"
+	"testing"
+)
+
+func TestNotifyRecipeHandler_Handle_PublishesNotifiedEvent(t *testing.T) {
+	// Arrange
+	mockBus := &mockEventBus{}
+	ref := domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}
+	var got domain.ExportReference
+	handler := recipe.NewNotifyRecipeHandler(notifierFunc(func(ctx context.Context, recipeID string, r domain.ExportReference) error {
+		got = r
+		return nil
+	}), mockBus, logger.NewNoopLogger())
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewNotifyRecipe("recipe-1", ref))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got != ref {
+		t.Errorf("Expected notification about %+v, got %+v", ref, got)
+	}
+	if _, ok := mockBus.lastEvent.(*domain.RecipeNotified); !ok {
+		t.Errorf("Expected RecipeNotified event, got %T", mockBus.lastEvent)
+	}
+}
+
+func TestNotifyRecipeHandler_Handle_NotifierError(t *testing.T) {
+	// Arrange
+	notifyErr := errors.New("webhook unavailable")
+	mockBus := &mockEventBus{}
+	handler := recipe.NewNotifyRecipeHandler(notifierFunc(func(ctx context.Context, recipeID string, r domain.ExportReference) error {
+		return notifyErr
+	}), mockBus, logger.NewNoopLogger())
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewNotifyRecipe("recipe-1", domain.ExportReference{PageID: "page-1"}))
+
+	// Assert
+	if !errors.Is(err, notifyErr) {
+		t.Fatalf("Expected error to wrap notifier error, got: %v", err)
+	}
+
+	failed, ok := mockBus.lastEvent.(*domain.RecipeProcessingFailed)
+	if !ok {
+		t.Fatalf("Expected RecipeProcessingFailed event, got %T", mockBus.lastEvent)
+	}
+	if failed.Stage != domain.StatusExported || failed.WillRetry {
+		t.Errorf("Expected a final failure after export, got %+v", failed)
+	}
+}
@@ -0,0 +1,70 @@
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"time"
+)
+
+// RecipeParser extracts a structured recipe from free text
+type RecipeParser interface {
+	Parse(ctx context.Context, recipeID, text string) (*domain.Recipe, error)
+}
+
+// ParseRecipeHandler parses recipes on the process manager's ParseRecipe command
+type ParseRecipeHandler struct {
+	parser     RecipeParser
+	repository RecipeRepository
+	eventBus   events.EventBus
+	logger     logger.Logger
+}
+
+// NewParseRecipeHandler creates a new recipe parsing handler
+func NewParseRecipeHandler(parser RecipeParser, repository RecipeRepository, eventBus events.EventBus, log logger.Logger) *ParseRecipeHandler {
+	return &ParseRecipeHandler{
+		parser:     parser,
+		repository: repository,
+		eventBus:   eventBus,
+		logger:     log,
+	}
+}
+
+// Handle processes a ParseRecipe command
+// Subscribe it with events.SubscribeTyped
+func (h *ParseRecipeHandler) Handle(ctx context.Context, cmd *domain.ParseRecipe) error {
+	lifecycle, err := startStage(ctx, h.repository, cmd.RecipeID, (*domain.RecipeLifecycle).StartParsing)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := h.parser.Parse(ctx, cmd.RecipeID, cmd.RecipeText)
+	if err != nil {
+		return failRecipe(ctx, h.repository, h.eventBus, h.logger, lifecycle,
+			fmt.Errorf("failed to parse recipe %s: %w", cmd.RecipeID, err))
+	}
+
+	if err := lifecycle.MarkParsed(parsed, time.Now()); err != nil {
+		return failRecipe(ctx, h.repository, h.eventBus, h.logger, lifecycle,
+			fmt.Errorf("recipe %s: %w", cmd.RecipeID, err))
+	}
+
+	if err := h.repository.Save(ctx, lifecycle); err != nil {
+		return fmt.Errorf("failed to save recipe %s: %w", cmd.RecipeID, err)
+	}
+
+	if err := h.eventBus.Publish(ctx, domain.NewRecipeParsed(cmd.RecipeID, parsed)); err != nil {
+		return fmt.Errorf("failed to publish parsed event: %w", err)
+	}
+
+	h.logger.Info("Recipe parsed successfully",
+		logger.String("recipe_id", cmd.RecipeID),
+		logger.String("title", parsed.Title()),
+		logger.Int("ingredients", len(parsed.Ingredients())),
+		logger.Int("steps", len(parsed.Steps())),
+	)
+
+	return nil
+}
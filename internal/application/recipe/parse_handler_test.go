@@ -0,0 +1,162 @@
+package recipe_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/memory"
+	"recipe-processor/internal/shared/logger"
+	"testing"
+	"time"
+)
+
+// mockRecipeParser is a mock implementation of RecipeParser
+type mockRecipeParser struct {
+	parseFunc func(ctx context.Context, recipeID, text string) (*domain.Recipe, error)
+}
+
+func (m *mockRecipeParser) Parse(ctx context.Context, recipeID, text string) (*domain.Recipe, error) {
+	return m.parseFunc(ctx, recipeID, text)
+}
+
+// newTestRecipe builds a minimal valid recipe aggregate
+func newTestRecipe(t *testing.T, id string) *domain.Recipe {
+	t.Helper()
+
+	flour, _ := domain.NewIngredient(domain.IngredientParams{Name: "flour"})
+	mix, _ := domain.NewStep(domain.StepParams{Text: "mix"})
+
+	r, err := domain.NewRecipe(domain.RecipeParams{
+		ID:          id,
+		Title:       "Pancakes",
+		Servings:    2,
+		Ingredients: []domain.Ingredient{*flour},
+		Steps:       []domain.Step{*mix},
+	})
+	if err != nil {
+		t.Fatalf("NewRecipe() error = %v", err)
+	}
+
+	return r
+}
+
+// newRepository returns an empty repository encoding the recipe events
+func newRepository(t *testing.T) *memory.RecipeRepository {
+	t.Helper()
+
+	registry, err := recipe.NewEventRegistry()
+	if err != nil {
+		t.Fatalf("NewEventRegistry() error = %v", err)
+	}
+	return memory.NewRecipeRepository(registry)
+}
+
+// newSubmittedRepository returns a repository holding one submitted recipe
+func newSubmittedRepository(t *testing.T, id string) *memory.RecipeRepository {
+	t.Helper()
+
+	repo := newRepository(t)
+	text, _ := domain.NewRecipeText("Pancakes...")
+	lifecycle, _ := domain.NewRecipeLifecycle(id, text, time.Now())
+	if err := repo.Save(context.Background(), lifecycle); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	return repo
+}
+
+func TestParseRecipeHandler_Handle_PublishesParsedEvent(t *testing.T) {
+	// Arrange
+	mockBus := &mockEventBus{}
+	repo := newSubmittedRepository(t, "recipe-1")
+	parser := &mockRecipeParser{
+		parseFunc: func(ctx context.Context, recipeID, text string) (*domain.Recipe, error) {
+			return newTestRecipe(t, recipeID), nil
+		},
+	}
+	handler := recipe.NewParseRecipeHandler(parser, repo, mockBus, logger.NewNoopLogger())
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewParseRecipe("recipe-1", "Pancakes..."))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	parsed, ok := mockBus.lastEvent.(*domain.RecipeParsed)
+	if !ok {
+		t.Fatalf("Expected RecipeParsed event, got %T", mockBus.lastEvent)
+	}
+
+	if parsed.RecipeID != "recipe-1" {
+		t.Errorf("Expected recipe ID 'recipe-1', got '%s'", parsed.RecipeID)
+	}
+
+	if parsed.Recipe.Title() != "Pancakes" {
+		t.Errorf("Expected title 'Pancakes', got '%s'", parsed.Recipe.Title())
+	}
+
+	lifecycle, _ := repo.FindByID(context.Background(), "recipe-1")
+	if lifecycle.Status() != domain.StatusParsed {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusParsed, lifecycle.Status())
+	}
+
+	if lifecycle.Recipe() == nil {
+		t.Error("Expected parsed recipe to be stored")
+	}
+}
+
+func TestParseRecipeHandler_Handle_ParserError(t *testing.T) {
+	// Arrange
+	parseErr := errors.New("ollama unavailable")
+	mockBus := &mockEventBus{}
+	repo := newSubmittedRepository(t, "recipe-1")
+	parser := &mockRecipeParser{
+		parseFunc: func(ctx context.Context, recipeID, text string) (*domain.Recipe, error) {
+			return nil, parseErr
+		},
+	}
+	handler := recipe.NewParseRecipeHandler(parser, repo, mockBus, logger.NewNoopLogger())
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewParseRecipe("recipe-1", "Pancakes..."))
+
+	// Assert
+	if !errors.Is(err, parseErr) {
+		t.Fatalf("Expected error to wrap parser error, got: %v", err)
+	}
+
+	failed, ok := mockBus.lastEvent.(*domain.RecipeProcessingFailed)
+	if !ok {
+		t.Fatalf("Expected RecipeProcessingFailed event, got %T", mockBus.lastEvent)
+	}
+
+	if failed.Stage != domain.StatusParsing {
+		t.Errorf("Expected failed stage '%s', got '%s'", domain.StatusParsing, failed.Stage)
+	}
+
+	lifecycle, _ := repo.FindByID(context.Background(), "recipe-1")
+	if lifecycle.Status() != domain.StatusFailed {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusFailed, lifecycle.Status())
+	}
+}
+
+func TestParseRecipeHandler_Handle_UnknownRecipe(t *testing.T) {
+	// Arrange
+	mockBus := &mockEventBus{}
+	handler := recipe.NewParseRecipeHandler(&mockRecipeParser{}, newRepository(t), mockBus, logger.NewNoopLogger())
+
+	// Act
+	err := handler.Handle(context.Background(), domain.NewParseRecipe("missing", "Pancakes..."))
+
+	// Assert
+	if !errors.Is(err, recipe.ErrRecipeNotFound) {
+		t.Fatalf("Expected ErrRecipeNotFound, got: %v", err)
+	}
+
+	if mockBus.publishCalled {
+		t.Error("Expected Publish not to be called for unknown recipe")
+	}
+}
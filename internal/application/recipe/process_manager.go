@@ -0,0 +1,536 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"recipe-processor/internal/shared/scheduler"
+	"time"
+)
+
+const (
+	DefaultParseTimeout  = 10 * time.Minute
+	DefaultExportTimeout = 5 * time.Minute
+	DefaultNotifyTimeout = 2 * time.Minute
+)
+
+// TimeoutScheduler publishes step timeouts later, see scheduler.Scheduler
+type TimeoutScheduler interface {
+	PublishAt(ctx context.Context, event events.Event, at time.Time) (string, error)
+	// Cancel returns scheduler.ErrNotFound once the event was published
+	Cancel(ctx context.Context, id string) error
+}
+
+// RecipeValidator checks a parsed recipe before it is exported
+type RecipeValidator interface {
+	Validate(ctx context.Context, recipe *domain.Recipe) error
+}
+
+type ProcessManagerConfig struct {
+	// ParseTimeout, ExportTimeout and NotifyTimeout bound how long each step
+	// may take, retries included; zero values use the defaults
+	ParseTimeout  time.Duration
+	ExportTimeout time.Duration
+	NotifyTimeout time.Duration
+	// Validator checks parsed recipes; nil accepts every recipe
+	Validator RecipeValidator
+	// Export and Notify enable their steps, whose handlers must be subscribed
+	Export bool
+	Notify bool
+	// Archive undoes exports of failed workflows; the ArchiveRecipeExport
+	// handler must be subscribed
+	Archive bool
+}
+
+// RecipeProcessManager drives each submitted recipe through parse, validate,
+// export and notify
+//
+// It keeps a Workflow per recipe, sends the command of the next step when one
+// finishes and fails the workflow when a step fails for good or outlives its
+// timeout. A failed workflow archives the pages its exports wrote, so Notion
+// never keeps half-written or orphaned recipes
+type RecipeProcessManager struct {
+	workflows  WorkflowStore
+	repository RecipeRepository
+	eventBus   events.EventBus
+	timeouts   TimeoutScheduler
+	logger     logger.Logger
+	cfg        ProcessManagerConfig
+}
+
+// NewRecipeProcessManager creates a process manager; call Subscribe to start it
+func NewRecipeProcessManager(
+	workflows WorkflowStore,
+	repository RecipeRepository,
+	eventBus events.EventBus,
+	timeouts TimeoutScheduler,
+	log logger.Logger,
+	cfg ProcessManagerConfig,
+) *RecipeProcessManager {
+	if cfg.ParseTimeout <= 0 {
+		cfg.ParseTimeout = DefaultParseTimeout
+	}
+	if cfg.ExportTimeout <= 0 {
+		cfg.ExportTimeout = DefaultExportTimeout
+	}
+	if cfg.NotifyTimeout <= 0 {
+		cfg.NotifyTimeout = DefaultNotifyTimeout
+	}
+
+	return &RecipeProcessManager{
+		workflows:  workflows,
+		repository: repository,
+		eventBus:   eventBus,
+		timeouts:   timeouts,
+		logger:     log,
+		cfg:        cfg,
+	}
+}
+
+// Subscribe subscribes the process manager to the recipe events
+func (pm *RecipeProcessManager) Subscribe() error {
+	subscribe := []func() error{
+		func() error {
+			_, err := events.SubscribeTyped(pm.eventBus, "recipe-workflow-submitted", pm.HandleSubmitted)
+			return err
+		},
+		func() error {
+			_, err := events.SubscribeTyped(pm.eventBus, "recipe-workflow-parsed", pm.HandleParsed)
+			return err
+		},
+		func() error {
+			_, err := events.SubscribeTyped(pm.eventBus, "recipe-workflow-exported", pm.HandleExported)
+			return err
+		},
+		func() error {
+			_, err := events.SubscribeTyped(pm.eventBus, "recipe-workflow-notified", pm.HandleNotified)
+			return err
+		},
+		func() error {
+			_, err := events.SubscribeTyped(pm.eventBus, "recipe-workflow-failed", pm.HandleFailed)
+			return err
+		},
+		func() error {
+			_, err := events.SubscribeTyped(pm.eventBus, "recipe-workflow-timed-out", pm.HandleTimedOut)
+			return err
+		},
+		func() error {
+			_, err := events.SubscribeTyped(pm.eventBus, "recipe-workflow-archived", pm.HandleExportArchived)
+			return err
+		},
+	}
+
+	for _, s := range subscribe {
+		if err := s(); err != nil {
+			return fmt.Errorf("failed to subscribe recipe process manager: %w", err)
+		}
+	}
+	return nil
+}
+
+// HandleSubmitted starts a workflow, or a new run of a finished one
+// Submissions of a running workflow are redeliveries and ignored
+func (pm *RecipeProcessManager) HandleSubmitted(ctx context.Context, submitted *domain.RecipeSubmitted) error {
+	now := time.Now()
+
+	workflow, err := pm.workflows.Find(ctx, submitted.RecipeID)
+	switch {
+	case errors.Is(err, ErrWorkflowNotFound):
+		workflow = &Workflow{RecipeID: submitted.RecipeID}
+	case err != nil:
+		return fmt.Errorf("failed to load workflow of recipe %s: %w", submitted.RecipeID, err)
+	case workflow.Active():
+		pm.logger.Debug("Ignoring submission of a running workflow", logger.String("recipe_id", submitted.RecipeID))
+		return nil
+	}
+
+	// Finished workflows have no timeout left, only the run and version carry over
+	*workflow = Workflow{
+		RecipeID:  submitted.RecipeID,
+		Run:       workflow.Run + 1,
+		StartedAt: now,
+		Version:   workflow.Version,
+	}
+	return pm.startStep(ctx, workflow, domain.StepParse, domain.NewParseRecipe(submitted.RecipeID, submitted.RecipeText))
+}
+
+// HandleParsed validates the parsed recipe and starts its export
+func (pm *RecipeProcessManager) HandleParsed(ctx context.Context, parsed *domain.RecipeParsed) error {
+	workflow, err := pm.find(ctx, parsed.RecipeID)
+	if workflow == nil {
+		return err
+	}
+
+	if !pm.expects(workflow, domain.StepParse) {
+		// A parse that finished after its workflow failed moved the
+		// lifecycle out of failed again
+		if workflow.Status == WorkflowFailed || workflow.Status == WorkflowCompensating {
+			failed, err := pm.failLifecycle(ctx, workflow.RecipeID, workflow.FailureReason)
+			if err != nil {
+				return err
+			}
+			pm.publish(ctx, workflow.RecipeID, failed...)
+		}
+		return nil
+	}
+
+	if pm.cfg.Validator != nil {
+		if err := pm.cfg.Validator.Validate(ctx, parsed.Recipe); err != nil {
+			workflow.Step = domain.StepValidate
+			return pm.fail(ctx, workflow, fmt.Sprintf("recipe %s failed validation: %v", parsed.RecipeID, err))
+		}
+	}
+
+	if !pm.cfg.Export {
+		return pm.complete(ctx, workflow, domain.StepValidate)
+	}
+	return pm.startStep(ctx, workflow, domain.StepExport, domain.NewExportRecipe(parsed.RecipeID, parsed.Recipe))
+}
+
+// HandleExported records the page and starts the notification
+func (pm *RecipeProcessManager) HandleExported(ctx context.Context, exported *domain.RecipeExported) error {
+	workflow, err := pm.find(ctx, exported.RecipeID)
+	if workflow == nil {
+		return err
+	}
+
+	if !pm.expects(workflow, domain.StepExport) {
+		// An export that finished after its workflow failed left a page
+		// nobody tracks; undo it like the rest of the workflow
+		late := workflow.Export == nil || *workflow.Export != exported.Reference
+		if late && (workflow.Status == WorkflowFailed || workflow.Status == WorkflowCompensating) {
+			failed, err := pm.failLifecycle(ctx, workflow.RecipeID, workflow.FailureReason)
+			if err != nil {
+				return err
+			}
+			if err := pm.compensate(ctx, workflow, exported.Reference); err != nil {
+				return err
+			}
+			pm.publish(ctx, workflow.RecipeID, failed...)
+		}
+		return nil
+	}
+
+	ref := exported.Reference
+	workflow.Export = &ref
+
+	if !pm.cfg.Notify {
+		return pm.complete(ctx, workflow, domain.StepExport)
+	}
+	return pm.startStep(ctx, workflow, domain.StepNotify, domain.NewNotifyRecipe(exported.RecipeID, ref))
+}
+
+// HandleNotified completes the workflow
+func (pm *RecipeProcessManager) HandleNotified(ctx context.Context, notified *domain.RecipeNotified) error {
+	workflow, err := pm.find(ctx, notified.RecipeID)
+	if workflow == nil {
+		return err
+	}
+
+	if !pm.expects(workflow, domain.StepNotify) {
+		return nil
+	}
+	return pm.complete(ctx, workflow, domain.StepNotify)
+}
+
+// HandleFailed fails the workflow once its current step failed for good
+// Partial exports are archived right away, even when the export is retried,
+// since the retry writes a new page
+func (pm *RecipeProcessManager) HandleFailed(ctx context.Context, failed *domain.RecipeProcessingFailed) error {
+	workflow, err := pm.find(ctx, failed.RecipeID)
+	if workflow == nil {
+		return err
+	}
+
+	if failed.PartialExport != nil {
+		if err := pm.compensate(ctx, workflow, *failed.PartialExport); err != nil {
+			return err
+		}
+	}
+
+	if failed.WillRetry || !pm.expects(workflow, stepOf(failed.Stage)) {
+		return nil
+	}
+	return pm.fail(ctx, workflow, failed.Reason)
+}
+
+// HandleTimedOut fails the workflow when the timed out step is still running
+func (pm *RecipeProcessManager) HandleTimedOut(ctx context.Context, timedOut *domain.RecipeStepTimedOut) error {
+	workflow, err := pm.find(ctx, timedOut.RecipeID)
+	if workflow == nil {
+		return err
+	}
+
+	if workflow.Run != timedOut.Run || !pm.expects(workflow, timedOut.Step) || time.Now().Before(workflow.StepDeadline) {
+		return nil
+	}
+
+	workflow.TimeoutID = ""
+	return pm.fail(ctx, workflow, fmt.Sprintf("%s step of recipe %s timed out after %s",
+		timedOut.Step, timedOut.RecipeID, pm.timeout(timedOut.Step)))
+}
+
+// HandleExportArchived counts the confirmed compensation
+func (pm *RecipeProcessManager) HandleExportArchived(ctx context.Context, archived *domain.RecipeExportArchived) error {
+	workflow, err := pm.find(ctx, archived.RecipeID)
+	if workflow == nil || workflow.PendingCompensations == 0 {
+		return err
+	}
+
+	workflow.PendingCompensations--
+	if workflow.Status == WorkflowCompensating && workflow.PendingCompensations == 0 {
+		workflow.Status = WorkflowFailed
+	}
+	workflow.UpdatedAt = time.Now()
+
+	if err := pm.save(ctx, workflow); err != nil {
+		return err
+	}
+
+	if workflow.Status == WorkflowFailed {
+		pm.logger.Info("Recipe workflow compensated",
+			logger.String("recipe_id", workflow.RecipeID),
+			logger.Int("run", workflow.Run),
+		)
+	}
+	return nil
+}
+
+// find loads the workflow of a recipe
+// Recipes without a workflow, submitted before the process manager ran,
+// return a nil workflow and no error
+func (pm *RecipeProcessManager) find(ctx context.Context, recipeID string) (*Workflow, error) {
+	workflow, err := pm.workflows.Find(ctx, recipeID)
+	if errors.Is(err, ErrWorkflowNotFound) {
+		pm.logger.Debug("Ignoring event of a recipe without workflow", logger.String("recipe_id", recipeID))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow of recipe %s: %w", recipeID, err)
+	}
+	return workflow, nil
+}
+
+// expects reports whether the workflow waits for the given step
+// Anything else is a redelivery or arrived after the workflow moved on
+func (pm *RecipeProcessManager) expects(workflow *Workflow, step domain.ProcessingStep) bool {
+	return workflow.Active() && workflow.Step == step
+}
+
+// startStep schedules the step's timeout, saves the workflow and sends the
+// step's command
+// A command that cannot be published is only logged: the workflow already
+// waits for the step, whose timeout then fails it
+func (pm *RecipeProcessManager) startStep(ctx context.Context, workflow *Workflow, step domain.ProcessingStep, cmd events.Event) error {
+	now := time.Now()
+	previousTimeout := workflow.TimeoutID
+
+	workflow.Status = WorkflowRunning
+	workflow.Step = step
+	workflow.StepDeadline = now.Add(pm.timeout(step))
+	workflow.UpdatedAt = now
+
+	timeoutID, err := pm.timeouts.PublishAt(ctx, domain.NewRecipeStepTimedOut(workflow.RecipeID, step, workflow.Run), workflow.StepDeadline)
+	if err != nil {
+		return fmt.Errorf("failed to schedule %s timeout of recipe %s: %w", step, workflow.RecipeID, err)
+	}
+	workflow.TimeoutID = timeoutID
+
+	if err := pm.save(ctx, workflow); err != nil {
+		pm.cancelTimeout(ctx, timeoutID)
+		return err
+	}
+	pm.cancelTimeout(ctx, previousTimeout)
+
+	if err := pm.eventBus.Publish(ctx, cmd); err != nil {
+		pm.logger.Error("Failed to publish recipe command, the step will time out",
+			logger.String("recipe_id", workflow.RecipeID),
+			logger.String("command", cmd.EventType()),
+			logger.Error(err),
+		)
+		return nil
+	}
+
+	pm.logger.Debug("Recipe workflow step started",
+		logger.String("recipe_id", workflow.RecipeID),
+		logger.String("step", string(step)),
+		logger.Int("run", workflow.Run),
+	)
+	return nil
+}
+
+// complete finishes the workflow after its last enabled step
+func (pm *RecipeProcessManager) complete(ctx context.Context, workflow *Workflow, step domain.ProcessingStep) error {
+	timeoutID := workflow.TimeoutID
+
+	workflow.Status = WorkflowCompleted
+	workflow.Step = step
+	workflow.StepDeadline = time.Time{}
+	workflow.TimeoutID = ""
+	workflow.UpdatedAt = time.Now()
+
+	if err := pm.save(ctx, workflow); err != nil {
+		return err
+	}
+	pm.cancelTimeout(ctx, timeoutID)
+
+	pm.logger.Info("Recipe workflow completed",
+		logger.String("recipe_id", workflow.RecipeID),
+		logger.Int("run", workflow.Run),
+	)
+	return nil
+}
+
+// fail fails the lifecycle and the workflow, then archives the workflow's export
+// The lifecycle is failed first: if saving the workflow conflicts, the retry
+// finds the lifecycle failed already
+// Its failure event is only published once the workflow is saved, since on a
+// synchronous bus HandleFailed runs inside the publish and saves the workflow too
+func (pm *RecipeProcessManager) fail(ctx context.Context, workflow *Workflow, reason string) error {
+	failed, err := pm.failLifecycle(ctx, workflow.RecipeID, reason)
+	if err != nil {
+		return err
+	}
+
+	timeoutID := workflow.TimeoutID
+
+	var undo []events.Event
+	if workflow.Export != nil && pm.cfg.Archive {
+		undo = append(undo, domain.NewArchiveRecipeExport(workflow.RecipeID, *workflow.Export))
+	}
+
+	workflow.PendingCompensations += len(undo)
+	workflow.Status = WorkflowFailed
+	if workflow.PendingCompensations > 0 {
+		workflow.Status = WorkflowCompensating
+	}
+	workflow.FailureReason = reason
+	workflow.StepDeadline = time.Time{}
+	workflow.TimeoutID = ""
+	workflow.UpdatedAt = time.Now()
+
+	if err := pm.save(ctx, workflow); err != nil {
+		return err
+	}
+	pm.cancelTimeout(ctx, timeoutID)
+
+	pm.logger.Warn("Recipe workflow failed",
+		logger.String("recipe_id", workflow.RecipeID),
+		logger.String("step", string(workflow.Step)),
+		logger.String("reason", reason),
+		logger.Int("compensations", len(undo)),
+	)
+
+	pm.publish(ctx, workflow.RecipeID, append(failed, undo...)...)
+	return nil
+}
+
+// compensate archives an export outside the workflow's own, a partial or late page
+func (pm *RecipeProcessManager) compensate(ctx context.Context, workflow *Workflow, ref domain.ExportReference) error {
+	if !pm.cfg.Archive {
+		pm.logger.Warn("Export left behind, archiving is disabled",
+			logger.String("recipe_id", workflow.RecipeID),
+			logger.String("page_id", ref.PageID),
+		)
+		return nil
+	}
+
+	workflow.PendingCompensations++
+	workflow.UpdatedAt = time.Now()
+	if err := pm.save(ctx, workflow); err != nil {
+		return err
+	}
+
+	pm.publish(ctx, workflow.RecipeID, domain.NewArchiveRecipeExport(workflow.RecipeID, ref))
+	return nil
+}
+
+// failLifecycle moves the recipe's lifecycle into failed unless it is already
+// It returns the failure event for the caller to publish once the workflow
+// is saved, none when the lifecycle needed no change
+func (pm *RecipeProcessManager) failLifecycle(ctx context.Context, recipeID, reason string) ([]events.Event, error) {
+	lifecycle, err := pm.repository.FindByID(ctx, recipeID)
+	if errors.Is(err, ErrRecipeNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recipe %s: %w", recipeID, err)
+	}
+	if lifecycle.Status() == domain.StatusFailed {
+		return nil, nil
+	}
+
+	stage := lifecycle.Status()
+	if err := lifecycle.Fail(reason, time.Now()); err != nil {
+		return nil, events.Permanent(fmt.Errorf("recipe %s: %w", recipeID, err))
+	}
+	if err := pm.repository.Save(ctx, lifecycle); err != nil {
+		return nil, fmt.Errorf("failed to save recipe %s: %w", recipeID, err)
+	}
+
+	return []events.Event{domain.NewRecipeProcessingFailed(recipeID, stage, reason)}, nil
+}
+
+func (pm *RecipeProcessManager) save(ctx context.Context, workflow *Workflow) error {
+	if err := pm.workflows.Save(ctx, workflow); err != nil {
+		return fmt.Errorf("failed to save workflow of recipe %s: %w", workflow.RecipeID, err)
+	}
+	return nil
+}
+
+// publish sends events after the workflow was saved; failures are only logged
+// as retrying the triggering event would find the workflow moved on
+func (pm *RecipeProcessManager) publish(ctx context.Context, recipeID string, evts ...events.Event) {
+	for _, event := range evts {
+		if err := pm.eventBus.Publish(ctx, event); err != nil {
+			pm.logger.Error("Failed to publish recipe workflow event",
+				logger.String("recipe_id", recipeID),
+				logger.String("event_type", event.EventType()),
+				logger.Error(err),
+			)
+		}
+	}
+}
+
+// cancelTimeout removes a step timeout that is no longer needed
+// Timeouts that already fired are ignored by HandleTimedOut anyway
+func (pm *RecipeProcessManager) cancelTimeout(ctx context.Context, id string) {
+	if id == "" {
+		return
+	}
+
+	if err := pm.timeouts.Cancel(ctx, id); err != nil && !errors.Is(err, scheduler.ErrNotFound) {
+		pm.logger.Warn("Failed to cancel step timeout",
+			logger.String("timeout_id", id),
+			logger.Error(err),
+		)
+	}
+}
+
+func (pm *RecipeProcessManager) timeout(step domain.ProcessingStep) time.Duration {
+	switch step {
+	case domain.StepExport:
+		return pm.cfg.ExportTimeout
+	case domain.StepNotify:
+		return pm.cfg.NotifyTimeout
+	default:
+		return pm.cfg.ParseTimeout
+	}
+}
+
+// stepOf maps the lifecycle status a handler failed in to its workflow step
+func stepOf(stage domain.RecipeStatus) domain.ProcessingStep {
+	switch stage {
+	case domain.StatusSubmitted, domain.StatusParsing:
+		return domain.StepParse
+	case domain.StatusParsed, domain.StatusExporting:
+		return domain.StepExport
+	case domain.StatusExported:
+		return domain.StepNotify
+	default:
+		return ""
+	}
+}
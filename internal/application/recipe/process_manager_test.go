@@ -0,0 +1,416 @@
+package recipe_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/memory"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/events/eventstest"
+	"recipe-processor/internal/shared/logger"
+	"recipe-processor/internal/shared/scheduler"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// archivingExporter is a mock exporter that also validates and archives
+type archivingExporter struct {
+	mockRecipeExporter
+	validateErr error
+
+	mu       sync.Mutex
+	archived []domain.ExportReference
+}
+
+func (e *archivingExporter) Validate(ctx context.Context, r *domain.Recipe) error {
+	return e.validateErr
+}
+
+func (e *archivingExporter) Archive(ctx context.Context, ref domain.ExportReference) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.archived = append(e.archived, ref)
+	return nil
+}
+
+// notifierFunc adapts a function to RecipeNotifier
+type notifierFunc func(ctx context.Context, recipeID string, ref domain.ExportReference) error
+
+func (f notifierFunc) Notify(ctx context.Context, recipeID string, ref domain.ExportReference) error {
+	return f(ctx, recipeID, ref)
+}
+
+// workflowFixture is a process manager with its handlers on a synchronous bus
+type workflowFixture struct {
+	bus        *events.SyncEventBus
+	recorder   *eventstest.Recorder
+	repository *memory.RecipeRepository
+	workflows  *memory.WorkflowStore
+	schedule   *memory.ScheduleStore
+	timeouts   *scheduler.Scheduler
+}
+
+func newWorkflowFixture(t *testing.T, retry events.RetryPolicy) *workflowFixture {
+	t.Helper()
+
+	recorder := eventstest.NewRecorder()
+	bus := events.NewSyncEventBusWithConfig(logger.NewNoopLogger(), events.SyncConfig{
+		RetryPolicy: retry,
+		Store:       recorder,
+		Middleware:  []events.HandlerMiddleware{recorder.Middleware()},
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	schedule := memory.NewScheduleStore()
+	return &workflowFixture{
+		bus:        bus,
+		recorder:   recorder,
+		repository: newSubmittedRepository(t, "recipe-1"),
+		workflows:  memory.NewWorkflowStore(),
+		schedule:   schedule,
+		timeouts:   scheduler.NewScheduler(schedule, bus, logger.NewNoopLogger(), scheduler.Config{}),
+	}
+}
+
+// start subscribes the process manager with the given handlers
+func (f *workflowFixture) start(t *testing.T, cfg recipe.ProcessManagerConfig, parser recipe.RecipeParser, exporter recipe.RecipeExporter, notifier recipe.RecipeNotifier) {
+	t.Helper()
+	log := logger.NewNoopLogger()
+
+	if parser != nil {
+		handler := recipe.NewParseRecipeHandler(parser, f.repository, f.bus, log)
+		if _, err := events.SubscribeTyped(f.bus, "parse-recipe", handler.Handle); err != nil {
+			t.Fatalf("SubscribeTyped() error = %v", err)
+		}
+	}
+	if exporter != nil {
+		handler := recipe.NewExportRecipeHandler(exporter, f.repository, f.bus, log)
+		if _, err := events.SubscribeTyped(f.bus, "export-recipe", handler.Handle); err != nil {
+			t.Fatalf("SubscribeTyped() error = %v", err)
+		}
+		cfg.Export = true
+	}
+	if archiver, ok := exporter.(*archivingExporter); ok {
+		handler := recipe.NewArchiveExportHandler(archiver, f.bus, log)
+		if _, err := events.SubscribeTyped(f.bus, "archive-export", handler.Handle); err != nil {
+			t.Fatalf("SubscribeTyped() error = %v", err)
+		}
+		cfg.Validator = archiver
+		cfg.Archive = true
+	}
+	if notifier != nil {
+		handler := recipe.NewNotifyRecipeHandler(notifier, f.bus, log)
+		if _, err := events.SubscribeTyped(f.bus, "notify-recipe", handler.Handle); err != nil {
+			t.Fatalf("SubscribeTyped() error = %v", err)
+		}
+		cfg.Notify = true
+	}
+
+	pm := recipe.NewRecipeProcessManager(f.workflows, f.repository, f.bus, f.timeouts, log, cfg)
+	if err := pm.Subscribe(); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+}
+
+func (f *workflowFixture) submit(t *testing.T) {
+	t.Helper()
+	if err := f.bus.Publish(context.Background(), domain.NewRecipeSubmitted("recipe-1", "Pancakes...")); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+}
+
+func (f *workflowFixture) workflow(t *testing.T) *recipe.Workflow {
+	t.Helper()
+	workflow, err := f.workflows.Find(context.Background(), "recipe-1")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	return workflow
+}
+
+func (f *workflowFixture) lifecycle(t *testing.T) *domain.RecipeLifecycle {
+	t.Helper()
+	lifecycle, err := f.repository.FindByID(context.Background(), "recipe-1")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	return lifecycle
+}
+
+// scheduled returns the step timeouts not published or cancelled yet
+func (f *workflowFixture) scheduled(t *testing.T) []scheduler.ScheduledEvent {
+	t.Helper()
+	return f.schedule.List()
+}
+
+func parsesPancakes(t *testing.T) *mockRecipeParser {
+	return &mockRecipeParser{
+		parseFunc: func(ctx context.Context, recipeID, text string) (*domain.Recipe, error) {
+			return newTestRecipe(t, recipeID), nil
+		},
+	}
+}
+
+func exportsTo(pageID string) *archivingExporter {
+	return &archivingExporter{mockRecipeExporter: mockRecipeExporter{
+		exportFunc: func(ctx context.Context, r *domain.Recipe) (*domain.ExportReference, error) {
+			return &domain.ExportReference{PageID: pageID, URL: "https://notion.so/" + pageID}, nil
+		},
+	}}
+}
+
+func TestRecipeProcessManager_RunsStepsInOrder(t *testing.T) {
+	// Arrange
+	f := newWorkflowFixture(t, events.NoRetry())
+	var notified []string
+	f.start(t, recipe.ProcessManagerConfig{}, parsesPancakes(t), exportsTo("page-1"),
+		notifierFunc(func(ctx context.Context, recipeID string, ref domain.ExportReference) error {
+			notified = append(notified, recipeID+" "+ref.PageID)
+			return nil
+		}))
+
+	// Act
+	f.submit(t)
+
+	// Assert
+	var order []string
+	for _, p := range f.recorder.Published() {
+		order = append(order, p.Event.EventType())
+	}
+	want := []string{
+		domain.EventTypeRecipeSubmitted, domain.CommandTypeParseRecipe, domain.EventTypeRecipeParsed,
+		domain.CommandTypeExportRecipe, domain.EventTypeRecipeExported, domain.CommandTypeNotifyRecipe,
+		domain.EventTypeRecipeNotified,
+	}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected events %v, got %v", want, order)
+	}
+
+	workflow := f.workflow(t)
+	if workflow.Status != recipe.WorkflowCompleted || workflow.Step != domain.StepNotify || workflow.Run != 1 {
+		t.Errorf("Expected run 1 completed after notify, got %+v", workflow)
+	}
+	if workflow.Export == nil || workflow.Export.PageID != "page-1" {
+		t.Errorf("Expected export to be recorded, got %+v", workflow.Export)
+	}
+	if len(notified) != 1 || notified[0] != "recipe-1 page-1" {
+		t.Errorf("Expected one notification, got %v", notified)
+	}
+	if due := f.scheduled(t); len(due) != 0 {
+		t.Errorf("Expected step timeouts to be cancelled, got %d scheduled", len(due))
+	}
+	if f.lifecycle(t).Status() != domain.StatusExported {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusExported, f.lifecycle(t).Status())
+	}
+}
+
+func TestRecipeProcessManager_StepTimeoutFailsWorkflow(t *testing.T) {
+	// Arrange: nothing handles the parse command, as if the worker was down
+	f := newWorkflowFixture(t, events.NoRetry())
+	f.start(t, recipe.ProcessManagerConfig{ParseTimeout: time.Nanosecond}, nil, nil, nil)
+	f.submit(t)
+
+	// Act
+	published, err := f.timeouts.PublishDue(context.Background())
+
+	// Assert
+	if err != nil || published != 1 {
+		t.Fatalf("Expected one timeout to be published, got %d, %v", published, err)
+	}
+	f.recorder.AssertPublished(t, domain.EventTypeRecipeStepTimedOut, "recipe-1")
+
+	workflow := f.workflow(t)
+	if workflow.Status != recipe.WorkflowFailed || workflow.Step != domain.StepParse {
+		t.Errorf("Expected workflow to fail in parse, got %+v", workflow)
+	}
+	if !strings.Contains(workflow.FailureReason, "timed out") {
+		t.Errorf("Expected a timeout reason, got %q", workflow.FailureReason)
+	}
+
+	lifecycle := f.lifecycle(t)
+	if lifecycle.Status() != domain.StatusFailed || lifecycle.FailureReason() != workflow.FailureReason {
+		t.Errorf("Expected recipe to fail with the workflow, got '%s': %q", lifecycle.Status(), lifecycle.FailureReason())
+	}
+}
+
+// inlineFailureBus runs the process manager's HandleFailed inside Publish, as
+// a bus delivering to handlers on the publisher's goroutine would
+type inlineFailureBus struct {
+	events.EventBus
+	pm *recipe.RecipeProcessManager
+}
+
+func (b *inlineFailureBus) Publish(ctx context.Context, event events.Event) error {
+	if failed, ok := event.(*domain.RecipeProcessingFailed); ok {
+		if err := b.pm.HandleFailed(ctx, failed); err != nil {
+			return err
+		}
+	}
+	return b.EventBus.Publish(ctx, event)
+}
+
+func TestRecipeProcessManager_StepFailureCompletesWithoutRetry(t *testing.T) {
+	// Arrange: nothing handles the parse command, so the parse step times out
+	f := newWorkflowFixture(t, events.NoRetry())
+	bus := &inlineFailureBus{EventBus: f.bus}
+	bus.pm = recipe.NewRecipeProcessManager(f.workflows, f.repository, bus, f.timeouts, logger.NewNoopLogger(),
+		recipe.ProcessManagerConfig{ParseTimeout: time.Nanosecond})
+	if err := bus.pm.Subscribe(); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	f.submit(t)
+
+	// Act
+	if _, err := f.timeouts.PublishDue(context.Background()); err != nil {
+		t.Fatalf("PublishDue() error = %v", err)
+	}
+
+	// Assert
+	f.recorder.AssertPublished(t, domain.EventTypeRecipeFailed, "recipe-1")
+	for _, h := range f.recorder.Handled() {
+		if h.Err != nil {
+			t.Errorf("Expected %s to handle %s on the first attempt, got %v", h.Handler, h.Event.EventType(), h.Err)
+		}
+	}
+	if workflow := f.workflow(t); workflow.Status != recipe.WorkflowFailed {
+		t.Errorf("Expected workflow to fail, got %+v", workflow)
+	}
+}
+
+func TestRecipeProcessManager_FailedNotificationArchivesExport(t *testing.T) {
+	// Arrange
+	f := newWorkflowFixture(t, events.NoRetry())
+	exporter := exportsTo("page-1")
+	f.start(t, recipe.ProcessManagerConfig{}, parsesPancakes(t), exporter,
+		notifierFunc(func(ctx context.Context, recipeID string, ref domain.ExportReference) error {
+			return events.Permanent(errors.New("webhook rejected"))
+		}))
+
+	// Act
+	f.submit(t)
+
+	// Assert
+	f.recorder.AssertPublished(t, domain.CommandTypeArchiveRecipeExport, "recipe-1")
+	f.recorder.AssertPublished(t, domain.EventTypeRecipeExportArchived, "recipe-1")
+	if len(exporter.archived) != 1 || exporter.archived[0].PageID != "page-1" {
+		t.Errorf("Expected page-1 to be archived, got %+v", exporter.archived)
+	}
+
+	workflow := f.workflow(t)
+	if workflow.Status != recipe.WorkflowFailed || workflow.Step != domain.StepNotify || workflow.PendingCompensations != 0 {
+		t.Errorf("Expected workflow to fail in notify once compensated, got %+v", workflow)
+	}
+
+	lifecycle := f.lifecycle(t)
+	if lifecycle.Status() != domain.StatusFailed {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusFailed, lifecycle.Status())
+	}
+	if lifecycle.ExportReference() != nil {
+		t.Errorf("Expected the archived export to be dropped, got %+v", lifecycle.ExportReference())
+	}
+}
+
+func TestRecipeProcessManager_ArchivesPartialExportBeforeRetry(t *testing.T) {
+	// Arrange
+	f := newWorkflowFixture(t, events.RetryPolicy{MaxAttempts: 2})
+	attempts := 0
+	exporter := &archivingExporter{mockRecipeExporter: mockRecipeExporter{
+		exportFunc: func(ctx context.Context, r *domain.Recipe) (*domain.ExportReference, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, &domain.IncompleteExportError{
+					Reference: domain.ExportReference{PageID: "half-written"},
+					Err:       errors.New("append failed"),
+				}
+			}
+			return &domain.ExportReference{PageID: "page-2", URL: "https://notion.so/page-2"}, nil
+		},
+	}}
+	f.start(t, recipe.ProcessManagerConfig{}, parsesPancakes(t), exporter, nil)
+
+	// Act
+	f.submit(t)
+
+	// Assert
+	if len(exporter.archived) != 1 || exporter.archived[0].PageID != "half-written" {
+		t.Errorf("Expected the half-written page to be archived, got %+v", exporter.archived)
+	}
+
+	workflow := f.workflow(t)
+	if workflow.Status != recipe.WorkflowCompleted || workflow.Export == nil || workflow.Export.PageID != "page-2" {
+		t.Errorf("Expected workflow to complete with page-2, got %+v", workflow)
+	}
+	if workflow.PendingCompensations != 0 {
+		t.Errorf("Expected the archive to be confirmed, got %d pending", workflow.PendingCompensations)
+	}
+	if f.lifecycle(t).Status() != domain.StatusExported {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusExported, f.lifecycle(t).Status())
+	}
+}
+
+func TestRecipeProcessManager_InvalidRecipeIsNotExported(t *testing.T) {
+	// Arrange
+	f := newWorkflowFixture(t, events.NoRetry())
+	exporter := exportsTo("page-1")
+	exporter.validateErr = errors.New("step too long")
+	f.start(t, recipe.ProcessManagerConfig{}, parsesPancakes(t), exporter, nil)
+
+	// Act
+	f.submit(t)
+
+	// Assert
+	f.recorder.AssertNotPublished(t, domain.CommandTypeExportRecipe, "recipe-1")
+
+	workflow := f.workflow(t)
+	if workflow.Status != recipe.WorkflowFailed || workflow.Step != domain.StepValidate {
+		t.Errorf("Expected workflow to fail in validate, got %+v", workflow)
+	}
+
+	lifecycle := f.lifecycle(t)
+	if lifecycle.Status() != domain.StatusFailed || !strings.Contains(lifecycle.FailureReason(), "step too long") {
+		t.Errorf("Expected recipe to fail validation, got '%s': %q", lifecycle.Status(), lifecycle.FailureReason())
+	}
+}
+
+func TestRecipeProcessManager_ResubmissionStartsNewRun(t *testing.T) {
+	// Arrange
+	f := newWorkflowFixture(t, events.NoRetry())
+	f.start(t, recipe.ProcessManagerConfig{}, parsesPancakes(t), nil, nil)
+	f.submit(t)
+
+	// Act
+	f.submit(t)
+
+	// Assert
+	workflow := f.workflow(t)
+	if workflow.Run != 2 || workflow.Status != recipe.WorkflowCompleted {
+		t.Errorf("Expected a second completed run, got %+v", workflow)
+	}
+}
+
+func TestRecipeProcessManager_IgnoresStaleTimeout(t *testing.T) {
+	// Arrange
+	f := newWorkflowFixture(t, events.NoRetry())
+	f.start(t, recipe.ProcessManagerConfig{}, parsesPancakes(t), nil, nil)
+	f.submit(t)
+
+	// Act
+	err := f.bus.Publish(context.Background(), domain.NewRecipeStepTimedOut("recipe-1", domain.StepParse, 1))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if workflow := f.workflow(t); workflow.Status != recipe.WorkflowCompleted {
+		t.Errorf("Expected the completed workflow to ignore the timeout, got %+v", workflow)
+	}
+	if f.lifecycle(t).Status() != domain.StatusParsed {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusParsed, f.lifecycle(t).Status())
+	}
+}
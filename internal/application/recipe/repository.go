@@ -0,0 +1,26 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+)
+
+var ErrRecipeNotFound = errors.New("recipe not found")
+
+// RecipeRepository persists recipe lifecycles
+type RecipeRepository interface {
+	// Save inserts or replaces the lifecycle
+	Save(ctx context.Context, lifecycle *domain.RecipeLifecycle) error
+	// FindByID returns ErrRecipeNotFound when no recipe has the given ID
+	FindByID(ctx context.Context, id string) (*domain.RecipeLifecycle, error)
+}
+
+// OutboxRepository persists lifecycles together with the events they raised
+// Both are committed atomically; an outbox relay dispatches the events later
+type OutboxRepository interface {
+	RecipeRepository
+	// SaveWithEvents inserts or replaces the lifecycle and enqueues the events
+	SaveWithEvents(ctx context.Context, lifecycle *domain.RecipeLifecycle, evts ...events.Event) error
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"recipe-processor/internal/domain"
-	"recipe-processor/internal/shared/events"
 	"recipe-processor/internal/shared/logger"
 
 	"github.com/google/uuid"
@@ -26,15 +25,17 @@ type RecipeSubmitter interface {
 
 // SubmitRecipeService handles the business logic for submitting recipes
 type SubmitRecipeService struct {
-	eventBus events.EventBus
-	logger   logger.Logger
+	repository OutboxRepository
+	logger     logger.Logger
 }
 
 // NewSubmitRecipeService creates a new recipe submission service
-func NewSubmitRecipeService(eventBus events.EventBus, log logger.Logger) *SubmitRecipeService {
+// The RecipeSubmitted event goes through the repository's outbox, so an
+// accepted submission is processed even if the process stops right after
+func NewSubmitRecipeService(repository OutboxRepository, log logger.Logger) *SubmitRecipeService {
 	return &SubmitRecipeService{
-		eventBus: eventBus,
-		logger:   log,
+		repository: repository,
+		logger:     log,
 	}
 }
 
@@ -50,13 +51,15 @@ func (s *SubmitRecipeService) Execute(ctx context.Context, cmd SubmitRecipeComma
 	recipeID := uuid.New().String()
 	event := domain.NewRecipeSubmitted(recipeID, recipeText.Value())
 
-	// Publish event
-	if err := s.eventBus.Publish(ctx, event); err != nil {
-		s.logger.Error("Failed to publish recipe submitted event",
-			logger.String("recipe_id", recipeID),
-			logger.Error(err),
-		)
-		return nil, fmt.Errorf("failed to publish event: %w", err)
+	// Record the submission so its status can be queried
+	lifecycle, err := domain.NewRecipeLifecycle(recipeID, recipeText, event.OccurredAt())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recipe lifecycle: %w", err)
+	}
+
+	// Store the submission and its event atomically
+	if err := s.repository.SaveWithEvents(ctx, lifecycle, event); err != nil {
+		return nil, fmt.Errorf("failed to save recipe: %w", err)
 	}
 
 	s.logger.Info("Recipe submitted successfully",
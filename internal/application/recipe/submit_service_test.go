@@ -5,10 +5,12 @@ import (
 	"errors"
 	"recipe-processor/internal/application/recipe"
 	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/memory"
 	"recipe-processor/internal/shared/events"
 	"recipe-processor/internal/shared/logger"
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockEventBus is a mock implementation of EventBus
@@ -28,20 +30,59 @@ func (m *mockEventBus) Publish(ctx context.Context, event events.Event) error {
 }
 
 func (m *mockEventBus) Subscribe(eventType string, handler events.EventHandler) {}
+func (m *mockEventBus) SubscribeNamed(eventType, name string, handler events.EventHandler, opts ...events.SubscribeOption) (events.Subscription, error) {
+	return nil, nil
+}
 
 func (m *mockEventBus) Start(ctx context.Context) error {
 	return nil
 }
 
-func (m *mockEventBus) Stop() error {
+func (m *mockEventBus) Stop(ctx context.Context) error {
 	return nil
 }
 
+// mockOutboxRepository wraps the in-memory repository with a failing SaveWithEvents
+type mockOutboxRepository struct {
+	*memory.RecipeRepository
+	saveWithEventsFunc func(ctx context.Context, lifecycle *domain.RecipeLifecycle, evts ...events.Event) error
+}
+
+func (m *mockOutboxRepository) SaveWithEvents(ctx context.Context, lifecycle *domain.RecipeLifecycle, evts ...events.Event) error {
+	return m.saveWithEventsFunc(ctx, lifecycle, evts...)
+}
+
+// pendingEvents decodes the events waiting in the repository's outbox
+func pendingEvents(t *testing.T, repo *memory.RecipeRepository) []events.Event {
+	t.Helper()
+
+	messages, err := repo.Outbox().Pending(context.Background(), time.Now(), 0)
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+
+	registry, err := recipe.NewEventRegistry()
+	if err != nil {
+		t.Fatalf("NewEventRegistry() error = %v", err)
+	}
+
+	evts := make([]events.Event, 0, len(messages))
+	for _, msg := range messages {
+		event, err := registry.Decode(msg.EventType, msg.Envelope.SchemaVersion, msg.Payload)
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		evts = append(evts, event)
+	}
+
+	return evts
+}
+
 func TestSubmitRecipeService_Execute_Success(t *testing.T) {
 	// Arrange
-	mockBus := &mockEventBus{}
+	repo := newRepository(t)
 	log := logger.NewNoopLogger()
-	service := recipe.NewSubmitRecipeService(mockBus, log)
+	service := recipe.NewSubmitRecipeService(repo, log)
 
 	cmd := recipe.SubmitRecipeCommand{
 		RecipeText: "Chocolate Chip Cookies\n\nIngredients:\n- 2 cups flour\n- 1 cup sugar\n\nInstructions:\n1. Mix ingredients\n2. Bake at 350F",
@@ -63,23 +104,20 @@ func TestSubmitRecipeService_Execute_Success(t *testing.T) {
 		t.Error("Expected non-empty recipe ID")
 	}
 
-	if !mockBus.publishCalled {
-		t.Error("Expected Publish to be called")
-	}
-
-	if mockBus.lastEvent == nil {
-		t.Fatal("Expected event to be published")
+	pending := pendingEvents(t, repo)
+	if len(pending) != 1 {
+		t.Fatalf("Expected 1 event in the outbox, got %d", len(pending))
 	}
 
 	// Verify event type
-	if mockBus.lastEvent.EventType() != domain.EventTypeRecipeSubmitted {
+	if pending[0].EventType() != domain.EventTypeRecipeSubmitted {
 		t.Errorf("Expected event type '%s', got '%s'",
 			domain.EventTypeRecipeSubmitted,
-			mockBus.lastEvent.EventType())
+			pending[0].EventType())
 	}
 
 	// Verify event data
-	recipeEvent, ok := mockBus.lastEvent.(*domain.RecipeSubmitted)
+	recipeEvent, ok := pending[0].(*domain.RecipeSubmitted)
 	if !ok {
 		t.Fatal("Expected RecipeSubmitted event")
 	}
@@ -96,9 +134,9 @@ func TestSubmitRecipeService_Execute_Success(t *testing.T) {
 
 func TestSubmitRecipeService_Execute_EmptyText(t *testing.T) {
 	// Arrange
-	mockBus := &mockEventBus{}
+	repo := newRepository(t)
 	log := logger.NewNoopLogger()
-	service := recipe.NewSubmitRecipeService(mockBus, log)
+	service := recipe.NewSubmitRecipeService(repo, log)
 
 	cmd := recipe.SubmitRecipeCommand{
 		RecipeText: "",
@@ -120,16 +158,16 @@ func TestSubmitRecipeService_Execute_EmptyText(t *testing.T) {
 		t.Error("Expected nil result on error")
 	}
 
-	if mockBus.publishCalled {
-		t.Error("Expected Publish not to be called on validation error")
+	if len(pendingEvents(t, repo)) != 0 {
+		t.Error("Expected no event to be enqueued on validation error")
 	}
 }
 
 func TestSubmitRecipeService_Execute_TextTooLong(t *testing.T) {
 	// Arrange
-	mockBus := &mockEventBus{}
+	repo := newRepository(t)
 	log := logger.NewNoopLogger()
-	service := recipe.NewSubmitRecipeService(mockBus, log)
+	service := recipe.NewSubmitRecipeService(repo, log)
 
 	cmd := recipe.SubmitRecipeCommand{
 		RecipeText: strings.Repeat("a", 10001), // Over 10,000 char limit
@@ -151,21 +189,22 @@ func TestSubmitRecipeService_Execute_TextTooLong(t *testing.T) {
 		t.Error("Expected nil result on error")
 	}
 
-	if mockBus.publishCalled {
-		t.Error("Expected Publish not to be called on validation error")
+	if len(pendingEvents(t, repo)) != 0 {
+		t.Error("Expected no event to be enqueued on validation error")
 	}
 }
 
-func TestSubmitRecipeService_Execute_PublishError(t *testing.T) {
+func TestSubmitRecipeService_Execute_SaveError(t *testing.T) {
 	// Arrange
-	publishError := errors.New("event bus connection failed")
-	mockBus := &mockEventBus{
-		publishFunc: func(ctx context.Context, event events.Event) error {
-			return publishError
+	saveError := errors.New("database is locked")
+	repo := &mockOutboxRepository{
+		RecipeRepository: newRepository(t),
+		saveWithEventsFunc: func(ctx context.Context, lifecycle *domain.RecipeLifecycle, evts ...events.Event) error {
+			return saveError
 		},
 	}
 	log := logger.NewNoopLogger()
-	service := recipe.NewSubmitRecipeService(mockBus, log)
+	service := recipe.NewSubmitRecipeService(repo, log)
 
 	cmd := recipe.SubmitRecipeCommand{
 		RecipeText: "Valid recipe text",
@@ -176,32 +215,29 @@ func TestSubmitRecipeService_Execute_PublishError(t *testing.T) {
 
 	// Assert
 	if err == nil {
-		t.Fatal("Expected error when publish fails, got nil")
+		t.Fatal("Expected error when save fails, got nil")
 	}
 
-	if !errors.Is(err, publishError) {
-		t.Errorf("Expected error to wrap publish error, got: %v", err)
+	if !errors.Is(err, saveError) {
+		t.Errorf("Expected error to wrap save error, got: %v", err)
 	}
 
 	if result != nil {
-		t.Error("Expected nil result when publish fails")
-	}
-
-	if !mockBus.publishCalled {
-		t.Error("Expected Publish to be called")
+		t.Error("Expected nil result when save fails")
 	}
 }
 
 func TestSubmitRecipeService_Execute_ContextCancellation(t *testing.T) {
 	// Arrange
-	mockBus := &mockEventBus{
-		publishFunc: func(ctx context.Context, event events.Event) error {
-			// Simulate publish checking context
+	repo := &mockOutboxRepository{
+		RecipeRepository: newRepository(t),
+		saveWithEventsFunc: func(ctx context.Context, lifecycle *domain.RecipeLifecycle, evts ...events.Event) error {
+			// Simulate save checking context
 			return ctx.Err()
 		},
 	}
 	log := logger.NewNoopLogger()
-	service := recipe.NewSubmitRecipeService(mockBus, log)
+	service := recipe.NewSubmitRecipeService(repo, log)
 
 	cmd := recipe.SubmitRecipeCommand{
 		RecipeText: "Valid recipe text",
@@ -226,9 +262,8 @@ func TestSubmitRecipeService_Execute_ContextCancellation(t *testing.T) {
 
 func TestSubmitRecipeService_Execute_RecipeIDIsUnique(t *testing.T) {
 	// Arrange
-	mockBus := &mockEventBus{}
 	log := logger.NewNoopLogger()
-	service := recipe.NewSubmitRecipeService(mockBus, log)
+	service := recipe.NewSubmitRecipeService(newRepository(t), log)
 
 	cmd := recipe.SubmitRecipeCommand{
 		RecipeText: "Recipe text",
@@ -247,3 +282,29 @@ func TestSubmitRecipeService_Execute_RecipeIDIsUnique(t *testing.T) {
 		t.Error("Expected unique recipe IDs for different submissions")
 	}
 }
+
+func TestSubmitRecipeService_Execute_SavesSubmittedRecipe(t *testing.T) {
+	// Arrange
+	repo := newRepository(t)
+	service := recipe.NewSubmitRecipeService(repo, logger.NewNoopLogger())
+
+	// Act
+	result, err := service.Execute(context.Background(), recipe.SubmitRecipeCommand{RecipeText: "  Soup  "})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Assert
+	lifecycle, err := repo.FindByID(context.Background(), result.RecipeID)
+	if err != nil {
+		t.Fatalf("Expected recipe to be saved, got: %v", err)
+	}
+
+	if lifecycle.Status() != domain.StatusSubmitted {
+		t.Errorf("Expected status '%s', got '%s'", domain.StatusSubmitted, lifecycle.Status())
+	}
+
+	if lifecycle.RawText() != "Soup" {
+		t.Errorf("Expected raw text 'Soup', got '%s'", lifecycle.RawText())
+	}
+}
@@ -0,0 +1,64 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/domain"
+	"time"
+)
+
+var (
+	ErrWorkflowNotFound = errors.New("workflow not found")
+	ErrWorkflowConflict = errors.New("workflow was changed concurrently")
+)
+
+type WorkflowStatus string
+
+const (
+	// WorkflowRunning waits for the current step to finish
+	WorkflowRunning WorkflowStatus = "running"
+	// WorkflowCompleted finished every step
+	WorkflowCompleted WorkflowStatus = "completed"
+	// WorkflowCompensating failed and waits for its compensations to finish
+	WorkflowCompensating WorkflowStatus = "compensating"
+	// WorkflowFailed failed and undid what it could
+	WorkflowFailed WorkflowStatus = "failed"
+)
+
+// Workflow is the process manager's state of one recipe
+type Workflow struct {
+	RecipeID string
+	// Run counts how often the recipe was (re)submitted, starting at 1
+	Run    int
+	Status WorkflowStatus
+	// Step is the step running, the last one once completed, or the one that failed
+	Step domain.ProcessingStep
+	// StepDeadline is when the running step times out; TimeoutID is the
+	// scheduled timeout event, empty when the step has none
+	StepDeadline time.Time
+	TimeoutID    string
+	// Export is the page the export step wrote
+	Export *domain.ExportReference
+	// PendingCompensations counts archive commands not confirmed yet
+	PendingCompensations int
+	FailureReason        string
+	StartedAt            time.Time
+	UpdatedAt            time.Time
+	// Version is incremented by every save and guards against lost updates
+	Version int
+}
+
+// Active reports whether the workflow still waits for its steps
+func (w *Workflow) Active() bool {
+	return w.Status == WorkflowRunning
+}
+
+// WorkflowStore persists the process manager's workflows
+type WorkflowStore interface {
+	// Find returns ErrWorkflowNotFound when the recipe has no workflow
+	Find(ctx context.Context, recipeID string) (*Workflow, error)
+	// Save inserts a workflow of version 0 or replaces the stored one of the
+	// same version, then increments Version; a stored workflow of another
+	// version makes it return ErrWorkflowConflict
+	Save(ctx context.Context, workflow *Workflow) error
+}
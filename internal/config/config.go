@@ -6,10 +6,28 @@ import (
 	"time"
 )
 
+// Supported MODE values of the API binary
+const (
+	// ModeAll serves the API and runs the event handlers in one process
+	ModeAll = "all"
+	// ModeAPI only serves the API and publishes events; cmd/worker handles them
+	ModeAPI = "api"
+)
+
+// Supported EVENT_BUS values
+const (
+	EventBusMemory = "memory"
+	EventBusNATS   = "nats"
+	EventBusRedis  = "redis"
+)
+
 type Config struct {
 	// Environment
 	Environment string
 	Port        string
+	Mode        string
+	// HealthPort serves the health endpoints of binaries without an API
+	HealthPort string
 
 	// Server timeouts
 	RequestTimeout time.Duration
@@ -20,26 +38,77 @@ type Config struct {
 	// Logging
 	LogLevel string
 
-	// LLM
+	// LLM; OllamaTimeout bounds one request and the parse handler running it
 	OllamaBaseUrl string
+	OllamaModel   string
+	OllamaTimeout time.Duration
 
 	// Notion tokens
 	NotionToken      string
 	NotionDatabaseId string
+
+	// Notification webhook, the notify step is skipped without one
+	NotifyWebhookURL string
+
+	// Persistence
+	DatabasePath string
+
+	// Admin endpoints are disabled unless a token is set
+	AdminToken string
+
+	// Event bus: "memory", "nats" or "redis"
+	EventBus       string
+	NATSURL        string
+	NATSStream     string
+	NATSMaxDeliver int
+	RedisAddr      string
+	RedisPassword  string
+	RedisMaxLen    int
+
+	// Queue of the memory event bus: its size, and "block", "fail" or "spill"
+	// when it is full
+	EventQueueSize         int
+	EventQueueOverflow     string
+	EventQueueBlockTimeout time.Duration
+
+	// How long each step of recipe processing may take, retries included
+	WorkflowParseTimeout  time.Duration
+	WorkflowExportTimeout time.Duration
+	WorkflowNotifyTimeout time.Duration
 }
 
 func Load() *Config {
 	return &Config{
-		Environment:      getEnv("ENV", "development"),
-		Port:             getEnv("PORT", "8080"),
-		RequestTimeout:   getDurationEnv("REQUEST_TIMEOUT", 30*time.Minute),
-		ReadTimeout:      getDurationEnv("READ_TIMEOUT", 10*time.Minute),
-		WriteTimeout:     getDurationEnv("WRITE_TIMEOUT", 10*time.Minute),
-		IdleTimeout:      getDurationEnv("IDLE_TIMEOUT", 60*time.Minute),
-		LogLevel:         getEnv("LOG_LEVEL", "info"),
-		OllamaBaseUrl:    getEnv("OLLAMA_BASE_URL", "http://ollama:11434"),
-		NotionToken:      getEnv("NOTION_TOKEN", ""),
-		NotionDatabaseId: getEnv("NOTION_DATABASE_ID", ""),
+		Environment:            getEnv("ENV", "development"),
+		Port:                   getEnv("PORT", "8080"),
+		Mode:                   getEnv("MODE", ModeAll),
+		HealthPort:             getEnv("HEALTH_PORT", "8081"),
+		RequestTimeout:         getDurationEnv("REQUEST_TIMEOUT", 30*time.Minute),
+		ReadTimeout:            getDurationEnv("READ_TIMEOUT", 10*time.Minute),
+		WriteTimeout:           getDurationEnv("WRITE_TIMEOUT", 10*time.Minute),
+		IdleTimeout:            getDurationEnv("IDLE_TIMEOUT", 60*time.Minute),
+		LogLevel:               getEnv("LOG_LEVEL", "info"),
+		OllamaBaseUrl:          getEnv("OLLAMA_BASE_URL", "http://ollama:11434"),
+		OllamaModel:            getEnv("OLLAMA_MODEL", "llama3.1"),
+		OllamaTimeout:          getDurationEnv("OLLAMA_TIMEOUT", 5*time.Minute),
+		NotionToken:            getEnv("NOTION_TOKEN", ""),
+		NotionDatabaseId:       getEnv("NOTION_DATABASE_ID", ""),
+		NotifyWebhookURL:       getEnv("NOTIFY_WEBHOOK_URL", ""),
+		DatabasePath:           getEnv("DATABASE_PATH", "recipes.db"),
+		AdminToken:             getEnv("ADMIN_TOKEN", ""),
+		EventBus:               getEnv("EVENT_BUS", EventBusMemory),
+		NATSURL:                getEnv("NATS_URL", "nats://localhost:4222"),
+		NATSStream:             getEnv("NATS_STREAM", "RECIPE_EVENTS"),
+		NATSMaxDeliver:         getIntEnv("NATS_MAX_DELIVER", 5),
+		RedisAddr:              getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:          getEnv("REDIS_PASSWORD", ""),
+		RedisMaxLen:            getIntEnv("REDIS_STREAM_MAX_LEN", 100000),
+		EventQueueSize:         getIntEnv("EVENT_QUEUE_SIZE", 100),
+		EventQueueOverflow:     getEnv("EVENT_QUEUE_OVERFLOW", "block"),
+		EventQueueBlockTimeout: getDurationEnv("EVENT_QUEUE_BLOCK_TIMEOUT", 5*time.Second),
+		WorkflowParseTimeout:   getDurationEnv("WORKFLOW_PARSE_TIMEOUT", 10*time.Minute),
+		WorkflowExportTimeout:  getDurationEnv("WORKFLOW_EXPORT_TIMEOUT", 5*time.Minute),
+		WorkflowNotifyTimeout:  getDurationEnv("WORKFLOW_NOTIFY_TIMEOUT", 2*time.Minute),
 	}
 }
 
@@ -60,3 +129,13 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 
 	return defaultValue
 }
+
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+	}
+
+	return defaultValue
+}
@@ -9,14 +9,27 @@ import (
 func TestLoad_Defaults(t *testing.T) {
 	t.Setenv("ENV", "")
 	t.Setenv("PORT", "")
+	t.Setenv("MODE", "")
+	t.Setenv("HEALTH_PORT", "")
 	t.Setenv("REQUEST_TIMEOUT", "")
 	t.Setenv("READ_TIMEOUT", "")
 	t.Setenv("WRITE_TIMEOUT", "")
 	t.Setenv("IDLE_TIMEOUT", "")
 	t.Setenv("LOG_LEVEL", "")
 	t.Setenv("OLLAMA_BASE_URL", "")
+	t.Setenv("OLLAMA_MODEL", "")
+	t.Setenv("OLLAMA_TIMEOUT", "")
 	t.Setenv("NOTION_TOKEN", "")
 	t.Setenv("NOTION_DATABASE_ID", "")
+	t.Setenv("DATABASE_PATH", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	t.Setenv("EVENT_BUS", "")
+	t.Setenv("NATS_URL", "")
+	t.Setenv("NATS_STREAM", "")
+	t.Setenv("NATS_MAX_DELIVER", "")
+	t.Setenv("REDIS_ADDR", "")
+	t.Setenv("REDIS_PASSWORD", "")
+	t.Setenv("REDIS_STREAM_MAX_LEN", "")
 
 	cfg := config.Load()
 
@@ -26,6 +39,12 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.Port != "8080" {
 		t.Errorf("expected default Port=8080, got %s", cfg.Port)
 	}
+	if cfg.Mode != config.ModeAll {
+		t.Errorf("expected default Mode=all, got %s", cfg.Mode)
+	}
+	if cfg.HealthPort != "8081" {
+		t.Errorf("expected default HealthPort=8081, got %s", cfg.HealthPort)
+	}
 	if cfg.RequestTimeout != 30*time.Minute {
 		t.Errorf("expected default RequestTimeout=30m, got %v", cfg.RequestTimeout)
 	}
@@ -44,25 +63,83 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.OllamaBaseUrl != "http://ollama:11434" {
 		t.Errorf("expected default OllamaBaseUrl=http://ollama:11434, got %s", cfg.OllamaBaseUrl)
 	}
+	if cfg.OllamaModel != "llama3.1" {
+		t.Errorf("expected default OllamaModel=llama3.1, got %s", cfg.OllamaModel)
+	}
+	if cfg.OllamaTimeout != 5*time.Minute {
+		t.Errorf("expected default OllamaTimeout=5m, got %v", cfg.OllamaTimeout)
+	}
 	if cfg.NotionToken != "" {
 		t.Errorf("expected default NotionToken empty, got %s", cfg.NotionToken)
 	}
 	if cfg.NotionDatabaseId != "" {
 		t.Errorf("expected default NotionDatabaseId empty, got %s", cfg.NotionDatabaseId)
 	}
+	if cfg.DatabasePath != "recipes.db" {
+		t.Errorf("expected default DatabasePath=recipes.db, got %s", cfg.DatabasePath)
+	}
+	if cfg.AdminToken != "" {
+		t.Errorf("expected default AdminToken empty, got %s", cfg.AdminToken)
+	}
+	if cfg.EventBus != config.EventBusMemory {
+		t.Errorf("expected default EventBus=memory, got %s", cfg.EventBus)
+	}
+	if cfg.NATSURL != "nats://localhost:4222" {
+		t.Errorf("expected default NATSURL=nats://localhost:4222, got %s", cfg.NATSURL)
+	}
+	if cfg.NATSStream != "RECIPE_EVENTS" {
+		t.Errorf("expected default NATSStream=RECIPE_EVENTS, got %s", cfg.NATSStream)
+	}
+	if cfg.NATSMaxDeliver != 5 {
+		t.Errorf("expected default NATSMaxDeliver=5, got %d", cfg.NATSMaxDeliver)
+	}
+	if cfg.RedisAddr != "localhost:6379" {
+		t.Errorf("expected default RedisAddr=localhost:6379, got %s", cfg.RedisAddr)
+	}
+	if cfg.RedisPassword != "" {
+		t.Errorf("expected default RedisPassword empty, got %s", cfg.RedisPassword)
+	}
+	if cfg.RedisMaxLen != 100000 {
+		t.Errorf("expected default RedisMaxLen=100000, got %d", cfg.RedisMaxLen)
+	}
+	if cfg.EventQueueSize != 100 {
+		t.Errorf("expected default EventQueueSize=100, got %d", cfg.EventQueueSize)
+	}
+	if cfg.EventQueueOverflow != "block" {
+		t.Errorf("expected default EventQueueOverflow=block, got %s", cfg.EventQueueOverflow)
+	}
+	if cfg.EventQueueBlockTimeout != 5*time.Second {
+		t.Errorf("expected default EventQueueBlockTimeout=5s, got %v", cfg.EventQueueBlockTimeout)
+	}
 }
 
 func TestLoad_EnvOverrides(t *testing.T) {
 	t.Setenv("ENV", "production")
 	t.Setenv("PORT", "9000")
+	t.Setenv("MODE", "api")
+	t.Setenv("HEALTH_PORT", "9001")
 	t.Setenv("REQUEST_TIMEOUT", "120")
 	t.Setenv("READ_TIMEOUT", "30")
 	t.Setenv("WRITE_TIMEOUT", "45")
 	t.Setenv("IDLE_TIMEOUT", "300")
 	t.Setenv("LOG_LEVEL", "debug")
 	t.Setenv("OLLAMA_BASE_URL", "http://localhost:1234")
+	t.Setenv("OLLAMA_MODEL", "mistral")
+	t.Setenv("OLLAMA_TIMEOUT", "600")
 	t.Setenv("NOTION_TOKEN", "xyz")
 	t.Setenv("NOTION_DATABASE_ID", "abc")
+	t.Setenv("DATABASE_PATH", "/data/recipes.db")
+	t.Setenv("ADMIN_TOKEN", "s3cret")
+	t.Setenv("EVENT_BUS", "nats")
+	t.Setenv("NATS_URL", "nats://nats:4222")
+	t.Setenv("NATS_STREAM", "EVENTS")
+	t.Setenv("NATS_MAX_DELIVER", "8")
+	t.Setenv("REDIS_ADDR", "redis:6379")
+	t.Setenv("REDIS_PASSWORD", "hunter2")
+	t.Setenv("REDIS_STREAM_MAX_LEN", "500")
+	t.Setenv("EVENT_QUEUE_SIZE", "1000")
+	t.Setenv("EVENT_QUEUE_OVERFLOW", "spill")
+	t.Setenv("EVENT_QUEUE_BLOCK_TIMEOUT", "2")
 
 	cfg := config.Load()
 
@@ -72,6 +149,12 @@ func TestLoad_EnvOverrides(t *testing.T) {
 	if cfg.Port != "9000" {
 		t.Errorf("expected PORT=9000, got %s", cfg.Port)
 	}
+	if cfg.Mode != config.ModeAPI {
+		t.Errorf("expected MODE=api, got %s", cfg.Mode)
+	}
+	if cfg.HealthPort != "9001" {
+		t.Errorf("expected HEALTH_PORT=9001, got %s", cfg.HealthPort)
+	}
 	if cfg.RequestTimeout != 120*time.Second {
 		t.Errorf("expected RequestTimeout=120s, got %v", cfg.RequestTimeout)
 	}
@@ -90,12 +173,54 @@ func TestLoad_EnvOverrides(t *testing.T) {
 	if cfg.OllamaBaseUrl != "http://localhost:1234" {
 		t.Errorf("expected OllamaBaseUrl override, got %s", cfg.OllamaBaseUrl)
 	}
+	if cfg.OllamaModel != "mistral" {
+		t.Errorf("expected OllamaModel=mistral, got %s", cfg.OllamaModel)
+	}
+	if cfg.OllamaTimeout != 10*time.Minute {
+		t.Errorf("expected OllamaTimeout=10m, got %v", cfg.OllamaTimeout)
+	}
 	if cfg.NotionToken != "xyz" {
 		t.Errorf("expected NotionToken=xyz, got %s", cfg.NotionToken)
 	}
 	if cfg.NotionDatabaseId != "abc" {
 		t.Errorf("expected NotionDatabaseId=abc, got %s", cfg.NotionDatabaseId)
 	}
+	if cfg.DatabasePath != "/data/recipes.db" {
+		t.Errorf("expected DatabasePath=/data/recipes.db, got %s", cfg.DatabasePath)
+	}
+	if cfg.AdminToken != "s3cret" {
+		t.Errorf("expected AdminToken=s3cret, got %s", cfg.AdminToken)
+	}
+	if cfg.EventBus != config.EventBusNATS {
+		t.Errorf("expected EventBus=nats, got %s", cfg.EventBus)
+	}
+	if cfg.NATSURL != "nats://nats:4222" {
+		t.Errorf("expected NATSURL=nats://nats:4222, got %s", cfg.NATSURL)
+	}
+	if cfg.NATSStream != "EVENTS" {
+		t.Errorf("expected NATSStream=EVENTS, got %s", cfg.NATSStream)
+	}
+	if cfg.NATSMaxDeliver != 8 {
+		t.Errorf("expected NATSMaxDeliver=8, got %d", cfg.NATSMaxDeliver)
+	}
+	if cfg.RedisAddr != "redis:6379" {
+		t.Errorf("expected RedisAddr=redis:6379, got %s", cfg.RedisAddr)
+	}
+	if cfg.RedisPassword != "hunter2" {
+		t.Errorf("expected RedisPassword=hunter2, got %s", cfg.RedisPassword)
+	}
+	if cfg.RedisMaxLen != 500 {
+		t.Errorf("expected RedisMaxLen=500, got %d", cfg.RedisMaxLen)
+	}
+	if cfg.EventQueueSize != 1000 {
+		t.Errorf("expected EventQueueSize=1000, got %d", cfg.EventQueueSize)
+	}
+	if cfg.EventQueueOverflow != "spill" {
+		t.Errorf("expected EventQueueOverflow=spill, got %s", cfg.EventQueueOverflow)
+	}
+	if cfg.EventQueueBlockTimeout != 2*time.Second {
+		t.Errorf("expected EventQueueBlockTimeout=2s, got %v", cfg.EventQueueBlockTimeout)
+	}
 }
 
 func TestLoad_InvalidDurationFallback(t *testing.T) {
@@ -119,3 +244,17 @@ func TestLoad_InvalidDurationFallback(t *testing.T) {
 		t.Errorf("expected default IdleTimeout=60m on invalid input, got %v", cfg.IdleTimeout)
 	}
 }
+
+func TestLoad_InvalidIntFallback(t *testing.T) {
+	t.Setenv("NATS_MAX_DELIVER", "many")
+	t.Setenv("REDIS_STREAM_MAX_LEN", "-1")
+
+	cfg := config.Load()
+
+	if cfg.NATSMaxDeliver != 5 {
+		t.Errorf("expected default NATSMaxDeliver=5 on invalid input, got %d", cfg.NATSMaxDeliver)
+	}
+	if cfg.RedisMaxLen != 100000 {
+		t.Errorf("expected default RedisMaxLen=100000 on invalid input, got %d", cfg.RedisMaxLen)
+	}
+}
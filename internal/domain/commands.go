@@ -0,0 +1,142 @@
+package domain
+
+import "time"
+
+// Commands ask a handler to run one processing step; they travel over the
+// event bus like events, but name what should happen rather than what did
+const (
+	CommandTypeParseRecipe         = "recipe.parse"
+	CommandTypeExportRecipe        = "recipe.export"
+	CommandTypeNotifyRecipe        = "recipe.notify"
+	CommandTypeArchiveRecipeExport = "recipe.archive_export"
+)
+
+// ProcessingStep names a step of recipe processing
+type ProcessingStep string
+
+const (
+	StepParse    ProcessingStep = "parse"
+	StepValidate ProcessingStep = "validate"
+	StepExport   ProcessingStep = "export"
+	StepNotify   ProcessingStep = "notify"
+)
+
+type ParseRecipe struct {
+	RecipeID   string
+	RecipeText string
+	occurredAt time.Time
+}
+
+// NewParseRecipe creates a new ParseRecipe command
+func NewParseRecipe(recipeID, recipeText string) *ParseRecipe {
+	return &ParseRecipe{
+		RecipeID:   recipeID,
+		RecipeText: recipeText,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (c *ParseRecipe) EventType() string {
+	return CommandTypeParseRecipe
+}
+
+// OccurredAt implements Event interface
+func (c *ParseRecipe) OccurredAt() time.Time {
+	return c.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the command belongs to
+func (c *ParseRecipe) AggregateID() string {
+	return c.RecipeID
+}
+
+type ExportRecipe struct {
+	RecipeID   string
+	Recipe     *Recipe
+	occurredAt time.Time
+}
+
+// NewExportRecipe creates a new ExportRecipe command
+func NewExportRecipe(recipeID string, recipe *Recipe) *ExportRecipe {
+	return &ExportRecipe{
+		RecipeID:   recipeID,
+		Recipe:     recipe,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (c *ExportRecipe) EventType() string {
+	return CommandTypeExportRecipe
+}
+
+// OccurredAt implements Event interface
+func (c *ExportRecipe) OccurredAt() time.Time {
+	return c.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the command belongs to
+func (c *ExportRecipe) AggregateID() string {
+	return c.RecipeID
+}
+
+type NotifyRecipe struct {
+	RecipeID   string
+	Reference  ExportReference
+	occurredAt time.Time
+}
+
+// NewNotifyRecipe creates a new NotifyRecipe command
+func NewNotifyRecipe(recipeID string, ref ExportReference) *NotifyRecipe {
+	return &NotifyRecipe{
+		RecipeID:   recipeID,
+		Reference:  ref,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (c *NotifyRecipe) EventType() string {
+	return CommandTypeNotifyRecipe
+}
+
+// OccurredAt implements Event interface
+func (c *NotifyRecipe) OccurredAt() time.Time {
+	return c.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the command belongs to
+func (c *NotifyRecipe) AggregateID() string {
+	return c.RecipeID
+}
+
+type ArchiveRecipeExport struct {
+	RecipeID   string
+	Reference  ExportReference
+	occurredAt time.Time
+}
+
+// NewArchiveRecipeExport creates a new ArchiveRecipeExport command
+func NewArchiveRecipeExport(recipeID string, ref ExportReference) *ArchiveRecipeExport {
+	return &ArchiveRecipeExport{
+		RecipeID:   recipeID,
+		Reference:  ref,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (c *ArchiveRecipeExport) EventType() string {
+	return CommandTypeArchiveRecipeExport
+}
+
+// OccurredAt implements Event interface
+func (c *ArchiveRecipeExport) OccurredAt() time.Time {
+	return c.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the command belongs to
+func (c *ArchiveRecipeExport) AggregateID() string {
+	return c.RecipeID
+}
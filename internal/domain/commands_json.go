@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Wire formats of the recipe commands
+
+type parseRecipeJSON struct {
+	RecipeID   string    `json:"recipe_id"`
+	RecipeText string    `json:"recipe_text"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type exportRecipeJSON struct {
+	RecipeID   string    `json:"recipe_id"`
+	Recipe     *Recipe   `json:"recipe"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type exportCommandJSON struct {
+	RecipeID   string    `json:"recipe_id"`
+	PageID     string    `json:"page_id"`
+	URL        string    `json:"url"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (c *ParseRecipe) MarshalJSON() ([]byte, error) {
+	return json.Marshal(parseRecipeJSON{
+		RecipeID:   c.RecipeID,
+		RecipeText: c.RecipeText,
+		OccurredAt: c.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *ParseRecipe) UnmarshalJSON(data []byte) error {
+	var in parseRecipeJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*c = ParseRecipe{RecipeID: in.RecipeID, RecipeText: in.RecipeText, occurredAt: in.OccurredAt}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (c *ExportRecipe) MarshalJSON() ([]byte, error) {
+	return json.Marshal(exportRecipeJSON{
+		RecipeID:   c.RecipeID,
+		Recipe:     c.Recipe,
+		OccurredAt: c.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *ExportRecipe) UnmarshalJSON(data []byte) error {
+	var in exportRecipeJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*c = ExportRecipe{RecipeID: in.RecipeID, Recipe: in.Recipe, occurredAt: in.OccurredAt}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (c *NotifyRecipe) MarshalJSON() ([]byte, error) {
+	return json.Marshal(exportCommandJSON{
+		RecipeID:   c.RecipeID,
+		PageID:     c.Reference.PageID,
+		URL:        c.Reference.URL,
+		OccurredAt: c.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *NotifyRecipe) UnmarshalJSON(data []byte) error {
+	var in exportCommandJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*c = NotifyRecipe{
+		RecipeID:   in.RecipeID,
+		Reference:  ExportReference{PageID: in.PageID, URL: in.URL},
+		occurredAt: in.OccurredAt,
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (c *ArchiveRecipeExport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(exportCommandJSON{
+		RecipeID:   c.RecipeID,
+		PageID:     c.Reference.PageID,
+		URL:        c.Reference.URL,
+		OccurredAt: c.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *ArchiveRecipeExport) UnmarshalJSON(data []byte) error {
+	var in exportCommandJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*c = ArchiveRecipeExport{
+		RecipeID:   in.RecipeID,
+		Reference:  ExportReference{PageID: in.PageID, URL: in.URL},
+		occurredAt: in.OccurredAt,
+	}
+	return nil
+}
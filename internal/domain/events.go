@@ -4,6 +4,13 @@ import "time"
 
 const (
 	EventTypeRecipeSubmitted = "recipe.submitted"
+	EventTypeRecipeParsed    = "recipe.parsed"
+	EventTypeRecipeExported  = "recipe.exported"
+	EventTypeRecipeFailed    = "recipe.failed"
+
+	EventTypeRecipeNotified       = "recipe.notified"
+	EventTypeRecipeExportArchived = "recipe.export_archived"
+	EventTypeRecipeStepTimedOut   = "recipe.step_timed_out"
 )
 
 type RecipeSubmitted struct {
@@ -30,3 +37,206 @@ func (e *RecipeSubmitted) EventType() string {
 func (e *RecipeSubmitted) OccurredAt() time.Time {
 	return e.occurredAt
 }
+
+// AggregateID returns the ID of the recipe the event belongs to
+func (e *RecipeSubmitted) AggregateID() string {
+	return e.RecipeID
+}
+
+type RecipeParsed struct {
+	RecipeID   string
+	Recipe     *Recipe
+	occurredAt time.Time
+}
+
+// NewRecipeParsed creates a new RecipeParsed event
+func NewRecipeParsed(recipeID string, recipe *Recipe) *RecipeParsed {
+	return &RecipeParsed{
+		RecipeID:   recipeID,
+		Recipe:     recipe,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (e *RecipeParsed) EventType() string {
+	return EventTypeRecipeParsed
+}
+
+// OccurredAt implements Event interface
+func (e *RecipeParsed) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the event belongs to
+func (e *RecipeParsed) AggregateID() string {
+	return e.RecipeID
+}
+
+type RecipeExported struct {
+	RecipeID   string
+	Reference  ExportReference
+	occurredAt time.Time
+}
+
+// NewRecipeExported creates a new RecipeExported event
+func NewRecipeExported(recipeID string, ref ExportReference) *RecipeExported {
+	return &RecipeExported{
+		RecipeID:   recipeID,
+		Reference:  ref,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (e *RecipeExported) EventType() string {
+	return EventTypeRecipeExported
+}
+
+// OccurredAt implements Event interface
+func (e *RecipeExported) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the event belongs to
+func (e *RecipeExported) AggregateID() string {
+	return e.RecipeID
+}
+
+type RecipeProcessingFailed struct {
+	RecipeID string
+	Stage    RecipeStatus
+	Reason   string
+	// WillRetry is set when the bus retries the failed step, so the failure
+	// is not final yet
+	WillRetry bool
+	// PartialExport is the half-written page a failed export left behind
+	PartialExport *ExportReference
+	occurredAt    time.Time
+}
+
+// NewRecipeProcessingFailed creates a new RecipeProcessingFailed event
+// Stage is the status the recipe was in when processing failed
+func NewRecipeProcessingFailed(recipeID string, stage RecipeStatus, reason string) *RecipeProcessingFailed {
+	return &RecipeProcessingFailed{
+		RecipeID:   recipeID,
+		Stage:      stage,
+		Reason:     reason,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (e *RecipeProcessingFailed) EventType() string {
+	return EventTypeRecipeFailed
+}
+
+// OccurredAt implements Event interface
+func (e *RecipeProcessingFailed) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the event belongs to
+func (e *RecipeProcessingFailed) AggregateID() string {
+	return e.RecipeID
+}
+
+type RecipeNotified struct {
+	RecipeID   string
+	Reference  ExportReference
+	occurredAt time.Time
+}
+
+// NewRecipeNotified creates a new RecipeNotified event
+func NewRecipeNotified(recipeID string, ref ExportReference) *RecipeNotified {
+	return &RecipeNotified{
+		RecipeID:   recipeID,
+		Reference:  ref,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (e *RecipeNotified) EventType() string {
+	return EventTypeRecipeNotified
+}
+
+// OccurredAt implements Event interface
+func (e *RecipeNotified) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the event belongs to
+func (e *RecipeNotified) AggregateID() string {
+	return e.RecipeID
+}
+
+type RecipeExportArchived struct {
+	RecipeID   string
+	Reference  ExportReference
+	occurredAt time.Time
+}
+
+// NewRecipeExportArchived creates a new RecipeExportArchived event
+func NewRecipeExportArchived(recipeID string, ref ExportReference) *RecipeExportArchived {
+	return &RecipeExportArchived{
+		RecipeID:   recipeID,
+		Reference:  ref,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (e *RecipeExportArchived) EventType() string {
+	return EventTypeRecipeExportArchived
+}
+
+// OccurredAt implements Event interface
+func (e *RecipeExportArchived) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the event belongs to
+func (e *RecipeExportArchived) AggregateID() string {
+	return e.RecipeID
+}
+
+type RecipeStepTimedOut struct {
+	RecipeID string
+	Step     ProcessingStep
+	// Run is the processing run the step belongs to, so timeouts of an
+	// earlier run of the same recipe can be told apart
+	Run        int
+	occurredAt time.Time
+}
+
+// NewRecipeStepTimedOut creates a new RecipeStepTimedOut event
+func NewRecipeStepTimedOut(recipeID string, step ProcessingStep, run int) *RecipeStepTimedOut {
+	return &RecipeStepTimedOut{
+		RecipeID:   recipeID,
+		Step:       step,
+		Run:        run,
+		occurredAt: time.Now(),
+	}
+}
+
+// EventType implements Event interface
+func (e *RecipeStepTimedOut) EventType() string {
+	return EventTypeRecipeStepTimedOut
+}
+
+// OccurredAt implements Event interface
+func (e *RecipeStepTimedOut) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// AggregateID returns the ID of the recipe the event belongs to
+func (e *RecipeStepTimedOut) AggregateID() string {
+	return e.RecipeID
+}
+
+// OrderingKey is empty so the timeout is not queued behind the stuck step it
+// reports, which holds the recipe's place in order on the memory event bus
+func (e *RecipeStepTimedOut) OrderingKey() string {
+	return ""
+}
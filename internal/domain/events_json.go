@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Wire formats of the recipe events, used when events leave the process
+// (outbox, external brokers)
+
+type recipeSubmittedJSON struct {
+	RecipeID   string    `json:"recipe_id"`
+	RecipeText string    `json:"recipe_text"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type recipeParsedJSON struct {
+	RecipeID   string    `json:"recipe_id"`
+	Recipe     *Recipe   `json:"recipe"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type recipeExportedJSON struct {
+	RecipeID   string    `json:"recipe_id"`
+	PageID     string    `json:"page_id"`
+	URL        string    `json:"url"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type recipeProcessingFailedJSON struct {
+	RecipeID      string               `json:"recipe_id"`
+	Stage         RecipeStatus         `json:"stage"`
+	Reason        string               `json:"reason"`
+	WillRetry     bool                 `json:"will_retry,omitempty"`
+	PartialExport *exportReferenceJSON `json:"partial_export,omitempty"`
+	OccurredAt    time.Time            `json:"occurred_at"`
+}
+
+type exportReferenceJSON struct {
+	PageID string `json:"page_id"`
+	URL    string `json:"url"`
+}
+
+type recipeNotifiedJSON struct {
+	RecipeID   string    `json:"recipe_id"`
+	PageID     string    `json:"page_id"`
+	URL        string    `json:"url"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type recipeExportArchivedJSON struct {
+	RecipeID   string    `json:"recipe_id"`
+	PageID     string    `json:"page_id"`
+	URL        string    `json:"url"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type recipeStepTimedOutJSON struct {
+	RecipeID   string         `json:"recipe_id"`
+	Step       ProcessingStep `json:"step"`
+	Run        int            `json:"run"`
+	OccurredAt time.Time      `json:"occurred_at"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (e *RecipeSubmitted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recipeSubmittedJSON{
+		RecipeID:   e.RecipeID,
+		RecipeText: e.RecipeText,
+		OccurredAt: e.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *RecipeSubmitted) UnmarshalJSON(data []byte) error {
+	var in recipeSubmittedJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = RecipeSubmitted{RecipeID: in.RecipeID, RecipeText: in.RecipeText, occurredAt: in.OccurredAt}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (e *RecipeParsed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recipeParsedJSON{
+		RecipeID:   e.RecipeID,
+		Recipe:     e.Recipe,
+		OccurredAt: e.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *RecipeParsed) UnmarshalJSON(data []byte) error {
+	var in recipeParsedJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = RecipeParsed{RecipeID: in.RecipeID, Recipe: in.Recipe, occurredAt: in.OccurredAt}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (e *RecipeExported) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recipeExportedJSON{
+		RecipeID:   e.RecipeID,
+		PageID:     e.Reference.PageID,
+		URL:        e.Reference.URL,
+		OccurredAt: e.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *RecipeExported) UnmarshalJSON(data []byte) error {
+	var in recipeExportedJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = RecipeExported{
+		RecipeID:   in.RecipeID,
+		Reference:  ExportReference{PageID: in.PageID, URL: in.URL},
+		occurredAt: in.OccurredAt,
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (e *RecipeProcessingFailed) MarshalJSON() ([]byte, error) {
+	out := recipeProcessingFailedJSON{
+		RecipeID:   e.RecipeID,
+		Stage:      e.Stage,
+		Reason:     e.Reason,
+		WillRetry:  e.WillRetry,
+		OccurredAt: e.occurredAt,
+	}
+	if e.PartialExport != nil {
+		out.PartialExport = &exportReferenceJSON{PageID: e.PartialExport.PageID, URL: e.PartialExport.URL}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *RecipeProcessingFailed) UnmarshalJSON(data []byte) error {
+	var in recipeProcessingFailedJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = RecipeProcessingFailed{
+		RecipeID:   in.RecipeID,
+		Stage:      in.Stage,
+		Reason:     in.Reason,
+		WillRetry:  in.WillRetry,
+		occurredAt: in.OccurredAt,
+	}
+	if in.PartialExport != nil {
+		e.PartialExport = &ExportReference{PageID: in.PartialExport.PageID, URL: in.PartialExport.URL}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (e *RecipeNotified) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recipeNotifiedJSON{
+		RecipeID:   e.RecipeID,
+		PageID:     e.Reference.PageID,
+		URL:        e.Reference.URL,
+		OccurredAt: e.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *RecipeNotified) UnmarshalJSON(data []byte) error {
+	var in recipeNotifiedJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = RecipeNotified{
+		RecipeID:   in.RecipeID,
+		Reference:  ExportReference{PageID: in.PageID, URL: in.URL},
+		occurredAt: in.OccurredAt,
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (e *RecipeExportArchived) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recipeExportArchivedJSON{
+		RecipeID:   e.RecipeID,
+		PageID:     e.Reference.PageID,
+		URL:        e.Reference.URL,
+		OccurredAt: e.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *RecipeExportArchived) UnmarshalJSON(data []byte) error {
+	var in recipeExportArchivedJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = RecipeExportArchived{
+		RecipeID:   in.RecipeID,
+		Reference:  ExportReference{PageID: in.PageID, URL: in.URL},
+		occurredAt: in.OccurredAt,
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (e *RecipeStepTimedOut) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recipeStepTimedOutJSON{
+		RecipeID:   e.RecipeID,
+		Step:       e.Step,
+		Run:        e.Run,
+		OccurredAt: e.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *RecipeStepTimedOut) UnmarshalJSON(data []byte) error {
+	var in recipeStepTimedOutJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = RecipeStepTimedOut{RecipeID: in.RecipeID, Step: in.Step, Run: in.Run, occurredAt: in.OccurredAt}
+	return nil
+}
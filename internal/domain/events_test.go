@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"encoding/json"
 	"recipe-processor/internal/domain"
 	"testing"
 	"time"
@@ -22,3 +23,127 @@ func TestNewRecipeSubmitted_EventTypeAndTimestamp(t *testing.T) {
 		t.Fatalf("OccurredAt is too far in the past: %v", e.OccurredAt())
 	}
 }
+
+func TestNewRecipeParsed_EventTypeAndPayload(t *testing.T) {
+	parsed := newTestRecipe(t, "recipe-123")
+
+	e := domain.NewRecipeParsed("recipe-123", parsed)
+
+	if et := e.EventType(); et != domain.EventTypeRecipeParsed {
+		t.Fatalf("EventType() = %q, want %q", et, domain.EventTypeRecipeParsed)
+	}
+
+	if e.RecipeID != "recipe-123" {
+		t.Fatalf("RecipeID = %q, want %q", e.RecipeID, "recipe-123")
+	}
+
+	if e.Recipe.Title() != "Pancakes" {
+		t.Fatalf("Recipe.Title() = %q, want %q", e.Recipe.Title(), "Pancakes")
+	}
+
+	if time.Since(e.OccurredAt()) > 5*time.Second {
+		t.Fatalf("OccurredAt is too far in the past: %v", e.OccurredAt())
+	}
+}
+
+func TestNewRecipeExported_EventTypeAndReference(t *testing.T) {
+	ref := domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}
+
+	e := domain.NewRecipeExported("recipe-123", ref)
+
+	if et := e.EventType(); et != domain.EventTypeRecipeExported {
+		t.Fatalf("EventType() = %q, want %q", et, domain.EventTypeRecipeExported)
+	}
+
+	if e.Reference != ref {
+		t.Fatalf("Reference = %+v, want %+v", e.Reference, ref)
+	}
+}
+
+func TestNewRecipeProcessingFailed_EventTypeAndReason(t *testing.T) {
+	e := domain.NewRecipeProcessingFailed("recipe-123", domain.StatusParsing, "ollama unavailable")
+
+	if et := e.EventType(); et != domain.EventTypeRecipeFailed {
+		t.Fatalf("EventType() = %q, want %q", et, domain.EventTypeRecipeFailed)
+	}
+
+	if e.Stage != domain.StatusParsing || e.Reason != "ollama unavailable" {
+		t.Fatalf("unexpected stage/reason: %q %q", e.Stage, e.Reason)
+	}
+}
+
+func TestRecipeEvents_JSONRoundTrip(t *testing.T) {
+	submitted := domain.NewRecipeSubmitted("recipe-1", "Pancakes")
+	data, err := json.Marshal(submitted)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decodedSubmitted domain.RecipeSubmitted
+	if err := json.Unmarshal(data, &decodedSubmitted); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decodedSubmitted.RecipeText != "Pancakes" || !decodedSubmitted.OccurredAt().Equal(submitted.OccurredAt()) {
+		t.Errorf("unexpected RecipeSubmitted after round trip: %+v", decodedSubmitted)
+	}
+
+	parsed := domain.NewRecipeParsed("recipe-1", newTestRecipe(t, "recipe-1"))
+	data, _ = json.Marshal(parsed)
+
+	var decodedParsed domain.RecipeParsed
+	if err := json.Unmarshal(data, &decodedParsed); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decodedParsed.Recipe == nil || decodedParsed.Recipe.Title() != "Pancakes" {
+		t.Errorf("unexpected RecipeParsed after round trip: %+v", decodedParsed)
+	}
+
+	exported := domain.NewRecipeExported("recipe-1", domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"})
+	data, _ = json.Marshal(exported)
+
+	var decodedExported domain.RecipeExported
+	if err := json.Unmarshal(data, &decodedExported); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decodedExported.Reference != exported.Reference {
+		t.Errorf("Reference = %+v, want %+v", decodedExported.Reference, exported.Reference)
+	}
+
+	failed := domain.NewRecipeProcessingFailed("recipe-1", domain.StatusParsing, "model unavailable")
+	data, _ = json.Marshal(failed)
+
+	var decodedFailed domain.RecipeProcessingFailed
+	if err := json.Unmarshal(data, &decodedFailed); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decodedFailed.Stage != domain.StatusParsing || decodedFailed.Reason != "model unavailable" {
+		t.Errorf("unexpected RecipeProcessingFailed after round trip: %+v", decodedFailed)
+	}
+	if decodedFailed.WillRetry || decodedFailed.PartialExport != nil {
+		t.Errorf("expected no retry or partial export, got %+v", decodedFailed)
+	}
+
+	partial := domain.NewRecipeProcessingFailed("recipe-1", domain.StatusExporting, "notion unavailable")
+	partial.WillRetry = true
+	partial.PartialExport = &domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}
+	data, _ = json.Marshal(partial)
+
+	var decodedPartial domain.RecipeProcessingFailed
+	if err := json.Unmarshal(data, &decodedPartial); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !decodedPartial.WillRetry || decodedPartial.PartialExport == nil || *decodedPartial.PartialExport != *partial.PartialExport {
+		t.Errorf("unexpected partial RecipeProcessingFailed after round trip: %+v", decodedPartial)
+	}
+
+	timedOut := domain.NewRecipeStepTimedOut("recipe-1", domain.StepExport, 2)
+	data, _ = json.Marshal(timedOut)
+
+	var decodedTimedOut domain.RecipeStepTimedOut
+	if err := json.Unmarshal(data, &decodedTimedOut); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decodedTimedOut.Step != domain.StepExport || decodedTimedOut.Run != 2 {
+		t.Errorf("unexpected RecipeStepTimedOut after round trip: %+v", decodedTimedOut)
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "fmt"
+
+// ExportReference identifies where a recipe was exported to
+type ExportReference struct {
+	PageID string
+	URL    string
+}
+
+// IncompleteExportError reports an export that failed after it started
+// writing, leaving a partial page at Reference behind
+type IncompleteExportError struct {
+	Reference ExportReference
+	Err       error
+}
+
+func (e *IncompleteExportError) Error() string {
+	return fmt.Sprintf("export to %s left incomplete: %v", e.Reference.PageID, e.Err)
+}
+
+func (e *IncompleteExportError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrIngredientNameEmpty        = errors.New("ingredient name cannot be empty")
+	ErrIngredientQuantityNegative = errors.New("ingredient quantity cannot be negative")
+	ErrIngredientUnitWithoutQty   = errors.New("ingredient unit requires a quantity")
+)
+
+// IngredientParams holds the raw values for creating an Ingredient
+type IngredientParams struct {
+	Quantity    float64
+	Unit        string
+	Name        string
+	Preparation string
+	Optional    bool
+}
+
+// Ingredient is a single line of a recipe's ingredient list
+type Ingredient struct {
+	quantity    float64
+	unit        string
+	name        string
+	preparation string
+	optional    bool
+}
+
+// NewIngredient creates a validated ingredient
+// A zero quantity means "unspecified", e.g. "salt to taste"
+func NewIngredient(p IngredientParams) (*Ingredient, error) {
+	name := strings.TrimSpace(p.Name)
+	unit := strings.TrimSpace(p.Unit)
+
+	if name == "" {
+		return nil, ErrIngredientNameEmpty
+	}
+
+	if p.Quantity < 0 {
+		return nil, ErrIngredientQuantityNegative
+	}
+
+	if unit != "" && p.Quantity == 0 {
+		return nil, ErrIngredientUnitWithoutQty
+	}
+
+	return &Ingredient{
+		quantity:    p.Quantity,
+		unit:        unit,
+		name:        name,
+		preparation: strings.TrimSpace(p.Preparation),
+		optional:    p.Optional,
+	}, nil
+}
+
+// Quantity returns the amount, or zero when unspecified
+func (i Ingredient) Quantity() float64 {
+	return i.quantity
+}
+
+// Unit returns the unit of measure, empty for countable items
+func (i Ingredient) Unit() string {
+	return i.unit
+}
+
+// Name returns the ingredient name
+func (i Ingredient) Name() string {
+	return i.name
+}
+
+// Preparation returns the preparation note, e.g. "finely chopped"
+func (i Ingredient) Preparation() string {
+	return i.preparation
+}
+
+// Optional reports whether the ingredient may be left out
+func (i Ingredient) Optional() bool {
+	return i.optional
+}
+
+// String renders the ingredient as a human readable line
+func (i Ingredient) String() string {
+	var b strings.Builder
+
+	if i.quantity > 0 {
+		b.WriteString(strconv.FormatFloat(i.quantity, 'f', -1, 64))
+		b.WriteByte(' ')
+	}
+	if i.unit != "" {
+		b.WriteString(i.unit)
+		b.WriteByte(' ')
+	}
+	b.WriteString(i.name)
+	if i.preparation != "" {
+		b.WriteString(", ")
+		b.WriteString(i.preparation)
+	}
+	if i.optional {
+		b.WriteString(" (optional)")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"recipe-processor/internal/domain"
+	"testing"
+)
+
+func TestNewIngredient_Valid(t *testing.T) {
+	ing, err := domain.NewIngredient(domain.IngredientParams{
+		Quantity:    1.5,
+		Unit:        " cups ",
+		Name:        " onion ",
+		Preparation: "finely chopped",
+		Optional:    true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if ing.Name() != "onion" || ing.Unit() != "cups" {
+		t.Fatalf("expected trimmed name and unit, got %q %q", ing.Name(), ing.Unit())
+	}
+
+	want := "1.5 cups onion, finely chopped (optional)"
+	if s := ing.String(); s != want {
+		t.Fatalf("String() = %q, want %q", s, want)
+	}
+}
+
+func TestNewIngredient_UnspecifiedQuantity(t *testing.T) {
+	ing, err := domain.NewIngredient(domain.IngredientParams{Name: "salt", Preparation: "to taste"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if s := ing.String(); s != "salt, to taste" {
+		t.Fatalf("String() = %q, want %q", s, "salt, to taste")
+	}
+}
+
+func TestNewIngredient_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params domain.IngredientParams
+		want   error
+	}{
+		{"empty name", domain.IngredientParams{Name: "  "}, domain.ErrIngredientNameEmpty},
+		{"negative quantity", domain.IngredientParams{Name: "egg", Quantity: -1}, domain.ErrIngredientQuantityNegative},
+		{"unit without quantity", domain.IngredientParams{Name: "flour", Unit: "g"}, domain.ErrIngredientUnitWithoutQty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewIngredient(tt.params)
+			if err != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -2,16 +2,28 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 )
 
 const (
-	MaxRecipeTextLength = 10_000
+	MaxRecipeTextLength  = 10_000
+	MaxRecipeTitleLength = 200
 )
 
 var (
 	ErrRecipeTextEmpty   = errors.New("recipe text cannot be empty")
 	ErrRecipeTextTooLong = errors.New("recipe text exceeds maximum length")
+
+	ErrRecipeIDEmpty          = errors.New("recipe id cannot be empty")
+	ErrRecipeTitleEmpty       = errors.New("recipe title cannot be empty")
+	ErrRecipeTitleTooLong     = errors.New("recipe title exceeds maximum length")
+	ErrRecipeServingsNegative = errors.New("recipe servings cannot be negative")
+	ErrRecipeTimeNegative     = errors.New("recipe times cannot be negative")
+	ErrRecipeTotalTimeTooLow  = errors.New("recipe total time is shorter than prep and cook time")
+	ErrRecipeNoIngredients    = errors.New("recipe must have at least one ingredient")
+	ErrRecipeNoSteps          = errors.New("recipe must have at least one step")
 )
 
 type RecipeText struct {
@@ -41,3 +53,183 @@ func (rt *RecipeText) Value() string {
 func (rt *RecipeText) String() string {
 	return rt.value
 }
+
+// RecipeParams holds the raw values for creating a Recipe
+type RecipeParams struct {
+	ID          string
+	Title       string
+	Servings    int
+	PrepTime    time.Duration
+	CookTime    time.Duration
+	TotalTime   time.Duration
+	Yield       string
+	Source      string
+	Tags        []string
+	Ingredients []Ingredient
+	Steps       []Step
+}
+
+// Recipe is the structured recipe aggregate
+type Recipe struct {
+	id          string
+	title       string
+	servings    int
+	prepTime    time.Duration
+	cookTime    time.Duration
+	totalTime   time.Duration
+	yield       string
+	source      string
+	tags        []string
+	ingredients []Ingredient
+	steps       []Step
+}
+
+// NewRecipe creates a validated recipe
+// Zero servings and times mean "unknown"; an unknown total time is derived
+// from prep and cook time. Steps are numbered in the order given.
+func NewRecipe(p RecipeParams) (*Recipe, error) {
+	id := strings.TrimSpace(p.ID)
+	title := strings.TrimSpace(p.Title)
+
+	if id == "" {
+		return nil, ErrRecipeIDEmpty
+	}
+
+	if title == "" {
+		return nil, ErrRecipeTitleEmpty
+	}
+
+	if len(title) > MaxRecipeTitleLength {
+		return nil, ErrRecipeTitleTooLong
+	}
+
+	if p.Servings < 0 {
+		return nil, ErrRecipeServingsNegative
+	}
+
+	if p.PrepTime < 0 || p.CookTime < 0 || p.TotalTime < 0 {
+		return nil, ErrRecipeTimeNegative
+	}
+
+	totalTime := p.TotalTime
+	if totalTime == 0 {
+		totalTime = p.PrepTime + p.CookTime
+	} else if totalTime < p.PrepTime+p.CookTime {
+		return nil, ErrRecipeTotalTimeTooLow
+	}
+
+	if len(p.Ingredients) == 0 {
+		return nil, ErrRecipeNoIngredients
+	}
+
+	if len(p.Steps) == 0 {
+		return nil, ErrRecipeNoSteps
+	}
+
+	for i, step := range p.Steps {
+		if step.text == "" {
+			return nil, fmt.Errorf("step %d: %w", i+1, ErrStepTextEmpty)
+		}
+	}
+
+	for i, ingredient := range p.Ingredients {
+		if ingredient.name == "" {
+			return nil, fmt.Errorf("ingredient %d: %w", i+1, ErrIngredientNameEmpty)
+		}
+	}
+
+	steps := make([]Step, len(p.Steps))
+	for i, step := range p.Steps {
+		step.number = i + 1
+		steps[i] = step
+	}
+
+	return &Recipe{
+		id:          id,
+		title:       title,
+		servings:    p.Servings,
+		prepTime:    p.PrepTime,
+		cookTime:    p.CookTime,
+		totalTime:   totalTime,
+		yield:       strings.TrimSpace(p.Yield),
+		source:      strings.TrimSpace(p.Source),
+		tags:        normalizeTags(p.Tags),
+		ingredients: append([]Ingredient(nil), p.Ingredients...),
+		steps:       steps,
+	}, nil
+}
+
+// ID returns the recipe identifier
+func (r *Recipe) ID() string {
+	return r.id
+}
+
+// Title returns the recipe title
+func (r *Recipe) Title() string {
+	return r.title
+}
+
+// Servings returns the number of servings, zero when unknown
+func (r *Recipe) Servings() int {
+	return r.servings
+}
+
+// PrepTime returns the preparation time, zero when unknown
+func (r *Recipe) PrepTime() time.Duration {
+	return r.prepTime
+}
+
+// CookTime returns the cooking time, zero when unknown
+func (r *Recipe) CookTime() time.Duration {
+	return r.cookTime
+}
+
+// TotalTime returns the total time, zero when unknown
+func (r *Recipe) TotalTime() time.Duration {
+	return r.totalTime
+}
+
+// Yield returns the free-form yield, e.g. "24 cookies"
+func (r *Recipe) Yield() string {
+	return r.yield
+}
+
+// Source returns where the recipe came from, e.g. a URL or book
+func (r *Recipe) Source() string {
+	return r.source
+}
+
+// Tags returns a copy of the normalized tags
+func (r *Recipe) Tags() []string {
+	return append([]string(nil), r.tags...)
+}
+
+// Ingredients returns a copy of the ingredient list
+func (r *Recipe) Ingredients() []Ingredient {
+	return append([]Ingredient(nil), r.ingredients...)
+}
+
+// Steps returns a copy of the ordered steps
+func (r *Recipe) Steps() []Step {
+	return append([]Step(nil), r.steps...)
+}
+
+// normalizeTags lowercases, trims and de-duplicates tags, keeping their order
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	result := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+
+	return result
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// recipeJSON is the wire format of a Recipe
+// Durations are stored in whole seconds to stay readable outside Go
+type recipeJSON struct {
+	ID               string           `json:"id"`
+	Title            string           `json:"title"`
+	Servings         int              `json:"servings,omitempty"`
+	PrepTimeSeconds  int64            `json:"prep_time_seconds,omitempty"`
+	CookTimeSeconds  int64            `json:"cook_time_seconds,omitempty"`
+	TotalTimeSeconds int64            `json:"total_time_seconds,omitempty"`
+	Yield            string           `json:"yield,omitempty"`
+	Source           string           `json:"source,omitempty"`
+	Tags             []string         `json:"tags,omitempty"`
+	Ingredients      []ingredientJSON `json:"ingredients"`
+	Steps            []stepJSON       `json:"steps"`
+}
+
+type ingredientJSON struct {
+	Quantity    float64 `json:"quantity,omitempty"`
+	Unit        string  `json:"unit,omitempty"`
+	Name        string  `json:"name"`
+	Preparation string  `json:"preparation,omitempty"`
+	Optional    bool    `json:"optional,omitempty"`
+}
+
+type stepJSON struct {
+	Text            string           `json:"text"`
+	DurationSeconds int64            `json:"duration_seconds,omitempty"`
+	Temperature     *temperatureJSON `json:"temperature,omitempty"`
+}
+
+type temperatureJSON struct {
+	Value float64         `json:"value"`
+	Unit  TemperatureUnit `json:"unit"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (r *Recipe) MarshalJSON() ([]byte, error) {
+	out := recipeJSON{
+		ID:               r.id,
+		Title:            r.title,
+		Servings:         r.servings,
+		PrepTimeSeconds:  int64(r.prepTime / time.Second),
+		CookTimeSeconds:  int64(r.cookTime / time.Second),
+		TotalTimeSeconds: int64(r.totalTime / time.Second),
+		Yield:            r.yield,
+		Source:           r.source,
+		Tags:             r.tags,
+		Ingredients:      make([]ingredientJSON, len(r.ingredients)),
+		Steps:            make([]stepJSON, len(r.steps)),
+	}
+
+	for i, in := range r.ingredients {
+		out.Ingredients[i] = ingredientJSON{
+			Quantity:    in.quantity,
+			Unit:        in.unit,
+			Name:        in.name,
+			Preparation: in.preparation,
+			Optional:    in.optional,
+		}
+	}
+
+	for i, s := range r.steps {
+		out.Steps[i] = stepJSON{
+			Text:            s.text,
+			DurationSeconds: int64(s.duration / time.Second),
+		}
+		if s.temperature != nil {
+			out.Steps[i].Temperature = &temperatureJSON{Value: s.temperature.value, Unit: s.temperature.unit}
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+// The decoded values go through the same validation as NewRecipe
+func (r *Recipe) UnmarshalJSON(data []byte) error {
+	var in recipeJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	params := RecipeParams{
+		ID:          in.ID,
+		Title:       in.Title,
+		Servings:    in.Servings,
+		PrepTime:    time.Duration(in.PrepTimeSeconds) * time.Second,
+		CookTime:    time.Duration(in.CookTimeSeconds) * time.Second,
+		TotalTime:   time.Duration(in.TotalTimeSeconds) * time.Second,
+		Yield:       in.Yield,
+		Source:      in.Source,
+		Tags:        in.Tags,
+		Ingredients: make([]Ingredient, 0, len(in.Ingredients)),
+		Steps:       make([]Step, 0, len(in.Steps)),
+	}
+
+	for i, ij := range in.Ingredients {
+		ingredient, err := NewIngredient(IngredientParams{
+			Quantity:    ij.Quantity,
+			Unit:        ij.Unit,
+			Name:        ij.Name,
+			Preparation: ij.Preparation,
+			Optional:    ij.Optional,
+		})
+		if err != nil {
+			return fmt.Errorf("ingredient %d: %w", i+1, err)
+		}
+		params.Ingredients = append(params.Ingredients, *ingredient)
+	}
+
+	for i, sj := range in.Steps {
+		var temperature *Temperature
+		if sj.Temperature != nil {
+			t, err := NewTemperature(sj.Temperature.Value, sj.Temperature.Unit)
+			if err != nil {
+				return fmt.Errorf("step %d: %w", i+1, err)
+			}
+			temperature = t
+		}
+
+		step, err := NewStep(StepParams{
+			Text:        sj.Text,
+			Duration:    time.Duration(sj.DurationSeconds) * time.Second,
+			Temperature: temperature,
+		})
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		params.Steps = append(params.Steps, *step)
+	}
+
+	recipe, err := NewRecipe(params)
+	if err != nil {
+		return err
+	}
+
+	*r = *recipe
+	return nil
+}
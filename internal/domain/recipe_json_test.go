@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"recipe-processor/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestRecipe_JSONRoundTrip(t *testing.T) {
+	butter, _ := domain.NewIngredient(domain.IngredientParams{Quantity: 50, Unit: "g", Name: "butter", Preparation: "melted", Optional: true})
+	temp, _ := domain.NewTemperature(180, domain.Celsius)
+	bake, _ := domain.NewStep(domain.StepParams{Text: "Bake", Duration: 25 * time.Minute, Temperature: temp})
+
+	original, err := domain.NewRecipe(domain.RecipeParams{
+		ID:          "recipe-1",
+		Title:       "Cake",
+		Servings:    8,
+		PrepTime:    15 * time.Minute,
+		CookTime:    25 * time.Minute,
+		TotalTime:   time.Hour,
+		Yield:       "1 cake",
+		Source:      "grandma",
+		Tags:        []string{"dessert"},
+		Ingredients: []domain.Ingredient{*butter},
+		Steps:       []domain.Step{*bake},
+	})
+	if err != nil {
+		t.Fatalf("NewRecipe() error = %v", err)
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded domain.Recipe
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if decoded.Title() != "Cake" || decoded.Servings() != 8 || decoded.TotalTime() != time.Hour {
+		t.Errorf("unexpected recipe metadata after round trip: %s %d %v", decoded.Title(), decoded.Servings(), decoded.TotalTime())
+	}
+
+	if decoded.Yield() != "1 cake" || decoded.Source() != "grandma" {
+		t.Errorf("unexpected yield/source: %q %q", decoded.Yield(), decoded.Source())
+	}
+
+	ing := decoded.Ingredients()[0]
+	if ing.String() != butter.String() {
+		t.Errorf("ingredient = %q, want %q", ing.String(), butter.String())
+	}
+
+	step := decoded.Steps()[0]
+	if step.Number() != 1 || step.Duration() != 25*time.Minute || step.Temperature().Value() != 180 {
+		t.Errorf("unexpected step after round trip: %+v", step)
+	}
+}
+
+func TestRecipe_UnmarshalJSON_Validates(t *testing.T) {
+	var r domain.Recipe
+
+	err := json.Unmarshal([]byte(`{"id":"recipe-1","title":"Cake","ingredients":[],"steps":[{"text":"Bake"}]}`), &r)
+	if !errors.Is(err, domain.ErrRecipeNoIngredients) {
+		t.Fatalf("expected ErrRecipeNoIngredients, got %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type RecipeStatus string
+
+const (
+	StatusSubmitted RecipeStatus = "submitted"
+	StatusParsing   RecipeStatus = "parsing"
+	StatusParsed    RecipeStatus = "parsed"
+	StatusExporting RecipeStatus = "exporting"
+	StatusExported  RecipeStatus = "exported"
+	StatusFailed    RecipeStatus = "failed"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid recipe status transition")
+	ErrFailureReasonEmpty      = errors.New("failure reason cannot be empty")
+	ErrParsedRecipeMismatch    = errors.New("parsed recipe does not belong to this submission")
+)
+
+// allowedTransitions lists, per target status, the statuses it may be entered from
+// Re-entering parsing or exporting allows an interrupted or failed step to be retried;
+// parsing a parsed or exported recipe again lets replayed submissions reprocess it;
+// an exported recipe fails when a later step undoes its export
+var allowedTransitions = map[RecipeStatus][]RecipeStatus{
+	StatusParsing:   {StatusSubmitted, StatusParsing, StatusParsed, StatusExported, StatusFailed},
+	StatusParsed:    {StatusParsing},
+	StatusExporting: {StatusParsed, StatusExporting, StatusFailed},
+	StatusExported:  {StatusExporting},
+	StatusFailed:    {StatusSubmitted, StatusParsing, StatusParsed, StatusExporting, StatusExported, StatusFailed},
+}
+
+// StatusTransition records when a recipe entered a status
+type StatusTransition struct {
+	Status RecipeStatus
+	At     time.Time
+}
+
+// RecipeLifecycle tracks a submitted recipe through parsing and export
+type RecipeLifecycle struct {
+	id            string
+	rawText       string
+	status        RecipeStatus
+	transitions   []StatusTransition
+	failureReason string
+	recipe        *Recipe
+	export        *ExportReference
+}
+
+// NewRecipeLifecycle starts the lifecycle of a freshly submitted recipe
+func NewRecipeLifecycle(id string, text *RecipeText, at time.Time) (*RecipeLifecycle, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, ErrRecipeIDEmpty
+	}
+
+	return &RecipeLifecycle{
+		id:          id,
+		rawText:     text.Value(),
+		status:      StatusSubmitted,
+		transitions: []StatusTransition{{Status: StatusSubmitted, At: at}},
+	}, nil
+}
+
+// ID returns the recipe identifier
+func (l *RecipeLifecycle) ID() string {
+	return l.id
+}
+
+// RawText returns the text as it was submitted
+func (l *RecipeLifecycle) RawText() string {
+	return l.rawText
+}
+
+// Status returns the current status
+func (l *RecipeLifecycle) Status() RecipeStatus {
+	return l.status
+}
+
+// Transitions returns a copy of all status transitions, oldest first
+func (l *RecipeLifecycle) Transitions() []StatusTransition {
+	return append([]StatusTransition(nil), l.transitions...)
+}
+
+// SubmittedAt returns when the recipe was submitted
+func (l *RecipeLifecycle) SubmittedAt() time.Time {
+	return l.transitions[0].At
+}
+
+// UpdatedAt returns when the status last changed
+func (l *RecipeLifecycle) UpdatedAt() time.Time {
+	return l.transitions[len(l.transitions)-1].At
+}
+
+// FailureReason returns why processing failed, empty unless status is failed
+func (l *RecipeLifecycle) FailureReason() string {
+	return l.failureReason
+}
+
+// Recipe returns the parsed recipe, nil until parsing succeeded
+func (l *RecipeLifecycle) Recipe() *Recipe {
+	return l.recipe
+}
+
+// ExportReference returns where the recipe was exported, nil until exported
+func (l *RecipeLifecycle) ExportReference() *ExportReference {
+	return l.export
+}
+
+// StartParsing moves the recipe into parsing
+func (l *RecipeLifecycle) StartParsing(at time.Time) error {
+	return l.transition(StatusParsing, at)
+}
+
+// MarkParsed stores the parsed recipe and moves it into parsed
+func (l *RecipeLifecycle) MarkParsed(recipe *Recipe, at time.Time) error {
+	if recipe.ID() != l.id {
+		return ErrParsedRecipeMismatch
+	}
+
+	if err := l.transition(StatusParsed, at); err != nil {
+		return err
+	}
+
+	l.recipe = recipe
+	return nil
+}
+
+// StartExporting moves the recipe into exporting
+// A failed recipe can only resume exporting once it has been parsed
+func (l *RecipeLifecycle) StartExporting(at time.Time) error {
+	if l.recipe == nil {
+		return fmt.Errorf("%w: %s -> %s without a parsed recipe", ErrInvalidStatusTransition, l.status, StatusExporting)
+	}
+
+	return l.transition(StatusExporting, at)
+}
+
+// MarkExported stores the export reference and moves it into exported
+func (l *RecipeLifecycle) MarkExported(ref ExportReference, at time.Time) error {
+	if err := l.transition(StatusExported, at); err != nil {
+		return err
+	}
+
+	l.export = &ref
+	return nil
+}
+
+// Fail moves the recipe into failed with the given reason
+// Failing an exported recipe drops its export reference, as the export was undone
+func (l *RecipeLifecycle) Fail(reason string, at time.Time) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrFailureReasonEmpty
+	}
+
+	from := l.status
+	if err := l.transition(StatusFailed, at); err != nil {
+		return err
+	}
+
+	if from == StatusExported {
+		l.export = nil
+	}
+	l.failureReason = reason
+	return nil
+}
+
+// transition validates and records a status change
+func (l *RecipeLifecycle) transition(to RecipeStatus, at time.Time) error {
+	allowed := false
+	for _, from := range allowedTransitions[to] {
+		if from == l.status {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, l.status, to)
+	}
+
+	if to != StatusFailed {
+		l.failureReason = ""
+	}
+
+	l.status = to
+	l.transitions = append(l.transitions, StatusTransition{Status: to, At: at})
+	return nil
+}
+
+// RecipeLifecycleSnapshot is the persistable state of a RecipeLifecycle
+type RecipeLifecycleSnapshot struct {
+	ID            string
+	RawText       string
+	Status        RecipeStatus
+	Transitions   []StatusTransition
+	FailureReason string
+	Recipe        *Recipe
+	Export        *ExportReference
+}
+
+// Snapshot returns the current state for persistence
+func (l *RecipeLifecycle) Snapshot() RecipeLifecycleSnapshot {
+	var export *ExportReference
+	if l.export != nil {
+		ref := *l.export
+		export = &ref
+	}
+
+	return RecipeLifecycleSnapshot{
+		ID:            l.id,
+		RawText:       l.rawText,
+		Status:        l.status,
+		Transitions:   l.Transitions(),
+		FailureReason: l.failureReason,
+		Recipe:        l.recipe,
+		Export:        export,
+	}
+}
+
+// RestoreRecipeLifecycle rebuilds a lifecycle from persisted state
+func RestoreRecipeLifecycle(s RecipeLifecycleSnapshot) (*RecipeLifecycle, error) {
+	if strings.TrimSpace(s.ID) == "" {
+		return nil, ErrRecipeIDEmpty
+	}
+
+	if len(s.Transitions) == 0 || s.Transitions[len(s.Transitions)-1].Status != s.Status {
+		return nil, fmt.Errorf("%w: history does not end in %s", ErrInvalidStatusTransition, s.Status)
+	}
+
+	l := &RecipeLifecycle{
+		id:            s.ID,
+		rawText:       s.RawText,
+		status:        s.Status,
+		transitions:   append([]StatusTransition(nil), s.Transitions...),
+		failureReason: s.FailureReason,
+		recipe:        s.Recipe,
+	}
+
+	if s.Export != nil {
+		ref := *s.Export
+		l.export = &ref
+	}
+
+	return l, nil
+}
@@ -0,0 +1,181 @@
+package domain_test
+
+import (
+	"errors"
+	"recipe-processor/internal/domain"
+	"testing"
+	"time"
+)
+
+func newTestLifecycle(t *testing.T) *domain.RecipeLifecycle {
+	t.Helper()
+
+	text, _ := domain.NewRecipeText("Pancakes")
+	l, err := domain.NewRecipeLifecycle("recipe-1", text, time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("NewRecipeLifecycle() error = %v", err)
+	}
+
+	return l
+}
+
+func TestRecipeLifecycle_HappyPath(t *testing.T) {
+	l := newTestLifecycle(t)
+	at := time.Unix(0, 0)
+
+	steps := []func() error{
+		func() error { return l.StartParsing(at.Add(1 * time.Second)) },
+		func() error { return l.MarkParsed(newTestRecipe(t, "recipe-1"), at.Add(2*time.Second)) },
+		func() error { return l.StartExporting(at.Add(3 * time.Second)) },
+		func() error {
+			return l.MarkExported(domain.ExportReference{PageID: "p", URL: "https://notion.so/p"}, at.Add(4*time.Second))
+		},
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d: unexpected error %v", i, err)
+		}
+	}
+
+	if l.Status() != domain.StatusExported {
+		t.Fatalf("Status() = %q, want %q", l.Status(), domain.StatusExported)
+	}
+
+	want := []domain.RecipeStatus{
+		domain.StatusSubmitted, domain.StatusParsing, domain.StatusParsed, domain.StatusExporting, domain.StatusExported,
+	}
+	transitions := l.Transitions()
+	if len(transitions) != len(want) {
+		t.Fatalf("expected %d transitions, got %d", len(want), len(transitions))
+	}
+	for i, status := range want {
+		if transitions[i].Status != status {
+			t.Errorf("transition %d = %q, want %q", i, transitions[i].Status, status)
+		}
+	}
+
+	if l.UpdatedAt().Sub(l.SubmittedAt()) != 4*time.Second {
+		t.Errorf("unexpected timestamps: submitted %v updated %v", l.SubmittedAt(), l.UpdatedAt())
+	}
+
+	if l.ExportReference().URL != "https://notion.so/p" {
+		t.Errorf("ExportReference().URL = %q", l.ExportReference().URL)
+	}
+}
+
+func TestRecipeLifecycle_InvalidTransitions(t *testing.T) {
+	l := newTestLifecycle(t)
+
+	if err := l.MarkParsed(newTestRecipe(t, "recipe-1"), time.Now()); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Fatalf("submitted -> parsed: expected ErrInvalidStatusTransition, got %v", err)
+	}
+
+	if err := l.StartExporting(time.Now()); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Fatalf("submitted -> exporting: expected ErrInvalidStatusTransition, got %v", err)
+	}
+
+	if err := l.MarkExported(domain.ExportReference{}, time.Now()); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Fatalf("submitted -> exported: expected ErrInvalidStatusTransition, got %v", err)
+	}
+
+	if l.Status() != domain.StatusSubmitted || len(l.Transitions()) != 1 {
+		t.Fatalf("rejected transitions must not change state, got %q with %d transitions", l.Status(), len(l.Transitions()))
+	}
+}
+
+func TestRecipeLifecycle_FailAndRetry(t *testing.T) {
+	l := newTestLifecycle(t)
+
+	_ = l.StartParsing(time.Now())
+	if err := l.Fail("ollama unavailable", time.Now()); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+
+	if l.Status() != domain.StatusFailed || l.FailureReason() != "ollama unavailable" {
+		t.Fatalf("unexpected state after failure: %q %q", l.Status(), l.FailureReason())
+	}
+
+	if err := l.StartExporting(time.Now()); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Fatalf("failed -> exporting without recipe: expected ErrInvalidStatusTransition, got %v", err)
+	}
+
+	if err := l.StartParsing(time.Now()); err != nil {
+		t.Fatalf("failed -> parsing: unexpected error %v", err)
+	}
+
+	if l.FailureReason() != "" {
+		t.Errorf("expected failure reason to be cleared on retry, got %q", l.FailureReason())
+	}
+}
+
+func TestRecipeLifecycle_ReparseExportedRecipe(t *testing.T) {
+	l := newTestLifecycle(t)
+
+	_ = l.StartParsing(time.Now())
+	_ = l.MarkParsed(newTestRecipe(t, "recipe-1"), time.Now())
+	_ = l.StartExporting(time.Now())
+	_ = l.MarkExported(domain.ExportReference{PageID: "p", URL: "https://notion.so/p"}, time.Now())
+
+	if err := l.StartParsing(time.Now()); err != nil {
+		t.Fatalf("exported -> parsing: unexpected error %v", err)
+	}
+
+	if err := l.MarkParsed(newTestRecipe(t, "recipe-1"), time.Now()); err != nil {
+		t.Fatalf("parsing -> parsed: unexpected error %v", err)
+	}
+}
+
+func TestRecipeLifecycle_FailExportedRecipeDropsExport(t *testing.T) {
+	l := newTestLifecycle(t)
+
+	_ = l.StartParsing(time.Now())
+	_ = l.MarkParsed(newTestRecipe(t, "recipe-1"), time.Now())
+	_ = l.StartExporting(time.Now())
+	_ = l.MarkExported(domain.ExportReference{PageID: "p", URL: "https://notion.so/p"}, time.Now())
+
+	if err := l.Fail("notification failed", time.Now()); err != nil {
+		t.Fatalf("exported -> failed: unexpected error %v", err)
+	}
+
+	if l.ExportReference() != nil {
+		t.Errorf("expected export reference to be dropped, got %+v", l.ExportReference())
+	}
+	if l.Recipe() == nil {
+		t.Error("expected parsed recipe to be kept")
+	}
+}
+
+func TestRecipeLifecycle_FailRequiresReason(t *testing.T) {
+	l := newTestLifecycle(t)
+
+	if err := l.Fail(" ", time.Now()); err != domain.ErrFailureReasonEmpty {
+		t.Fatalf("expected ErrFailureReasonEmpty, got %v", err)
+	}
+}
+
+func TestRecipeLifecycle_MarkParsedRejectsForeignRecipe(t *testing.T) {
+	l := newTestLifecycle(t)
+	_ = l.StartParsing(time.Now())
+
+	if err := l.MarkParsed(newTestRecipe(t, "other"), time.Now()); err != domain.ErrParsedRecipeMismatch {
+		t.Fatalf("expected ErrParsedRecipeMismatch, got %v", err)
+	}
+}
+
+func TestRestoreRecipeLifecycle_RoundTrip(t *testing.T) {
+	l := newTestLifecycle(t)
+	_ = l.StartParsing(time.Now())
+
+	restored, err := domain.RestoreRecipeLifecycle(l.Snapshot())
+	if err != nil {
+		t.Fatalf("RestoreRecipeLifecycle() error = %v", err)
+	}
+
+	if restored.Status() != domain.StatusParsing || restored.RawText() != "Pancakes" {
+		t.Fatalf("unexpected restored state: %q %q", restored.Status(), restored.RawText())
+	}
+
+	if _, err := domain.RestoreRecipeLifecycle(domain.RecipeLifecycleSnapshot{ID: "x", Status: domain.StatusParsed}); err == nil {
+		t.Fatal("expected error for snapshot without history")
+	}
+}
@@ -1,10 +1,12 @@
 package domain_test
 
 import (
+	"errors"
 	"recipe-processor/internal/domain"
 
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewRecipeText_Empty(t *testing.T) {
@@ -38,3 +40,115 @@ func TestNewRecipeText_Valid(t *testing.T) {
 		t.Fatalf("String() = %q, want %q", s, want)
 	}
 }
+
+// newTestRecipe builds a valid recipe used across domain tests
+func newTestRecipe(t *testing.T, id string) *domain.Recipe {
+	t.Helper()
+
+	flour, err := domain.NewIngredient(domain.IngredientParams{Quantity: 200, Unit: "g", Name: "flour"})
+	if err != nil {
+		t.Fatalf("NewIngredient() error = %v", err)
+	}
+
+	mix, err := domain.NewStep(domain.StepParams{Text: "Mix"})
+	if err != nil {
+		t.Fatalf("NewStep() error = %v", err)
+	}
+
+	recipe, err := domain.NewRecipe(domain.RecipeParams{
+		ID:          id,
+		Title:       "Pancakes",
+		Ingredients: []domain.Ingredient{*flour},
+		Steps:       []domain.Step{*mix},
+	})
+	if err != nil {
+		t.Fatalf("NewRecipe() error = %v", err)
+	}
+
+	return recipe
+}
+
+func TestNewRecipe_Valid(t *testing.T) {
+	flour, _ := domain.NewIngredient(domain.IngredientParams{Quantity: 200, Unit: "g", Name: "flour"})
+	mix, _ := domain.NewStep(domain.StepParams{Text: "Mix"})
+	bake, _ := domain.NewStep(domain.StepParams{Text: "Bake", Duration: 20 * time.Minute})
+
+	recipe, err := domain.NewRecipe(domain.RecipeParams{
+		ID:          " recipe-1 ",
+		Title:       "  Bread ",
+		Servings:    4,
+		PrepTime:    10 * time.Minute,
+		CookTime:    20 * time.Minute,
+		Tags:        []string{"Baking", " baking ", "", "Bread"},
+		Ingredients: []domain.Ingredient{*flour},
+		Steps:       []domain.Step{*mix, *bake},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if recipe.ID() != "recipe-1" {
+		t.Errorf("ID() = %q, want %q", recipe.ID(), "recipe-1")
+	}
+	if recipe.Title() != "Bread" {
+		t.Errorf("Title() = %q, want %q", recipe.Title(), "Bread")
+	}
+	if recipe.TotalTime() != 30*time.Minute {
+		t.Errorf("TotalTime() = %v, want derived 30m", recipe.TotalTime())
+	}
+
+	tags := recipe.Tags()
+	if len(tags) != 2 || tags[0] != "baking" || tags[1] != "bread" {
+		t.Errorf("Tags() = %v, want [baking bread]", tags)
+	}
+
+	steps := recipe.Steps()
+	if steps[0].Number() != 1 || steps[1].Number() != 2 {
+		t.Errorf("expected steps numbered 1 and 2, got %d and %d", steps[0].Number(), steps[1].Number())
+	}
+}
+
+func TestNewRecipe_ValidationErrors(t *testing.T) {
+	flour, _ := domain.NewIngredient(domain.IngredientParams{Name: "flour"})
+	mix, _ := domain.NewStep(domain.StepParams{Text: "Mix"})
+	valid := func() domain.RecipeParams {
+		return domain.RecipeParams{
+			ID:          "recipe-1",
+			Title:       "Bread",
+			Ingredients: []domain.Ingredient{*flour},
+			Steps:       []domain.Step{*mix},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *domain.RecipeParams)
+		want   error
+	}{
+		{"empty id", func(p *domain.RecipeParams) { p.ID = " " }, domain.ErrRecipeIDEmpty},
+		{"empty title", func(p *domain.RecipeParams) { p.Title = "" }, domain.ErrRecipeTitleEmpty},
+		{"title too long", func(p *domain.RecipeParams) { p.Title = strings.Repeat("a", domain.MaxRecipeTitleLength+1) }, domain.ErrRecipeTitleTooLong},
+		{"negative servings", func(p *domain.RecipeParams) { p.Servings = -1 }, domain.ErrRecipeServingsNegative},
+		{"negative time", func(p *domain.RecipeParams) { p.CookTime = -time.Minute }, domain.ErrRecipeTimeNegative},
+		{"total too low", func(p *domain.RecipeParams) {
+			p.PrepTime = 10 * time.Minute
+			p.TotalTime = 5 * time.Minute
+		}, domain.ErrRecipeTotalTimeTooLow},
+		{"no ingredients", func(p *domain.RecipeParams) { p.Ingredients = nil }, domain.ErrRecipeNoIngredients},
+		{"no steps", func(p *domain.RecipeParams) { p.Steps = nil }, domain.ErrRecipeNoSteps},
+		{"zero value step", func(p *domain.RecipeParams) { p.Steps = []domain.Step{{}} }, domain.ErrStepTextEmpty},
+		{"zero value ingredient", func(p *domain.RecipeParams) { p.Ingredients = []domain.Ingredient{{}} }, domain.ErrIngredientNameEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.modify(&p)
+
+			_, err := domain.NewRecipe(p)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TemperatureUnit string
+
+const (
+	Celsius    TemperatureUnit = "C"
+	Fahrenheit TemperatureUnit = "F"
+)
+
+var (
+	ErrStepTextEmpty           = errors.New("step text cannot be empty")
+	ErrStepDurationNegative    = errors.New("step duration cannot be negative")
+	ErrTemperatureUnitInvalid  = errors.New("temperature unit must be C or F")
+	ErrTemperatureBelowAbsZero = errors.New("temperature is below absolute zero")
+)
+
+// Temperature is an oven or cooking temperature
+type Temperature struct {
+	value float64
+	unit  TemperatureUnit
+}
+
+// NewTemperature creates a validated temperature
+func NewTemperature(value float64, unit TemperatureUnit) (*Temperature, error) {
+	unit = TemperatureUnit(strings.ToUpper(strings.TrimSpace(string(unit))))
+
+	switch unit {
+	case Celsius:
+		if value < -273.15 {
+			return nil, ErrTemperatureBelowAbsZero
+		}
+	case Fahrenheit:
+		if value < -459.67 {
+			return nil, ErrTemperatureBelowAbsZero
+		}
+	default:
+		return nil, ErrTemperatureUnitInvalid
+	}
+
+	return &Temperature{value: value, unit: unit}, nil
+}
+
+// Value returns the numeric temperature
+func (t Temperature) Value() float64 {
+	return t.value
+}
+
+// Unit returns the temperature unit
+func (t Temperature) Unit() TemperatureUnit {
+	return t.unit
+}
+
+// String implements Stringer interface
+func (t Temperature) String() string {
+	return fmt.Sprintf("%g°%s", t.value, t.unit)
+}
+
+// StepParams holds the raw values for creating a Step
+type StepParams struct {
+	Text        string
+	Duration    time.Duration
+	Temperature *Temperature
+}
+
+// Step is a single instruction of a recipe
+type Step struct {
+	number      int
+	text        string
+	duration    time.Duration
+	temperature *Temperature
+}
+
+// NewStep creates a validated step
+// The step number is assigned when the step is added to a Recipe
+func NewStep(p StepParams) (*Step, error) {
+	text := strings.TrimSpace(p.Text)
+
+	if text == "" {
+		return nil, ErrStepTextEmpty
+	}
+
+	if p.Duration < 0 {
+		return nil, ErrStepDurationNegative
+	}
+
+	return &Step{
+		text:        text,
+		duration:    p.Duration,
+		temperature: p.Temperature,
+	}, nil
+}
+
+// Number returns the 1-based position of the step within its recipe
+func (s Step) Number() int {
+	return s.number
+}
+
+// Text returns the instruction text
+func (s Step) Text() string {
+	return s.text
+}
+
+// Duration returns how long the step takes, zero when unknown
+func (s Step) Duration() time.Duration {
+	return s.duration
+}
+
+// Temperature returns the step temperature, nil when not applicable
+func (s Step) Temperature() *Temperature {
+	return s.temperature
+}
@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"recipe-processor/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestNewStep_Valid(t *testing.T) {
+	temp, err := domain.NewTemperature(180, "c")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	step, err := domain.NewStep(domain.StepParams{Text: " Bake ", Duration: 25 * time.Minute, Temperature: temp})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if step.Text() != "Bake" {
+		t.Fatalf("Text() = %q, want %q", step.Text(), "Bake")
+	}
+
+	if step.Temperature().Unit() != domain.Celsius {
+		t.Fatalf("Unit() = %q, want %q", step.Temperature().Unit(), domain.Celsius)
+	}
+
+	if s := step.Temperature().String(); s != "180°C" {
+		t.Fatalf("String() = %q, want %q", s, "180°C")
+	}
+}
+
+func TestNewStep_Errors(t *testing.T) {
+	if _, err := domain.NewStep(domain.StepParams{Text: " "}); err != domain.ErrStepTextEmpty {
+		t.Fatalf("expected ErrStepTextEmpty, got %v", err)
+	}
+
+	if _, err := domain.NewStep(domain.StepParams{Text: "Rest", Duration: -time.Second}); err != domain.ErrStepDurationNegative {
+		t.Fatalf("expected ErrStepDurationNegative, got %v", err)
+	}
+}
+
+func TestNewTemperature_Errors(t *testing.T) {
+	if _, err := domain.NewTemperature(100, "K"); err != domain.ErrTemperatureUnitInvalid {
+		t.Fatalf("expected ErrTemperatureUnitInvalid, got %v", err)
+	}
+
+	if _, err := domain.NewTemperature(-300, domain.Celsius); err != domain.ErrTemperatureBelowAbsZero {
+		t.Fatalf("expected ErrTemperatureBelowAbsZero, got %v", err)
+	}
+}
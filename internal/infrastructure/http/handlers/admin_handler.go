@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	logger      logger.Logger
+	deadLetters events.DeadLetterQueue
+}
+
+func NewAdminHandler(log logger.Logger, deadLetters events.DeadLetterQueue) *AdminHandler {
+	return &AdminHandler{
+		logger:      log,
+		deadLetters: deadLetters,
+	}
+}
+
+type DeadLetterResponse struct {
+	ID            string          `json:"id"`
+	EventType     string          `json:"event_type"`
+	EventID       string          `json:"event_id,omitempty"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Handler       string          `json:"handler"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error"`
+	FailedAt      time.Time       `json:"failed_at"`
+	Event         json.RawMessage `json:"event,omitempty"`
+}
+
+type ListDeadLettersResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+}
+
+type RedriveResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// ListDeadLetters handles GET /admin/dead-letters
+func (h *AdminHandler) ListDeadLetters(c *gin.Context) {
+	letters, err := h.deadLetters.DeadLetters(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list dead letters", logger.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to list dead letters",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	resp := ListDeadLettersResponse{DeadLetters: make([]DeadLetterResponse, len(letters))}
+	for i, letter := range letters {
+		resp.DeadLetters[i] = DeadLetterResponse{
+			ID:            letter.ID,
+			EventType:     letter.Event.EventType(),
+			EventID:       letter.Envelope.EventID,
+			CorrelationID: letter.Envelope.CorrelationID,
+			Handler:       letter.Handler,
+			Attempts:      letter.Attempts,
+			LastError:     letter.LastError,
+			FailedAt:      letter.FailedAt,
+		}
+		// Events without a JSON form are listed without their payload
+		if payload, err := json.Marshal(letter.Event); err == nil {
+			resp.DeadLetters[i].Event = payload
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RedriveDeadLetter handles POST /admin/dead-letters/:id/redrive
+func (h *AdminHandler) RedriveDeadLetter(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.deadLetters.Redrive(c.Request.Context(), id); err != nil {
+		if errors.Is(err, events.ErrDeadLetterNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Dead letter not found",
+				Code:  "NOT_FOUND",
+			})
+			return
+		}
+
+		h.logger.Error("Failed to redrive dead letter", logger.String("dead_letter_id", id), logger.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to redrive dead letter",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, RedriveResponse{
+		ID:      id,
+		Message: "Dead letter queued for redelivery",
+	})
+}
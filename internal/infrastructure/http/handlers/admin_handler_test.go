@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/http/handlers"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mockDeadLetterQueue is a mock implementation of DeadLetterQueue
+type mockDeadLetterQueue struct {
+	letters     []events.DeadLetter
+	redriveFunc func(ctx context.Context, id string) error
+	redriven    []string
+}
+
+func (m *mockDeadLetterQueue) DeadLetters(ctx context.Context) ([]events.DeadLetter, error) {
+	return m.letters, nil
+}
+
+func (m *mockDeadLetterQueue) Redrive(ctx context.Context, id string) error {
+	m.redriven = append(m.redriven, id)
+	if m.redriveFunc != nil {
+		return m.redriveFunc(ctx, id)
+	}
+	return nil
+}
+
+func setupAdminRouter(handler *handlers.AdminHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/dead-letters", handler.ListDeadLetters)
+	router.POST("/admin/dead-letters/:id/redrive", handler.RedriveDeadLetter)
+	return router
+}
+
+func TestAdminHandler_ListDeadLetters(t *testing.T) {
+	// Arrange
+	failedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	dlq := &mockDeadLetterQueue{letters: []events.DeadLetter{{
+		ID:        "dl-1",
+		Event:     domain.NewRecipeSubmitted("recipe-1", "Pancakes"),
+		Handler:   "parse-recipe",
+		Attempts:  3,
+		LastError: "ollama unavailable",
+		FailedAt:  failedAt,
+	}}}
+	router := setupAdminRouter(handlers.NewAdminHandler(logger.NewNoopLogger(), dlq))
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/dead-letters", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp handlers.ListDeadLettersResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(resp.DeadLetters) != 1 {
+		t.Fatalf("Expected 1 dead letter, got %d", len(resp.DeadLetters))
+	}
+
+	letter := resp.DeadLetters[0]
+	if letter.ID != "dl-1" || letter.EventType != domain.EventTypeRecipeSubmitted || letter.Handler != "parse-recipe" {
+		t.Errorf("Unexpected dead letter: %+v", letter)
+	}
+
+	if letter.Attempts != 3 || letter.LastError != "ollama unavailable" || !letter.FailedAt.Equal(failedAt) {
+		t.Errorf("Unexpected failure details: %+v", letter)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(letter.Event, &payload); err != nil || payload["recipe_id"] != "recipe-1" {
+		t.Errorf("Expected event payload with recipe ID, got %s", letter.Event)
+	}
+}
+
+func TestAdminHandler_RedriveDeadLetter(t *testing.T) {
+	// Arrange
+	dlq := &mockDeadLetterQueue{}
+	router := setupAdminRouter(handlers.NewAdminHandler(logger.NewNoopLogger(), dlq))
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/dead-letters/dl-1/redrive", nil))
+
+	// Assert
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d", http.StatusAccepted, w.Code)
+	}
+
+	if len(dlq.redriven) != 1 || dlq.redriven[0] != "dl-1" {
+		t.Errorf("Expected dl-1 to be redriven, got %v", dlq.redriven)
+	}
+}
+
+func TestAdminHandler_RedriveDeadLetter_NotFound(t *testing.T) {
+	// Arrange
+	dlq := &mockDeadLetterQueue{
+		redriveFunc: func(ctx context.Context, id string) error {
+			return events.ErrDeadLetterNotFound
+		},
+	}
+	router := setupAdminRouter(handlers.NewAdminHandler(logger.NewNoopLogger(), dlq))
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/dead-letters/missing/redrive", nil))
+
+	// Assert
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestAdminHandler_RedriveDeadLetter_Error(t *testing.T) {
+	// Arrange
+	dlq := &mockDeadLetterQueue{
+		redriveFunc: func(ctx context.Context, id string) error {
+			return errors.New("handler no longer subscribed")
+		},
+	}
+	router := setupAdminRouter(handlers.NewAdminHandler(logger.NewNoopLogger(), dlq))
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/dead-letters/dl-1/redrive", nil))
+
+	// Assert
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EventBusInspector is an event bus reporting its activity that can be paused
+type EventBusInspector interface {
+	events.StatsReporter
+	events.Pauser
+}
+
+type EventBusHandler struct {
+	logger logger.Logger
+	bus    EventBusInspector
+}
+
+func NewEventBusHandler(log logger.Logger, bus EventBusInspector) *EventBusHandler {
+	return &EventBusHandler{
+		logger: log,
+		bus:    bus,
+	}
+}
+
+type HandlerStatsResponse struct {
+	Name             string  `json:"name"`
+	Succeeded        int64   `json:"succeeded"`
+	Failed           int64   `json:"failed"`
+	DeadLettered     int64   `json:"dead_lettered"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+	MaxLatencyMs     float64 `json:"max_latency_ms"`
+}
+
+type EventBusStatsResponse struct {
+	Queue       events.QueueDepth `json:"queue"`
+	Workers     int               `json:"workers"`
+	BusyWorkers int               `json:"busy_workers"`
+	Paused      []string          `json:"paused"`
+	Held        int               `json:"held"`
+	// Subscriptions maps each subscribed event type or pattern to its handlers
+	Subscriptions map[string][]HandlerStatsResponse `json:"subscriptions"`
+}
+
+type PauseResponse struct {
+	EventType string `json:"event_type"`
+	Paused    bool   `json:"paused"`
+}
+
+// GetStats handles GET /admin/eventbus
+func (h *EventBusHandler) GetStats(c *gin.Context) {
+	stats := h.bus.Stats()
+
+	resp := EventBusStatsResponse{
+		Queue:         stats.Queue,
+		Workers:       stats.Workers,
+		BusyWorkers:   stats.BusyWorkers,
+		Paused:        stats.Paused,
+		Held:          stats.Held,
+		Subscriptions: make(map[string][]HandlerStatsResponse, len(stats.Subscriptions)),
+	}
+	if resp.Paused == nil {
+		resp.Paused = []string{}
+	}
+
+	for eventType, handlers := range stats.Subscriptions {
+		for _, handler := range handlers {
+			resp.Subscriptions[eventType] = append(resp.Subscriptions[eventType], HandlerStatsResponse{
+				Name:             handler.Name,
+				Succeeded:        handler.Succeeded,
+				Failed:           handler.Failed,
+				DeadLettered:     handler.DeadLettered,
+				AverageLatencyMs: milliseconds(handler.AverageLatency),
+				MaxLatencyMs:     milliseconds(handler.MaxLatency),
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// PauseEventType handles POST /admin/eventbus/:event_type/pause
+func (h *EventBusHandler) PauseEventType(c *gin.Context) {
+	eventType := c.Param("event_type")
+	h.bus.Pause(eventType)
+
+	h.logger.Warn("Event type paused by operator", logger.String("event_type", eventType))
+	c.JSON(http.StatusOK, PauseResponse{EventType: eventType, Paused: true})
+}
+
+// ResumeEventType handles POST /admin/eventbus/:event_type/resume
+func (h *EventBusHandler) ResumeEventType(c *gin.Context) {
+	eventType := c.Param("event_type")
+	h.bus.Resume(eventType)
+
+	h.logger.Info("Event type resumed by operator", logger.String("event_type", eventType))
+	c.JSON(http.StatusOK, PauseResponse{EventType: eventType, Paused: false})
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
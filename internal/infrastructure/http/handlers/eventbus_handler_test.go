@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"recipe-processor/internal/infrastructure/http/handlers"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mockEventBusInspector is a mock implementation of EventBusInspector
+type mockEventBusInspector struct {
+	stats   events.BusStats
+	paused  []string
+	resumed []string
+}
+
+func (m *mockEventBusInspector) Stats() events.BusStats { return m.stats }
+func (m *mockEventBusInspector) Pause(eventType string) { m.paused = append(m.paused, eventType) }
+func (m *mockEventBusInspector) Resume(eventType string) {
+	m.resumed = append(m.resumed, eventType)
+}
+
+func setupEventBusRouter(handler *handlers.EventBusHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/eventbus", handler.GetStats)
+	router.POST("/admin/eventbus/:event_type/pause", handler.PauseEventType)
+	router.POST("/admin/eventbus/:event_type/resume", handler.ResumeEventType)
+	return router
+}
+
+func TestEventBusHandler_GetStats(t *testing.T) {
+	// Arrange
+	bus := &mockEventBusInspector{stats: events.BusStats{
+		Queue:       events.QueueDepth{Queued: 3, Capacity: 100},
+		Workers:     10,
+		BusyWorkers: 2,
+		Subscriptions: map[string][]events.HandlerStats{
+			"recipe.submitted": {{
+				Name:           "parse-recipe",
+				Succeeded:      5,
+				Failed:         1,
+				DeadLettered:   1,
+				AverageLatency: 1500 * time.Microsecond,
+				MaxLatency:     4 * time.Millisecond,
+			}},
+		},
+	}}
+	router := setupEventBusRouter(handlers.NewEventBusHandler(logger.NewNoopLogger(), bus))
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/eventbus", nil))
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp handlers.EventBusStatsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.Queue.Queued != 3 || resp.Workers != 10 || resp.BusyWorkers != 2 {
+		t.Errorf("Unexpected bus stats: %+v", resp)
+	}
+	if resp.Paused == nil {
+		t.Error("Expected paused to be an empty list rather than null")
+	}
+
+	parse := resp.Subscriptions["recipe.submitted"]
+	if len(parse) != 1 {
+		t.Fatalf("Expected 1 handler for recipe.submitted, got %+v", resp.Subscriptions)
+	}
+	if parse[0].Name != "parse-recipe" || parse[0].Succeeded != 5 || parse[0].Failed != 1 || parse[0].DeadLettered != 1 {
+		t.Errorf("Unexpected handler stats: %+v", parse[0])
+	}
+	if parse[0].AverageLatencyMs != 1.5 || parse[0].MaxLatencyMs != 4 {
+		t.Errorf("Expected latencies in milliseconds, got %+v", parse[0])
+	}
+}
+
+func TestEventBusHandler_PauseAndResume(t *testing.T) {
+	// Arrange
+	bus := &mockEventBusInspector{}
+	router := setupEventBusRouter(handlers.NewEventBusHandler(logger.NewNoopLogger(), bus))
+
+	// Act
+	paused := httptest.NewRecorder()
+	router.ServeHTTP(paused, httptest.NewRequest(http.MethodPost, "/admin/eventbus/recipe.parsed/pause", nil))
+	resumed := httptest.NewRecorder()
+	router.ServeHTTP(resumed, httptest.NewRequest(http.MethodPost, "/admin/eventbus/recipe.parsed/resume", nil))
+
+	// Assert
+	if paused.Code != http.StatusOK || resumed.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d and %d", http.StatusOK, paused.Code, resumed.Code)
+	}
+
+	var resp handlers.PauseResponse
+	if err := json.Unmarshal(paused.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.EventType != "recipe.parsed" || !resp.Paused {
+		t.Errorf("Unexpected pause response: %+v", resp)
+	}
+
+	if len(bus.paused) != 1 || bus.paused[0] != "recipe.parsed" {
+		t.Errorf("Expected recipe.parsed to be paused, got %v", bus.paused)
+	}
+	if len(bus.resumed) != 1 || bus.resumed[0] != "recipe.parsed" {
+		t.Errorf("Expected recipe.parsed to be resumed, got %v", bus.resumed)
+	}
+}
@@ -5,20 +5,36 @@ import (
 	"net/http"
 	"recipe-processor/internal/application/recipe"
 	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
 	"recipe-processor/internal/shared/logger"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// queueFullRetryAfter is the Retry-After sent when the event queue is full
+const queueFullRetryAfter = 5 * time.Second
+
+// AdmissionCheck decides whether a submission is accepted now; it returns
+// events.ErrQueueFull when the pipeline is saturated
+type AdmissionCheck func() error
+
 type RecipeHandler struct {
 	logger        logger.Logger
 	submitService recipe.RecipeSubmitter
+	getService    recipe.RecipeGetter
+	admission     []AdmissionCheck
 }
 
-func NewRecipeHandler(log logger.Logger, submitService recipe.RecipeSubmitter) *RecipeHandler {
+// NewRecipeHandler creates the recipe handler; submissions are turned away
+// with 503 while an admission check fails
+func NewRecipeHandler(log logger.Logger, submitService recipe.RecipeSubmitter, getService recipe.RecipeGetter, admission ...AdmissionCheck) *RecipeHandler {
 	return &RecipeHandler{
 		logger:        log,
 		submitService: submitService,
+		getService:    getService,
+		admission:     admission,
 	}
 }
 
@@ -31,6 +47,55 @@ type SubmitRecipeResponse struct {
 	Message  string `json:"message"`
 }
 
+type StatusTransitionResponse struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+type IngredientResponse struct {
+	Quantity    float64 `json:"quantity,omitempty"`
+	Unit        string  `json:"unit,omitempty"`
+	Name        string  `json:"name"`
+	Preparation string  `json:"preparation,omitempty"`
+	Optional    bool    `json:"optional"`
+}
+
+type TemperatureResponse struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+type StepResponse struct {
+	Number          int                  `json:"number"`
+	Text            string               `json:"text"`
+	DurationMinutes float64              `json:"duration_minutes,omitempty"`
+	Temperature     *TemperatureResponse `json:"temperature,omitempty"`
+}
+
+type ParsedRecipeResponse struct {
+	Title            string               `json:"title"`
+	Servings         int                  `json:"servings,omitempty"`
+	PrepTimeMinutes  float64              `json:"prep_time_minutes,omitempty"`
+	CookTimeMinutes  float64              `json:"cook_time_minutes,omitempty"`
+	TotalTimeMinutes float64              `json:"total_time_minutes,omitempty"`
+	Yield            string               `json:"yield,omitempty"`
+	Source           string               `json:"source,omitempty"`
+	Tags             []string             `json:"tags"`
+	Ingredients      []IngredientResponse `json:"ingredients"`
+	Steps            []StepResponse       `json:"steps"`
+}
+
+type GetRecipeResponse struct {
+	RecipeID      string                     `json:"recipe_id"`
+	Status        string                     `json:"status"`
+	FailureReason string                     `json:"failure_reason,omitempty"`
+	SubmittedAt   time.Time                  `json:"submitted_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	Transitions   []StatusTransitionResponse `json:"transitions"`
+	Recipe        *ParsedRecipeResponse      `json:"recipe,omitempty"`
+	NotionPageURL string                     `json:"notion_page_url,omitempty"`
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 	Code  string `json:"code"`
@@ -50,6 +115,14 @@ func (h *RecipeHandler) SubmitRecipe(c *gin.Context) {
 		return
 	}
 
+	// Turn the submission away while the event queue is saturated
+	for _, admit := range h.admission {
+		if err := admit(); err != nil {
+			h.respondError(c, err)
+			return
+		}
+	}
+
 	// Execute business logic via application service
 	cmd := recipe.SubmitRecipeCommand{
 		RecipeText: req.RecipeText,
@@ -57,9 +130,7 @@ func (h *RecipeHandler) SubmitRecipe(c *gin.Context) {
 
 	result, err := h.submitService.Execute(c.Request.Context(), cmd)
 	if err != nil {
-		// Map domain errors to HTTP responses
-		statusCode, errorResp := h.mapErrorToResponse(err)
-		c.JSON(statusCode, errorResp)
+		h.respondError(c, err)
 		return
 	}
 
@@ -70,6 +141,100 @@ func (h *RecipeHandler) SubmitRecipe(c *gin.Context) {
 	})
 }
 
+// GetRecipe handles GET /api/v1/recipes/:id
+func (h *RecipeHandler) GetRecipe(c *gin.Context) {
+	query := recipe.GetRecipeQuery{
+		RecipeID: c.Param("id"),
+	}
+
+	lifecycle, err := h.getService.Execute(c.Request.Context(), query)
+	if err != nil {
+		statusCode, errorResp := h.mapErrorToResponse(err)
+		c.JSON(statusCode, errorResp)
+		return
+	}
+
+	c.JSON(http.StatusOK, newGetRecipeResponse(lifecycle))
+}
+
+func newGetRecipeResponse(l *domain.RecipeLifecycle) GetRecipeResponse {
+	transitions := l.Transitions()
+	resp := GetRecipeResponse{
+		RecipeID:      l.ID(),
+		Status:        string(l.Status()),
+		FailureReason: l.FailureReason(),
+		SubmittedAt:   l.SubmittedAt(),
+		UpdatedAt:     l.UpdatedAt(),
+		Transitions:   make([]StatusTransitionResponse, len(transitions)),
+	}
+
+	for i, t := range transitions {
+		resp.Transitions[i] = StatusTransitionResponse{Status: string(t.Status), At: t.At}
+	}
+
+	if r := l.Recipe(); r != nil {
+		resp.Recipe = newParsedRecipeResponse(r)
+	}
+
+	if ref := l.ExportReference(); ref != nil {
+		resp.NotionPageURL = ref.URL
+	}
+
+	return resp
+}
+
+func newParsedRecipeResponse(r *domain.Recipe) *ParsedRecipeResponse {
+	ingredients := r.Ingredients()
+	steps := r.Steps()
+
+	resp := &ParsedRecipeResponse{
+		Title:            r.Title(),
+		Servings:         r.Servings(),
+		PrepTimeMinutes:  r.PrepTime().Minutes(),
+		CookTimeMinutes:  r.CookTime().Minutes(),
+		TotalTimeMinutes: r.TotalTime().Minutes(),
+		Yield:            r.Yield(),
+		Source:           r.Source(),
+		Tags:             r.Tags(),
+		Ingredients:      make([]IngredientResponse, len(ingredients)),
+		Steps:            make([]StepResponse, len(steps)),
+	}
+
+	for i, in := range ingredients {
+		resp.Ingredients[i] = IngredientResponse{
+			Quantity:    in.Quantity(),
+			Unit:        in.Unit(),
+			Name:        in.Name(),
+			Preparation: in.Preparation(),
+			Optional:    in.Optional(),
+		}
+	}
+
+	for i, s := range steps {
+		resp.Steps[i] = StepResponse{
+			Number:          s.Number(),
+			Text:            s.Text(),
+			DurationMinutes: s.Duration().Minutes(),
+		}
+		if t := s.Temperature(); t != nil {
+			resp.Steps[i].Temperature = &TemperatureResponse{Value: t.Value(), Unit: string(t.Unit())}
+		}
+	}
+
+	return resp
+}
+
+// respondError maps err to an HTTP response; a full queue asks the client to
+// retry later
+func (h *RecipeHandler) respondError(c *gin.Context, err error) {
+	if errors.Is(err, events.ErrQueueFull) {
+		c.Header("Retry-After", strconv.Itoa(int(queueFullRetryAfter.Seconds())))
+	}
+
+	statusCode, errorResp := h.mapErrorToResponse(err)
+	c.JSON(statusCode, errorResp)
+}
+
 func (h *RecipeHandler) mapErrorToResponse(err error) (int, ErrorResponse) {
 	// Check for domain validation errors
 	if errors.Is(err, domain.ErrRecipeTextEmpty) {
@@ -86,8 +251,23 @@ func (h *RecipeHandler) mapErrorToResponse(err error) (int, ErrorResponse) {
 		}
 	}
 
+	if errors.Is(err, recipe.ErrRecipeNotFound) {
+		return http.StatusNotFound, ErrorResponse{
+			Error: "Recipe not found",
+			Code:  "NOT_FOUND",
+		}
+	}
+
+	if errors.Is(err, events.ErrQueueFull) {
+		h.logger.Warn("Recipe submission rejected", logger.Error(err))
+		return http.StatusServiceUnavailable, ErrorResponse{
+			Error: "Too many recipes in progress, retry later",
+			Code:  "QUEUE_FULL",
+		}
+	}
+
 	// Default to internal server error
-	h.logger.Error("Unexpected error in recipe submission", logger.Error(err))
+	h.logger.Error("Unexpected error in recipe request", logger.Error(err))
 	return http.StatusInternalServerError, ErrorResponse{
 		Error: "Failed to process recipe",
 		Code:  "INTERNAL_ERROR",
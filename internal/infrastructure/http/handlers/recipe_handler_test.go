@@ -5,14 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"recipe-processor/internal/application/recipe"
 	"recipe-processor/internal/domain"
 	"recipe-processor/internal/infrastructure/http/handlers"
+	"recipe-processor/internal/shared/events"
 	"recipe-processor/internal/shared/logger"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,10 +38,23 @@ func (m *mockRecipeSubmitter) Execute(ctx context.Context, cmd recipe.SubmitReci
 	return &recipe.SubmitRecipeResult{RecipeID: "test-id"}, nil
 }
 
+// mockRecipeGetter is a mock implementation of RecipeGetter
+type mockRecipeGetter struct {
+	executeFunc func(ctx context.Context, query recipe.GetRecipeQuery) (*domain.RecipeLifecycle, error)
+}
+
+func (m *mockRecipeGetter) Execute(ctx context.Context, query recipe.GetRecipeQuery) (*domain.RecipeLifecycle, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, query)
+	}
+	return nil, recipe.ErrRecipeNotFound
+}
+
 func setupTestRouter(handler *handlers.RecipeHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	router.POST("/api/v1/recipes", handler.SubmitRecipe)
+	router.GET("/api/v1/recipes/:id", handler.GetRecipe)
 	return router
 }
 
@@ -50,7 +66,7 @@ func TestRecipeHandler_SubmitRecipe_Success(t *testing.T) {
 		},
 	}
 
-	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService)
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService, &mockRecipeGetter{})
 	router := setupTestRouter(handler)
 
 	reqBody := handlers.SubmitRecipeRequest{
@@ -92,7 +108,7 @@ func TestRecipeHandler_SubmitRecipe_EmptyText(t *testing.T) {
 		},
 	}
 
-	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService)
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService, &mockRecipeGetter{})
 	router := setupTestRouter(handler)
 
 	reqBody := handlers.SubmitRecipeRequest{RecipeText: ""}
@@ -128,7 +144,7 @@ func TestRecipeHandler_SubmitRecipe_TextTooLong(t *testing.T) {
 		},
 	}
 
-	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService)
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService, &mockRecipeGetter{})
 	router := setupTestRouter(handler)
 
 	longText := strings.Repeat("a", 10001)
@@ -160,7 +176,7 @@ func TestRecipeHandler_SubmitRecipe_TextTooLong(t *testing.T) {
 func TestRecipeHandler_SubmitRecipe_InvalidJSON(t *testing.T) {
 	// Arrange
 	mockService := &mockRecipeSubmitter{}
-	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService)
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService, &mockRecipeGetter{})
 	router := setupTestRouter(handler)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewReader([]byte("invalid json")))
@@ -188,7 +204,7 @@ func TestRecipeHandler_SubmitRecipe_InvalidJSON(t *testing.T) {
 func TestRecipeHandler_SubmitRecipe_MissingRecipeText(t *testing.T) {
 	// Arrange
 	mockService := &mockRecipeSubmitter{}
-	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService)
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService, &mockRecipeGetter{})
 	router := setupTestRouter(handler)
 
 	reqBody := map[string]string{} // Missing recipe_text field
@@ -228,7 +244,7 @@ func TestRecipeHandler_SubmitRecipe_ServiceError(t *testing.T) {
 		},
 	}
 
-	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService)
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService, &mockRecipeGetter{})
 	router := setupTestRouter(handler)
 
 	reqBody := handlers.SubmitRecipeRequest{RecipeText: "Valid recipe"}
@@ -255,3 +271,189 @@ func TestRecipeHandler_SubmitRecipe_ServiceError(t *testing.T) {
 		t.Errorf("Expected code 'INTERNAL_ERROR', got '%s'", response.Code)
 	}
 }
+
+func TestRecipeHandler_SubmitRecipe_QueueFull(t *testing.T) {
+	// Arrange
+	executed := false
+	mockService := &mockRecipeSubmitter{
+		executeFunc: func(ctx context.Context, cmd recipe.SubmitRecipeCommand) (*recipe.SubmitRecipeResult, error) {
+			executed = true
+			return &recipe.SubmitRecipeResult{RecipeID: "recipe-123"}, nil
+		},
+	}
+	queueFull := func() error { return fmt.Errorf("%w: 100 of 100 queued", events.ErrQueueFull) }
+
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), mockService, &mockRecipeGetter{}, queueFull)
+	router := setupTestRouter(handler)
+
+	bodyBytes, _ := json.Marshal(handlers.SubmitRecipeRequest{RecipeText: "Valid recipe text"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "5" {
+		t.Errorf("Expected Retry-After '5', got '%s'", got)
+	}
+
+	var response handlers.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != "QUEUE_FULL" {
+		t.Errorf("Expected code 'QUEUE_FULL', got '%s'", response.Code)
+	}
+	if executed {
+		t.Error("Expected submission not to be executed")
+	}
+}
+
+func TestRecipeHandler_GetRecipe_Exported(t *testing.T) {
+	// Arrange
+	submittedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	text, _ := domain.NewRecipeText("Pancakes")
+	lifecycle, _ := domain.NewRecipeLifecycle("recipe-123", text, submittedAt)
+
+	flour, _ := domain.NewIngredient(domain.IngredientParams{Quantity: 200, Unit: "g", Name: "flour"})
+	temp, _ := domain.NewTemperature(180, domain.Celsius)
+	fry, _ := domain.NewStep(domain.StepParams{Text: "Fry", Duration: 5 * time.Minute, Temperature: temp})
+	parsed, _ := domain.NewRecipe(domain.RecipeParams{
+		ID:          "recipe-123",
+		Title:       "Pancakes",
+		Servings:    4,
+		Ingredients: []domain.Ingredient{*flour},
+		Steps:       []domain.Step{*fry},
+	})
+
+	_ = lifecycle.StartParsing(submittedAt.Add(time.Second))
+	_ = lifecycle.MarkParsed(parsed, submittedAt.Add(2*time.Second))
+	_ = lifecycle.StartExporting(submittedAt.Add(3 * time.Second))
+	_ = lifecycle.MarkExported(domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}, submittedAt.Add(4*time.Second))
+
+	getter := &mockRecipeGetter{
+		executeFunc: func(ctx context.Context, query recipe.GetRecipeQuery) (*domain.RecipeLifecycle, error) {
+			if query.RecipeID != "recipe-123" {
+				t.Errorf("Expected recipe ID 'recipe-123', got '%s'", query.RecipeID)
+			}
+			return lifecycle, nil
+		},
+	}
+
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), &mockRecipeSubmitter{}, getter)
+	router := setupTestRouter(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recipes/recipe-123", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response handlers.GetRecipeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if response.Status != "exported" {
+		t.Errorf("Expected status 'exported', got '%s'", response.Status)
+	}
+
+	if len(response.Transitions) != 5 {
+		t.Errorf("Expected 5 transitions, got %d", len(response.Transitions))
+	}
+
+	if !response.SubmittedAt.Equal(submittedAt) {
+		t.Errorf("Expected submitted_at %v, got %v", submittedAt, response.SubmittedAt)
+	}
+
+	if response.NotionPageURL != "https://notion.so/page-1" {
+		t.Errorf("Expected notion_page_url, got '%s'", response.NotionPageURL)
+	}
+
+	if response.Recipe == nil {
+		t.Fatal("Expected parsed recipe in response")
+	}
+
+	if response.Recipe.Title != "Pancakes" || len(response.Recipe.Ingredients) != 1 {
+		t.Errorf("Unexpected recipe: %+v", response.Recipe)
+	}
+
+	if step := response.Recipe.Steps[0]; step.DurationMinutes != 5 || step.Temperature == nil || step.Temperature.Unit != "C" {
+		t.Errorf("Unexpected step: %+v", step)
+	}
+}
+
+func TestRecipeHandler_GetRecipe_Submitted(t *testing.T) {
+	// Arrange
+	text, _ := domain.NewRecipeText("Pancakes")
+	lifecycle, _ := domain.NewRecipeLifecycle("recipe-123", text, time.Now())
+
+	getter := &mockRecipeGetter{
+		executeFunc: func(ctx context.Context, query recipe.GetRecipeQuery) (*domain.RecipeLifecycle, error) {
+			return lifecycle, nil
+		},
+	}
+
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), &mockRecipeSubmitter{}, getter)
+	router := setupTestRouter(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recipes/recipe-123", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if body["status"] != "submitted" {
+		t.Errorf("Expected status 'submitted', got '%v'", body["status"])
+	}
+
+	if _, ok := body["recipe"]; ok {
+		t.Error("Expected no recipe before parsing")
+	}
+
+	if _, ok := body["notion_page_url"]; ok {
+		t.Error("Expected no notion_page_url before export")
+	}
+}
+
+func TestRecipeHandler_GetRecipe_NotFound(t *testing.T) {
+	// Arrange
+	handler := handlers.NewRecipeHandler(logger.NewNoopLogger(), &mockRecipeSubmitter{}, &mockRecipeGetter{})
+	router := setupTestRouter(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recipes/missing", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	var response handlers.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if response.Code != "NOT_FOUND" {
+		t.Errorf("Expected code 'NOT_FOUND', got '%s'", response.Code)
+	}
+}
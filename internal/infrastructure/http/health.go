@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds each readiness check
+const readinessTimeout = 2 * time.Second
+
+// HealthCheck reports whether a dependency the process needs is usable
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// ReadinessResponse is the body of GET /ready
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// RegisterHealthRoutes adds GET /health, which succeeds while the process
+// runs, and GET /ready, which succeeds only while every check passes
+func RegisterHealthRoutes(router gin.IRoutes, checks ...HealthCheck) {
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+	})
+
+	router.GET("/ready", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+		defer cancel()
+
+		response := ReadinessResponse{Status: "ready", Checks: make(map[string]string, len(checks))}
+		status := http.StatusOK
+		for _, check := range checks {
+			if err := check.Check(ctx); err != nil {
+				response.Checks[check.Name] = err.Error()
+				response.Status = "not ready"
+				status = http.StatusServiceUnavailable
+				continue
+			}
+			response.Checks[check.Name] = "ok"
+		}
+
+		c.JSON(status, response)
+	})
+}
+
+// HealthServer serves only the health endpoints, for processes without an API
+type HealthServer struct {
+	srv *http.Server
+}
+
+// NewHealthServer creates a health server listening on port
+func NewHealthServer(port string, checks ...HealthCheck) *HealthServer {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	RegisterHealthRoutes(router, checks...)
+
+	return &HealthServer{
+		srv: &http.Server{
+			Addr:              ":" + port,
+			Handler:           router,
+			ReadHeaderTimeout: readinessTimeout,
+		},
+	}
+}
+
+func (s *HealthServer) Start() error {
+	if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+func (s *HealthServer) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,99 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	apphttp "recipe-processor/internal/infrastructure/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRegisterHealthRoutes_Health(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Arrange
+	router := gin.New()
+	apphttp.RegisterHealthRoutes(router, apphttp.HealthCheck{
+		Name:  "database",
+		Check: func(ctx context.Context) error { return errors.New("database is locked") },
+	})
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert: liveness does not depend on the checks
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestRegisterHealthRoutes_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name           string
+		busCheck       func(ctx context.Context) error
+		expectedStatus int
+		expectedBody   apphttp.ReadinessResponse
+	}{
+		{
+			name:           "all checks pass",
+			busCheck:       ok,
+			expectedStatus: http.StatusOK,
+			expectedBody: apphttp.ReadinessResponse{
+				Status: "ready",
+				Checks: map[string]string{"database": "ok", "event_bus": "ok"},
+			},
+		},
+		{
+			name:           "a check fails",
+			busCheck:       failing,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: apphttp.ReadinessResponse{
+				Status: "not ready",
+				Checks: map[string]string{"database": "ok", "event_bus": "connection refused"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := gin.New()
+			apphttp.RegisterHealthRoutes(router,
+				apphttp.HealthCheck{Name: "database", Check: ok},
+				apphttp.HealthCheck{Name: "event_bus", Check: tt.busCheck},
+			)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+			// Assert
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			var body apphttp.ReadinessResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if body.Status != tt.expectedBody.Status {
+				t.Errorf("Expected status '%s', got '%s'", tt.expectedBody.Status, body.Status)
+			}
+			for name, want := range tt.expectedBody.Checks {
+				if body.Checks[name] != want {
+					t.Errorf("Expected check %s '%s', got '%s'", name, want, body.Checks[name])
+				}
+			}
+		})
+	}
+}
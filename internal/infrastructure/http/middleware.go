@@ -1,12 +1,44 @@
 package http
 
 import (
+	"crypto/subtle"
+	"net/http"
+	"recipe-processor/internal/infrastructure/http/handlers"
+	"recipe-processor/internal/shared/events"
 	"recipe-processor/internal/shared/logger"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey stores the request ID in the gin context
+const requestIDKey = "request_id"
+
+// maxRequestIDLength bounds client-supplied request IDs
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware assigns every request an ID, reusing X-Request-ID when
+// the client sent one, and makes it the correlation ID of published events
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(requestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(events.WithCorrelationID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
+
 // LoggingMiddleware logs HTTP requests with structured logging
 func LoggingMiddleware(log logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -28,6 +60,7 @@ func LoggingMiddleware(log logger.Logger) gin.HandlerFunc {
 			logger.Int("size", c.Writer.Size()),
 			logger.Duration("duration", duration),
 			logger.String("client_ip", c.ClientIP()),
+			logger.String("request_id", c.GetString(requestIDKey)),
 		)
 	}
 }
@@ -47,3 +80,19 @@ func CORSMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AdminAuthMiddleware requires "Authorization: Bearer <token>" on admin routes
+func AdminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, handlers.ErrorResponse{
+				Error: "Missing or invalid admin token",
+				Code:  "UNAUTHORIZED",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
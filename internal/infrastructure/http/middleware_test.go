@@ -0,0 +1,56 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	apphttp "recipe-processor/internal/infrastructure/http"
+	"recipe-processor/internal/shared/events"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestIDMiddleware_SetsCorrelationID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		requestID string
+	}{
+		{name: "reuses client request ID", requestID: "request-1"},
+		{name: "generates missing request ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var correlationID string
+			router := gin.New()
+			router.Use(apphttp.RequestIDMiddleware())
+			router.GET("/", func(c *gin.Context) {
+				correlationID = events.CorrelationIDFromContext(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.requestID != "" {
+				req.Header.Set(apphttp.RequestIDHeader, tt.requestID)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			got := w.Header().Get(apphttp.RequestIDHeader)
+			if got == "" {
+				t.Fatal("Expected X-Request-ID response header")
+			}
+			if tt.requestID != "" && got != tt.requestID {
+				t.Errorf("Expected request ID '%s', got '%s'", tt.requestID, got)
+			}
+			if correlationID != got {
+				t.Errorf("Expected correlation ID '%s', got '%s'", got, correlationID)
+			}
+		})
+	}
+}
@@ -13,26 +13,30 @@ import (
 )
 
 type Server struct {
-	config   *config.Config
-	logger   logger.Logger
-	eventBus events.EventBus
-	srv      *http.Server
+	config     *config.Config
+	logger     logger.Logger
+	repository recipe.OutboxRepository
+	bus        events.DeadLetterQueue
+	checks     []HealthCheck
+	srv        *http.Server
 }
 
-func NewServer(cfg *config.Config, log logger.Logger, eventBus events.EventBus) *Server {
+// NewServer creates the API server; checks decide whether GET /ready succeeds
+// Submissions are turned away while bus reports a full queue, see events.Admitter
+func NewServer(cfg *config.Config, log logger.Logger, repository recipe.OutboxRepository, bus events.DeadLetterQueue, checks ...HealthCheck) *Server {
 	return &Server{
-		config:   cfg,
-		logger:   log,
-		eventBus: eventBus,
+		config:     cfg,
+		logger:     log,
+		repository: repository,
+		bus:        bus,
+		checks:     checks,
 	}
 }
 
 func (s *Server) Start() error {
-	router := s.setupRouter()
-
 	s.srv = &http.Server{
 		Addr:         ":" + s.config.Port,
-		Handler:      router,
+		Handler:      s.Handler(),
 		ReadTimeout:  s.config.ReadTimeout,
 		WriteTimeout: s.config.WriteTimeout,
 		IdleTimeout:  s.config.IdleTimeout,
@@ -45,6 +49,11 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// Handler returns the routes Start serves, for serving them in tests
+func (s *Server) Handler() http.Handler {
+	return s.setupRouter()
+}
+
 func (s *Server) Shutdown(ctx context.Context) error {
 	if s.srv == nil {
 		return nil
@@ -58,20 +67,45 @@ func (s *Server) setupRouter() *gin.Engine {
 
 	// Middleware
 	router.Use(gin.Recovery())
+	router.Use(RequestIDMiddleware())
 	router.Use(LoggingMiddleware(s.logger))
 	router.Use(CORSMiddleware())
 
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
-	})
+	// Liveness and readiness
+	RegisterHealthRoutes(router, s.checks...)
 
 	v1 := router.Group("/api/v1")
 	{
 		// Recipe routes
-		submitService := recipe.NewSubmitRecipeService(s.eventBus, s.logger)
-		recipeHandler := handlers.NewRecipeHandler(s.logger, submitService)
+		submitService := recipe.NewSubmitRecipeService(s.repository, s.logger)
+		getService := recipe.NewGetRecipeService(s.repository)
+		var admission []handlers.AdmissionCheck
+		if admitter, ok := s.bus.(events.Admitter); ok {
+			admission = append(admission, admitter.Admit)
+		}
+		recipeHandler := handlers.NewRecipeHandler(s.logger, submitService, getService, admission...)
 		v1.POST("/recipes", recipeHandler.SubmitRecipe)
+		v1.GET("/recipes/:id", recipeHandler.GetRecipe)
+	}
+
+	if s.config.AdminToken == "" {
+		s.logger.Warn("Admin endpoints disabled: ADMIN_TOKEN not set")
+		return router
+	}
+
+	admin := router.Group("/admin", AdminAuthMiddleware(s.config.AdminToken))
+	{
+		adminHandler := handlers.NewAdminHandler(s.logger, s.bus)
+		admin.GET("/dead-letters", adminHandler.ListDeadLetters)
+		admin.POST("/dead-letters/:id/redrive", adminHandler.RedriveDeadLetter)
+
+		// Only the in-memory bus can be inspected and paused
+		if inspector, ok := s.bus.(handlers.EventBusInspector); ok {
+			eventBusHandler := handlers.NewEventBusHandler(s.logger, inspector)
+			admin.GET("/eventbus", eventBusHandler.GetStats)
+			admin.POST("/eventbus/:event_type/pause", eventBusHandler.PauseEventType)
+			admin.POST("/eventbus/:event_type/resume", eventBusHandler.ResumeEventType)
+		}
 	}
 
 	return router
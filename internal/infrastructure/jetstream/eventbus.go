@@ -0,0 +1,566 @@
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	natsjs "github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// DefaultStream is the JetStream stream holding the events
+	DefaultStream = "RECIPE_EVENTS"
+	// DefaultSubjectPrefix is the first token of every subject the bus uses
+	DefaultSubjectPrefix = "recipes"
+	// DefaultMaxDeliver is how often a message is delivered before it is dead-lettered
+	DefaultMaxDeliver = 5
+	// DefaultWorkers is the number of messages handled concurrently per subscription
+	DefaultWorkers = 4
+	// duplicateWindow is how long JetStream remembers event IDs to drop republished events
+	duplicateWindow = 2 * time.Minute
+)
+
+// Headers carrying the event metadata; the payload is the registry's JSON encoding
+const (
+	headerEventType = "Event-Type"
+	headerEnvelope  = "Event-Envelope"
+)
+
+// invalidConsumerChars are not allowed in durable consumer names
+var invalidConsumerChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// Config holds configuration for the JetStream event bus
+type Config struct {
+	URL           string
+	Stream        string
+	SubjectPrefix string
+	// MaxDeliver caps deliveries per message for subscriptions without their own retry policy
+	MaxDeliver     int
+	HandlerTimeout time.Duration
+	// AckWait is how long JetStream waits for an ack before redelivering; defaults to twice HandlerTimeout
+	AckWait time.Duration
+	Workers int
+	// RetryPolicy sets the delay before redelivering a failed message; its
+	// MaxAttempts is replaced by MaxDeliver
+	RetryPolicy events.RetryPolicy
+	// DeadLetters receives messages handlers gave up on; defaults to an in-memory store
+	DeadLetters events.DeadLetterStore
+	// Store records every published event; optional
+	Store    events.EventStore
+	Producer string
+	// Middleware wraps every handler; nil means events.DefaultMiddleware
+	Middleware []events.HandlerMiddleware
+}
+
+// EventBus is an EventBus backed by NATS JetStream
+// Every subscription is a durable consumer named after the subscription, so
+// processes subscribing under the same name share the work and messages
+// published while no consumer runs are delivered once one starts
+type EventBus struct {
+	nc       *nats.Conn
+	js       natsjs.JetStream
+	registry *events.Registry
+	config   Config
+	logger   logger.Logger
+	mu       sync.Mutex
+	subs     []*subscription
+	started  bool
+	stopped  bool
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// subscription is a handler bound to a durable consumer
+type subscription struct {
+	name      string
+	eventType string
+	// handler is wrapped in the middleware chain
+	handler   events.EventHandler
+	retry     events.RetryPolicy
+	timeout   time.Duration
+	consumers []natsjs.ConsumeContext
+	bus       *EventBus
+	removed   atomic.Bool
+}
+
+func (s *subscription) Name() string      { return s.name }
+func (s *subscription) EventType() string { return s.eventType }
+
+// Unsubscribe stops consuming; the durable consumer is kept, so subscribing
+// the same name again resumes where it left off
+func (s *subscription) Unsubscribe() {
+	s.bus.unsubscribe(s)
+}
+
+// NewEventBus connects to NATS; the stream and consumers are created by Start
+// registry encodes published events and decodes delivered ones
+func NewEventBus(registry *events.Registry, log logger.Logger, cfg Config) (*EventBus, error) {
+	if cfg.Stream == "" {
+		cfg.Stream = DefaultStream
+	}
+	if cfg.SubjectPrefix == "" {
+		cfg.SubjectPrefix = DefaultSubjectPrefix
+	}
+	if cfg.MaxDeliver <= 0 {
+		cfg.MaxDeliver = DefaultMaxDeliver
+	}
+	if cfg.HandlerTimeout <= 0 {
+		cfg.HandlerTimeout = events.DefaultHandlerTimeout
+	}
+	if cfg.AckWait <= 0 {
+		cfg.AckWait = 2 * cfg.HandlerTimeout
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkers
+	}
+	if cfg.RetryPolicy.InitialBackoff <= 0 {
+		cfg.RetryPolicy = events.DefaultRetryPolicy()
+	}
+	cfg.RetryPolicy.MaxAttempts = cfg.MaxDeliver
+	if cfg.DeadLetters == nil {
+		cfg.DeadLetters = events.NewMemoryDeadLetterStore()
+	}
+	if cfg.Producer == "" {
+		cfg.Producer = events.DefaultProducer
+	}
+	if cfg.Middleware == nil {
+		cfg.Middleware = events.DefaultMiddleware(log)
+	}
+
+	nc, err := nats.Connect(cfg.URL, nats.Name(cfg.Producer))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", cfg.URL, err)
+	}
+
+	js, err := natsjs.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("failed to open JetStream: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &EventBus{
+		nc:       nc,
+		js:       js,
+		registry: registry,
+		config:   cfg,
+		logger:   log,
+		ctx:      ctx,
+		cancel:   cancel,
+	}, nil
+}
+
+// Start creates the stream and starts consuming for every subscription
+func (b *EventBus) Start(ctx context.Context) error {
+	_, err := b.js.CreateOrUpdateStream(ctx, natsjs.StreamConfig{
+		Name:       b.config.Stream,
+		Subjects:   []string{b.config.SubjectPrefix + ".>"},
+		Storage:    natsjs.FileStorage,
+		Duplicates: duplicateWindow,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create stream %s: %w", b.config.Stream, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.ctx, b.cancel = context.WithCancel(ctx)
+	b.started = true
+	for _, sub := range b.subs {
+		if err := b.consume(ctx, sub); err != nil {
+			return err
+		}
+	}
+
+	b.logger.Info("Event bus started",
+		logger.String("stream", b.config.Stream),
+		logger.Int("subscriptions", len(b.subs)),
+	)
+	return nil
+}
+
+// Stop stops consuming, lets running handlers finish until ctx is done and
+// closes the connection
+// Messages that were not acknowledged are redelivered after a restart
+func (b *EventBus) Stop(ctx context.Context) error {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return nil
+	}
+	b.stopped = true
+	var consumers []natsjs.ConsumeContext
+	for _, sub := range b.subs {
+		consumers = append(consumers, sub.consumers...)
+	}
+	b.mu.Unlock()
+
+	for _, cc := range consumers {
+		cc.Drain()
+	}
+
+	var stopErr error
+	for _, cc := range consumers {
+		select {
+		case <-cc.Closed():
+		case <-ctx.Done():
+			// Out of time: interrupt running handlers, JetStream redelivers their messages
+			b.cancel()
+			cc.Stop()
+			<-cc.Closed()
+			stopErr = fmt.Errorf("%w: handlers interrupted, their messages will be redelivered", events.ErrDrainIncomplete)
+		}
+	}
+	b.cancel()
+
+	if err := b.nc.Drain(); err != nil {
+		b.logger.Error("Failed to drain NATS connection", logger.Error(err))
+	}
+
+	b.logger.Info("Event bus stopped")
+	return stopErr
+}
+
+// Publish stores the event in the stream
+// The event ID doubles as the JetStream message ID, so republishing an event
+// within the duplicate window does not deliver it twice
+func (b *EventBus) Publish(ctx context.Context, event events.Event) error {
+	if b.stopping() {
+		return events.ErrBusStopped
+	}
+
+	env := events.NewEnvelope(ctx, event, b.config.Producer)
+	if b.config.Store != nil {
+		if err := b.config.Store.Append(ctx, event, env); err != nil {
+			return fmt.Errorf("failed to store %s event: %w", event.EventType(), err)
+		}
+	}
+
+	if err := b.publish(ctx, b.eventSubject(event.EventType()), event, env, env.EventID); err != nil {
+		return err
+	}
+
+	b.logger.Debug("Event published",
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+	)
+	return nil
+}
+
+// Subscribe registers handler under its function name, see events.Subscribe
+// Subscription errors are logged; use SubscribeNamed to handle them
+func (b *EventBus) Subscribe(eventType string, handler events.EventHandler) {
+	if _, err := events.Subscribe(b, eventType, handler); err != nil {
+		b.logger.Error("Failed to subscribe handler", logger.String("event_type", eventType), logger.Error(err))
+	}
+}
+
+// SubscribeNamed registers a handler for an event type or a pattern such as
+// "recipe.*" or "*", see events.MatchEventType
+// The subscription name becomes the durable consumer name, so it must be
+// unique on the bus
+func (b *EventBus) SubscribeNamed(eventType, name string, handler events.EventHandler, opts ...events.SubscribeOption) (events.Subscription, error) {
+	cfg := events.ApplyNamedSubscribeOptions(name, handler, b.config.RetryPolicy, opts...)
+	sub := &subscription{
+		name:      invalidConsumerChars.ReplaceAllString(cfg.Name, "_"),
+		eventType: eventType,
+		handler:   events.WrapHandler(handler, b.config.Middleware, cfg.Middleware),
+		retry:     cfg.Retry,
+		timeout:   cfg.HandlerTimeout(b.config.HandlerTimeout),
+		bus:       b,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.findSubscription(sub.name) != nil {
+		return nil, fmt.Errorf("%w: %s", events.ErrSubscriptionExists, sub.name)
+	}
+
+	if b.started && !b.stopped {
+		if err := b.consume(b.ctx, sub); err != nil {
+			for _, cc := range sub.consumers {
+				cc.Stop()
+			}
+			return nil, err
+		}
+	}
+	b.subs = append(b.subs, sub)
+
+	b.logger.Info("Handler subscribed",
+		logger.String("event_type", eventType),
+		logger.String("handler", sub.name),
+	)
+	return sub, nil
+}
+
+// unsubscribe stops the consumers of sub and lets its running handlers finish
+func (b *EventBus) unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	if sub.removed.Swap(true) {
+		b.mu.Unlock()
+		return
+	}
+	for i, s := range b.subs {
+		if s == sub {
+			b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+			break
+		}
+	}
+	consumers := sub.consumers
+	sub.consumers = nil
+	b.mu.Unlock()
+
+	for _, cc := range consumers {
+		cc.Drain()
+	}
+
+	b.logger.Info("Handler unsubscribed",
+		logger.String("event_type", sub.eventType),
+		logger.String("handler", sub.name),
+	)
+}
+
+// Ping reports whether the bus is connected to NATS; it returns
+// ErrBusStopped once Stop was called
+func (b *EventBus) Ping(ctx context.Context) error {
+	if b.stopping() {
+		return events.ErrBusStopped
+	}
+	if status := b.nc.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("NATS connection is %s", status)
+	}
+	return nil
+}
+
+// DeadLetters returns the events handlers gave up on, oldest first
+func (b *EventBus) DeadLetters(ctx context.Context) ([]events.DeadLetter, error) {
+	return b.config.DeadLetters.List(ctx)
+}
+
+// Redrive delivers a dead letter to its handler again, with fresh attempts
+// The message goes to the consumer's redrive subject, so other subscribers
+// of the event type do not see it again
+func (b *EventBus) Redrive(ctx context.Context, id string) error {
+	letter, err := b.config.DeadLetters.Remove(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := b.publish(ctx, b.redriveSubject(letter.Handler), letter.Event, letter.Envelope, "redrive-"+letter.ID); err != nil {
+		_ = b.config.DeadLetters.Add(ctx, letter)
+		return err
+	}
+
+	b.logger.Info("Dead letter redriven",
+		logger.String("dead_letter_id", id),
+		logger.String("event_type", letter.Event.EventType()),
+		logger.String("handler", letter.Handler),
+	)
+	return nil
+}
+
+// consume creates the durable consumer of sub and starts its workers; callers hold b.mu
+func (b *EventBus) consume(ctx context.Context, sub *subscription) error {
+	consumer, err := b.js.CreateOrUpdateConsumer(ctx, b.config.Stream, natsjs.ConsumerConfig{
+		Durable:        sub.name,
+		FilterSubjects: []string{b.filterSubject(sub.eventType), b.redriveSubject(sub.name)},
+		AckPolicy:      natsjs.AckExplicitPolicy,
+		// A handler with a longer timeout must not be redelivered while it runs
+		AckWait:       max(b.config.AckWait, 2*sub.timeout),
+		MaxDeliver:    sub.retry.MaxAttempts,
+		DeliverPolicy: natsjs.DeliverAllPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create consumer %s: %w", sub.name, err)
+	}
+
+	for i := 0; i < b.config.Workers; i++ {
+		// One message at a time, so nothing waits in a buffer while its ack timer runs
+		cc, err := consumer.Consume(func(msg natsjs.Msg) { b.handle(sub, msg) }, natsjs.PullMaxMessages(1))
+		if err != nil {
+			return fmt.Errorf("failed to consume %s: %w", sub.name, err)
+		}
+		sub.consumers = append(sub.consumers, cc)
+	}
+
+	return nil
+}
+
+// handle runs the handler for one delivery and acknowledges the outcome
+func (b *EventBus) handle(sub *subscription, msg natsjs.Msg) {
+	if sub.removed.Load() {
+		// Pulled while unsubscribing; redelivered to the next consumer
+		b.settle(msg.Nak())
+		return
+	}
+
+	attempt := 1
+	if meta, err := msg.Metadata(); err == nil {
+		attempt = int(meta.NumDelivered)
+	}
+
+	event, env, err := b.decode(msg)
+	if err != nil {
+		// Redelivering cannot fix a message we cannot read
+		b.logger.Error("Terminating undecodable message",
+			logger.String("subject", msg.Subject()),
+			logger.String("handler", sub.name),
+			logger.Error(err),
+		)
+		b.settle(msg.TermWithReason("undecodable: " + err.Error()))
+		return
+	}
+
+	handlerCtx := events.WithHandlerInfo(events.ContextWithEnvelope(b.ctx, env), events.HandlerInfo{
+		Name:        sub.name,
+		Attempt:     attempt,
+		MaxAttempts: sub.retry.MaxAttempts,
+	})
+	handlerCtx, cancel := context.WithTimeout(handlerCtx, sub.timeout)
+	err = events.CallHandler(handlerCtx, sub.handler, event)
+	cancel()
+	events.LogPanic(handlerCtx, b.logger, event, err)
+
+	if err == nil {
+		b.settle(msg.Ack())
+		return
+	}
+
+	retryable := events.IsRetryable(err)
+	switch {
+	case b.stopping() && retryable:
+		// Redelivered after the restart, without spending a backoff now
+		b.settle(msg.Nak())
+	case retryable && attempt < sub.retry.MaxAttempts:
+		b.settle(msg.NakWithDelay(sub.retry.Backoff(attempt)))
+	default:
+		b.deadLetter(sub, event, env, attempt, err)
+		b.settle(msg.TermWithReason(err.Error()))
+	}
+}
+
+// settle logs a failed acknowledgement; JetStream redelivers after AckWait
+func (b *EventBus) settle(err error) {
+	if err != nil {
+		b.logger.Warn("Failed to acknowledge message", logger.Error(err))
+	}
+}
+
+func (b *EventBus) deadLetter(sub *subscription, event events.Event, env events.Envelope, attempts int, cause error) {
+	letter := events.DeadLetter{
+		ID:        uuid.New().String(),
+		Event:     event,
+		Envelope:  env,
+		Handler:   sub.name,
+		Attempts:  attempts,
+		LastError: cause.Error(),
+		FailedAt:  time.Now(),
+	}
+
+	if err := b.config.DeadLetters.Add(context.WithoutCancel(b.ctx), letter); err != nil {
+		// Last resort: the event only survives in the logs
+		b.logger.Error("Failed to store dead letter",
+			logger.String("event_type", event.EventType()),
+			logger.String("handler", sub.name),
+			logger.Any("event", event),
+			logger.Error(err),
+		)
+		return
+	}
+
+	b.logger.Warn("Event dead-lettered",
+		logger.String("dead_letter_id", letter.ID),
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+		logger.String("handler", sub.name),
+		logger.Int("attempts", attempts),
+		logger.String("reason", letter.LastError),
+	)
+}
+
+func (b *EventBus) publish(ctx context.Context, subject string, event events.Event, env events.Envelope, msgID string) error {
+	payload, version, err := b.registry.Encode(event)
+	if err != nil {
+		return err
+	}
+	env.SchemaVersion = version
+
+	envelope, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope of event %s: %w", env.EventID, err)
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = payload
+	msg.Header.Set(headerEventType, event.EventType())
+	msg.Header.Set(headerEnvelope, string(envelope))
+
+	if _, err := b.js.PublishMsg(ctx, msg, natsjs.WithMsgID(msgID)); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.EventType(), err)
+	}
+	return nil
+}
+
+func (b *EventBus) decode(msg natsjs.Msg) (events.Event, events.Envelope, error) {
+	var env events.Envelope
+	if err := json.Unmarshal([]byte(msg.Headers().Get(headerEnvelope)), &env); err != nil {
+		return nil, env, fmt.Errorf("invalid envelope: %w", err)
+	}
+
+	event, err := b.registry.Decode(msg.Headers().Get(headerEventType), env.SchemaVersion, msg.Data())
+	return event, env, err
+}
+
+func (b *EventBus) eventSubject(eventType string) string {
+	return b.config.SubjectPrefix + ".events." + eventType
+}
+
+// filterSubject maps an event type or pattern onto the subjects it matches;
+// a trailing "*" becomes the NATS wildcard for any number of tokens
+func (b *EventBus) filterSubject(pattern string) string {
+	if !events.IsPattern(pattern) {
+		return b.eventSubject(pattern)
+	}
+	return b.eventSubject(strings.TrimSuffix(pattern, events.Wildcard) + ">")
+}
+
+func (b *EventBus) redriveSubject(consumer string) string {
+	return b.config.SubjectPrefix + ".redrive." + consumer
+}
+
+// findSubscription looks up a subscription by name; callers hold b.mu
+func (b *EventBus) findSubscription(name string) *subscription {
+	for _, sub := range b.subs {
+		if sub.name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+func (b *EventBus) stopping() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.stopped
+}
+
+var (
+	_ events.EventBus        = (*EventBus)(nil)
+	_ events.DeadLetterQueue = (*EventBus)(nil)
+)
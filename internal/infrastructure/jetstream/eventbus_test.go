@@ -0,0 +1,387 @@
+package jetstream_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"recipe-processor/internal/infrastructure/jetstream"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+type orderPlaced struct {
+	OrderID string    `json:"order_id"`
+	At      time.Time `json:"at"`
+}
+
+func (e *orderPlaced) EventType() string     { return "order.placed" }
+func (e *orderPlaced) OccurredAt() time.Time { return e.At }
+
+// startServer runs an in-process NATS server with JetStream enabled
+func startServer(t *testing.T) string {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	go ns.Start()
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server did not start")
+	}
+	t.Cleanup(ns.Shutdown)
+
+	return ns.ClientURL()
+}
+
+func newRegistry(t *testing.T) *events.Registry {
+	t.Helper()
+
+	registry := events.NewRegistry()
+	if err := registry.Register(func() events.Event { return &orderPlaced{} }); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	return registry
+}
+
+// newBus creates a bus with fast redeliveries; the caller subscribes and starts it
+func newBus(t *testing.T, url string, maxDeliver int) *jetstream.EventBus {
+	t.Helper()
+
+	bus, err := jetstream.NewEventBus(newRegistry(t), logger.NewNoopLogger(), jetstream.Config{
+		URL:            url,
+		MaxDeliver:     maxDeliver,
+		HandlerTimeout: time.Second,
+		Workers:        1,
+		RetryPolicy:    events.RetryPolicy{InitialBackoff: 10 * time.Millisecond, Multiplier: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewEventBus() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	return bus
+}
+
+func start(t *testing.T, bus *jetstream.EventBus) {
+	t.Helper()
+
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+}
+
+// waitFor polls cond until it holds or the test times out
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEventBus_DeliversEventWithEnvelope(t *testing.T) {
+	bus := newBus(t, startServer(t), 3)
+
+	type delivery struct {
+		event *orderPlaced
+		env   events.Envelope
+	}
+	received := make(chan delivery, 1)
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		env, _ := events.EnvelopeFromContext(ctx)
+		received <- delivery{event: event.(*orderPlaced), env: env}
+		return nil
+	})
+	start(t, bus)
+
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+	if err := bus.Publish(ctx, &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got.event.OrderID != "order-1" {
+			t.Errorf("expected order-1, got %+v", got.event)
+		}
+		if got.env.CorrelationID != "request-1" || got.env.EventID == "" {
+			t.Errorf("unexpected envelope %+v", got.env)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
+
+func TestEventBus_NaksRetryableErrorsUntilSuccess(t *testing.T) {
+	bus := newBus(t, startServer(t), 3)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		if calls.Add(1) < 3 {
+			return errors.New("warehouse unavailable")
+		}
+		return nil
+	})
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	waitFor(t, "third delivery", func() bool { return calls.Load() == 3 })
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 0 {
+		t.Errorf("expected no dead letters, got %d", len(letters))
+	}
+}
+
+func TestEventBus_DeadLettersAfterMaxDeliverAndRedrives(t *testing.T) {
+	bus := newBus(t, startServer(t), 2)
+
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		if healthy.Load() {
+			return nil
+		}
+		return errors.New("warehouse unavailable")
+	})
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	var letters []events.DeadLetter
+	waitFor(t, "dead letter", func() bool {
+		letters, _ = bus.DeadLetters(context.Background())
+		return len(letters) == 1
+	})
+	if letters[0].Attempts != 2 || letters[0].Handler != "ship-order" {
+		t.Errorf("unexpected dead letter %+v", letters[0])
+	}
+
+	healthy.Store(true)
+	if err := bus.Redrive(context.Background(), letters[0].ID); err != nil {
+		t.Fatalf("Redrive() error = %v", err)
+	}
+	waitFor(t, "redelivery", func() bool { return calls.Load() == 3 })
+}
+
+func TestEventBus_PermanentErrorsAreNotRedelivered(t *testing.T) {
+	bus := newBus(t, startServer(t), 5)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return events.Permanent(errors.New("order does not exist"))
+	})
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	waitFor(t, "dead letter", func() bool {
+		letters, _ := bus.DeadLetters(context.Background())
+		return len(letters) == 1
+	})
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 delivery, got %d", got)
+	}
+}
+
+func TestEventBus_DurableConsumerReceivesEventsPublishedWhileDown(t *testing.T) {
+	url := startServer(t)
+
+	// Register the durable consumer, then go away
+	first := newBus(t, url, 3)
+	first.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error { return nil })
+	start(t, first)
+	if err := first.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	publisher := newBus(t, url, 3)
+	start(t, publisher)
+	if err := publisher.Publish(context.Background(), &orderPlaced{OrderID: "order-2"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	received := make(chan string, 1)
+	second := newBus(t, url, 3)
+	second.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		received <- event.(*orderPlaced).OrderID
+		return nil
+	})
+	start(t, second)
+
+	select {
+	case id := <-received:
+		if id != "order-2" {
+			t.Errorf("expected order-2, got %s", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
+
+func TestEventBus_PublishDeduplicatesEventIDs(t *testing.T) {
+	bus := newBus(t, startServer(t), 3)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return nil
+	})
+	start(t, bus)
+
+	// An outbox relay republishing after a crash reuses the stored envelope
+	ctx := events.WithOutgoingEnvelope(context.Background(), events.Envelope{EventID: "event-1"})
+	for range 2 {
+		if err := bus.Publish(ctx, &orderPlaced{OrderID: "order-1"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	// A later event proves the duplicate was not just slow
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-2"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	waitFor(t, "second event", func() bool { return calls.Load() >= 2 })
+	time.Sleep(50 * time.Millisecond)
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 deliveries, got %d", got)
+	}
+}
+
+func TestEventBus_TerminatesUndecodableMessages(t *testing.T) {
+	url := startServer(t)
+	bus := newBus(t, url, 3)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return nil
+	})
+	start(t, bus)
+
+	// A foreign producer publishing without the envelope header
+	nc, err := nats.Connect(url)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer nc.Close()
+	raw, _ := json.Marshal(map[string]string{"order_id": "order-1"})
+	if err := nc.Publish("recipes.events.order.placed", raw); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-2"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	waitFor(t, "valid event", func() bool { return calls.Load() == 1 })
+}
+
+func TestEventBus_PatternSubscription(t *testing.T) {
+	bus := newBus(t, startServer(t), 3)
+
+	received := make(chan string, 1)
+	bus.SubscribeNamed("order.*", "audit", func(ctx context.Context, event events.Event) error {
+		received <- event.EventType()
+		return nil
+	})
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case eventType := <-received:
+		if eventType != "order.placed" {
+			t.Errorf("expected order.placed, got %s", eventType)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
+
+func TestEventBus_UnsubscribeKeepsDurableConsumer(t *testing.T) {
+	bus := newBus(t, startServer(t), 3)
+
+	var before atomic.Int32
+	sub, err := bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		before.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	waitFor(t, "first delivery", func() bool { return before.Load() == 1 })
+
+	sub.Unsubscribe()
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-2"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	// Subscribing the name again resumes the durable consumer
+	received := make(chan string, 1)
+	if _, err := bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		received <- event.(*orderPlaced).OrderID
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	select {
+	case orderID := <-received:
+		if orderID != "order-2" {
+			t.Errorf("expected order-2, got %s", orderID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+	if got := before.Load(); got != 1 {
+		t.Errorf("expected unsubscribed handler to run once, got %d", got)
+	}
+}
+
+func TestEventBus_RejectsTakenName(t *testing.T) {
+	bus := newBus(t, startServer(t), 3)
+	noop := func(ctx context.Context, event events.Event) error { return nil }
+
+	if _, err := bus.SubscribeNamed("order.placed", "ship-order", noop); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	// Consumer names are unique per stream, whatever the event type
+	if _, err := bus.SubscribeNamed("order.*", "ship-order", noop); !errors.Is(err, events.ErrSubscriptionExists) {
+		t.Errorf("expected ErrSubscriptionExists, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultOllamaTimeout is the default timeout for a single Ollama request
+	DefaultOllamaTimeout = 5 * time.Minute
+
+	generatePath = "/api/generate"
+	chatPath     = "/api/chat"
+)
+
+// OllamaConfig holds configuration for the Ollama client
+type OllamaConfig struct {
+	BaseURL string
+	Model   string
+	Timeout time.Duration
+}
+
+// OllamaClient talks to the Ollama HTTP API
+type OllamaClient struct {
+	baseURL    string
+	model      string
+	httpClient *http.Client
+}
+
+// NewOllamaClient creates a new Ollama client
+func NewOllamaClient(cfg OllamaConfig) *OllamaClient {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultOllamaTimeout
+	}
+
+	return &OllamaClient{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		model:      cfg.Model,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// GenerateRequest is the body of a /api/generate call
+type GenerateRequest struct {
+	Model   string         `json:"model"`
+	Prompt  string         `json:"prompt"`
+	System  string         `json:"system,omitempty"`
+	Format  string         `json:"format,omitempty"`
+	Stream  bool           `json:"stream"`
+	Options map[string]any `json:"options,omitempty"`
+}
+
+// GenerateResponse is the non-streaming response of /api/generate
+type GenerateResponse struct {
+	Model     string `json:"model"`
+	CreatedAt string `json:"created_at"`
+	Response  string `json:"response"`
+	Done      bool   `json:"done"`
+}
+
+// ChatMessage is a single message in a chat conversation
+type ChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatRequest is the body of a /api/chat call
+type ChatRequest struct {
+	Model    string         `json:"model"`
+	Messages []ChatMessage  `json:"messages"`
+	Format   string         `json:"format,omitempty"`
+	Stream   bool           `json:"stream"`
+	Options  map[string]any `json:"options,omitempty"`
+}
+
+// ChatResponse is the non-streaming response of /api/chat
+type ChatResponse struct {
+	Model     string      `json:"model"`
+	CreatedAt string      `json:"created_at"`
+	Message   ChatMessage `json:"message"`
+	Done      bool        `json:"done"`
+}
+
+// APIError is returned when Ollama responds with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ollama api error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the request may succeed later
+// A 404 usually means the model is not pulled, which retrying will not fix
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// Generate runs a single-prompt completion
+func (c *OllamaClient) Generate(ctx context.Context, req GenerateRequest) (*GenerateResponse, error) {
+	if req.Model == "" {
+		req.Model = c.model
+	}
+	// Streaming responses are not supported by this client
+	req.Stream = false
+
+	var resp GenerateResponse
+	if err := c.post(ctx, generatePath, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Chat runs a chat completion
+func (c *OllamaClient) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	if req.Model == "" {
+		req.Model = c.model
+	}
+	// Streaming responses are not supported by this client
+	req.Stream = false
+
+	var resp ChatResponse
+	if err := c.post(ctx, chatPath, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// post sends a JSON request and decodes the JSON response into out
+func (c *OllamaClient) post(ctx context.Context, path string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("ollama request failed: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return &APIError{
+			StatusCode: httpResp.StatusCode,
+			Message:    readErrorMessage(httpResp.Body),
+		}
+	}
+
+	if err := json.NewDecoder(httpResp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode ollama response: %w", err)
+	}
+
+	return nil
+}
+
+// readErrorMessage extracts the error message from an Ollama error body
+func readErrorMessage(body io.Reader) string {
+	raw, err := io.ReadAll(io.LimitReader(body, 4096))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(raw, &payload) == nil && payload.Error != "" {
+		return payload.Error
+	}
+
+	return strings.TrimSpace(string(raw))
+}
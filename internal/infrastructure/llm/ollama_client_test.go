@@ -0,0 +1,110 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"recipe-processor/internal/infrastructure/llm"
+	"testing"
+)
+
+func TestOllamaClient_Generate(t *testing.T) {
+	var got llm.GenerateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/generate" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"model":    got.Model,
+			"response": "hello",
+			"done":     true,
+		})
+	}))
+	defer server.Close()
+
+	client := llm.NewOllamaClient(llm.OllamaConfig{BaseURL: server.URL + "/", Model: "test-model"})
+
+	resp, err := client.Generate(context.Background(), llm.GenerateRequest{Prompt: "hi", Stream: true})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if resp.Response != "hello" {
+		t.Errorf("Response = %q, want %q", resp.Response, "hello")
+	}
+	if got.Model != "test-model" {
+		t.Errorf("request model = %q, want default model %q", got.Model, "test-model")
+	}
+	if got.Stream {
+		t.Error("expected streaming to be disabled")
+	}
+}
+
+func TestOllamaClient_Chat(t *testing.T) {
+	var got llm.ChatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"model":   "override",
+			"message": map[string]string{"role": "assistant", "content": "{}"},
+			"done":    true,
+		})
+	}))
+	defer server.Close()
+
+	client := llm.NewOllamaClient(llm.OllamaConfig{BaseURL: server.URL, Model: "test-model"})
+
+	resp, err := client.Chat(context.Background(), llm.ChatRequest{
+		Model:    "override",
+		Messages: []llm.ChatMessage{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if resp.Message.Content != "{}" {
+		t.Errorf("Message.Content = %q, want %q", resp.Message.Content, "{}")
+	}
+	if got.Model != "override" {
+		t.Errorf("request model = %q, want %q", got.Model, "override")
+	}
+	if len(got.Messages) != 1 || got.Messages[0].Content != "hi" {
+		t.Errorf("unexpected messages: %+v", got.Messages)
+	}
+}
+
+func TestOllamaClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"model 'missing' not found"}`))
+	}))
+	defer server.Close()
+
+	client := llm.NewOllamaClient(llm.OllamaConfig{BaseURL: server.URL, Model: "missing"})
+
+	_, err := client.Generate(context.Background(), llm.GenerateRequest{Prompt: "hi"})
+
+	var apiErr *llm.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusNotFound)
+	}
+	if apiErr.Message != "model 'missing' not found" {
+		t.Errorf("Message = %q", apiErr.Message)
+	}
+	if apiErr.Retryable() {
+		t.Error("expected missing model not to be retryable")
+	}
+}
@@ -0,0 +1,172 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"time"
+)
+
+// ErrInvalidModelOutput is returned when the model response is not a usable recipe
+var ErrInvalidModelOutput = errors.New("model returned an invalid recipe")
+
+const recipeSystemPrompt = `You extract structured recipes from free text.
+Respond with a single JSON object and nothing else, using exactly this shape:
+{
+  "title": string,
+  "servings": integer (0 if unknown),
+  "prep_time_minutes": integer (0 if unknown),
+  "cook_time_minutes": integer (0 if unknown),
+  "total_time_minutes": integer (0 if unknown),
+  "yield": string (empty if unknown, e.g. "24 cookies"),
+  "tags": [string, ...],
+  "ingredients": [
+    {
+      "quantity": number (0 if unspecified),
+      "unit": string (empty for countable items),
+      "name": string,
+      "preparation": string (empty if none, e.g. "finely chopped"),
+      "optional": boolean
+    }
+  ],
+  "steps": [
+    {
+      "text": string,
+      "duration_minutes": integer (0 if unknown),
+      "temperature": {"value": number, "unit": "C" or "F"} or null
+    }
+  ]
+}
+Convert fractions such as 1/2 to decimals.
+Keep steps in their original order and do not number them.
+Tags are short lowercase labels such as cuisine, course or diet.`
+
+// recipeOutput is the JSON shape the model is asked to produce
+type recipeOutput struct {
+	Title            string             `json:"title"`
+	Servings         int                `json:"servings"`
+	PrepTimeMinutes  int                `json:"prep_time_minutes"`
+	CookTimeMinutes  int                `json:"cook_time_minutes"`
+	TotalTimeMinutes int                `json:"total_time_minutes"`
+	Yield            string             `json:"yield"`
+	Tags             []string           `json:"tags"`
+	Ingredients      []ingredientOutput `json:"ingredients"`
+	Steps            []stepOutput       `json:"steps"`
+}
+
+type ingredientOutput struct {
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit"`
+	Name        string  `json:"name"`
+	Preparation string  `json:"preparation"`
+	Optional    bool    `json:"optional"`
+}
+
+type stepOutput struct {
+	Text            string             `json:"text"`
+	DurationMinutes int                `json:"duration_minutes"`
+	Temperature     *temperatureOutput `json:"temperature"`
+}
+
+type temperatureOutput struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// RecipeParser extracts structured recipes using an Ollama chat model
+type RecipeParser struct {
+	client *OllamaClient
+}
+
+// NewRecipeParser creates a new Ollama-backed recipe parser
+func NewRecipeParser(client *OllamaClient) *RecipeParser {
+	return &RecipeParser{client: client}
+}
+
+// Parse turns free recipe text into a validated recipe aggregate
+func (p *RecipeParser) Parse(ctx context.Context, recipeID, text string) (*domain.Recipe, error) {
+	resp, err := p.client.Chat(ctx, ChatRequest{
+		Messages: []ChatMessage{
+			{Role: "system", Content: recipeSystemPrompt},
+			{Role: "user", Content: text},
+		},
+		Format: "json",
+		Options: map[string]any{
+			"temperature": 0,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out recipeOutput
+	if err := json.Unmarshal([]byte(resp.Message.Content), &out); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidModelOutput, err)
+	}
+
+	recipe, err := out.toDomain(recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidModelOutput, err)
+	}
+
+	return recipe, nil
+}
+
+// toDomain validates the model output through the domain constructors
+func (o recipeOutput) toDomain(recipeID string) (*domain.Recipe, error) {
+	ingredients := make([]domain.Ingredient, 0, len(o.Ingredients))
+	for i, in := range o.Ingredients {
+		ingredient, err := domain.NewIngredient(domain.IngredientParams{
+			Quantity:    in.Quantity,
+			Unit:        in.Unit,
+			Name:        in.Name,
+			Preparation: in.Preparation,
+			Optional:    in.Optional,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ingredient %d: %w", i+1, err)
+		}
+		ingredients = append(ingredients, *ingredient)
+	}
+
+	steps := make([]domain.Step, 0, len(o.Steps))
+	for i, in := range o.Steps {
+		var temperature *domain.Temperature
+		if in.Temperature != nil {
+			t, err := domain.NewTemperature(in.Temperature.Value, domain.TemperatureUnit(in.Temperature.Unit))
+			if err != nil {
+				return nil, fmt.Errorf("step %d: %w", i+1, err)
+			}
+			temperature = t
+		}
+
+		step, err := domain.NewStep(domain.StepParams{
+			Text:        in.Text,
+			Duration:    minutes(in.DurationMinutes),
+			Temperature: temperature,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+		steps = append(steps, *step)
+	}
+
+	return domain.NewRecipe(domain.RecipeParams{
+		ID:          recipeID,
+		Title:       o.Title,
+		Servings:    o.Servings,
+		PrepTime:    minutes(o.PrepTimeMinutes),
+		CookTime:    minutes(o.CookTimeMinutes),
+		TotalTime:   minutes(o.TotalTimeMinutes),
+		Yield:       o.Yield,
+		Tags:        o.Tags,
+		Ingredients: ingredients,
+		Steps:       steps,
+	})
+}
+
+func minutes(n int) time.Duration {
+	return time.Duration(n) * time.Minute
+}
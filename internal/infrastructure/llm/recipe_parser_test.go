@@ -0,0 +1,120 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/llm"
+	"testing"
+	"time"
+)
+
+// newChatServer returns a fake Ollama server answering /api/chat with content
+func newChatServer(t *testing.T, content string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req llm.ChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.Format != "json" {
+			t.Errorf("Format = %q, want json", req.Format)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"message": map[string]string{"role": "assistant", "content": content},
+			"done":    true,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRecipeParser_Parse_Success(t *testing.T) {
+	server := newChatServer(t, `{
+		"title": " Pancakes ",
+		"servings": 4,
+		"prep_time_minutes": 10,
+		"cook_time_minutes": 15,
+		"yield": "12 pancakes",
+		"tags": ["Breakfast", ""],
+		"ingredients": [
+			{"quantity": 200, "unit": "g", "name": "flour"},
+			{"quantity": 0, "unit": "", "name": "butter", "preparation": "melted", "optional": true}
+		],
+		"steps": [
+			{"text": "Mix everything", "duration_minutes": 5},
+			{"text": "Fry in a pan", "temperature": {"value": 180, "unit": "C"}}
+		]
+	}`)
+
+	parser := llm.NewRecipeParser(llm.NewOllamaClient(llm.OllamaConfig{BaseURL: server.URL, Model: "test"}))
+
+	recipe, err := parser.Parse(context.Background(), "recipe-1", "Pancakes: flour, eggs. Mix and fry.")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if recipe.ID() != "recipe-1" {
+		t.Errorf("ID() = %q, want %q", recipe.ID(), "recipe-1")
+	}
+	if recipe.Title() != "Pancakes" {
+		t.Errorf("Title() = %q, want %q", recipe.Title(), "Pancakes")
+	}
+	if recipe.Servings() != 4 {
+		t.Errorf("Servings() = %d, want 4", recipe.Servings())
+	}
+	if recipe.TotalTime() != 25*time.Minute {
+		t.Errorf("TotalTime() = %v, want 25m", recipe.TotalTime())
+	}
+	if tags := recipe.Tags(); len(tags) != 1 || tags[0] != "breakfast" {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+
+	ingredients := recipe.Ingredients()
+	if len(ingredients) != 2 || !ingredients[1].Optional() || ingredients[1].Preparation() != "melted" {
+		t.Errorf("unexpected ingredients: %v", ingredients)
+	}
+
+	steps := recipe.Steps()
+	if len(steps) != 2 || steps[0].Duration() != 5*time.Minute {
+		t.Fatalf("unexpected steps: %v", steps)
+	}
+	if temp := steps[1].Temperature(); temp == nil || temp.Unit() != domain.Celsius {
+		t.Errorf("expected step 2 temperature in Celsius, got %v", temp)
+	}
+}
+
+func TestRecipeParser_Parse_InvalidOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    error
+	}{
+		{name: "not json", content: "Sure! Here is your recipe"},
+		{name: "missing title", content: `{"ingredients":[{"name":"a"}],"steps":[{"text":"b"}]}`, want: domain.ErrRecipeTitleEmpty},
+		{name: "no ingredients", content: `{"title":"x","steps":[{"text":"b"}]}`, want: domain.ErrRecipeNoIngredients},
+		{name: "no steps", content: `{"title":"x","ingredients":[{"name":"a"}]}`, want: domain.ErrRecipeNoSteps},
+		{name: "blank ingredient", content: `{"title":"x","ingredients":[{"name":" "}],"steps":[{"text":"b"}]}`, want: domain.ErrIngredientNameEmpty},
+		{name: "bad temperature", content: `{"title":"x","ingredients":[{"name":"a"}],"steps":[{"text":"b","temperature":{"value":1,"unit":"K"}}]}`, want: domain.ErrTemperatureUnitInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newChatServer(t, tt.content)
+			parser := llm.NewRecipeParser(llm.NewOllamaClient(llm.OllamaConfig{BaseURL: server.URL}))
+
+			_, err := parser.Parse(context.Background(), "recipe-1", "text")
+			if !errors.Is(err, llm.ErrInvalidModelOutput) {
+				t.Fatalf("expected ErrInvalidModelOutput, got %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the public Notion API endpoint
+	DefaultBaseURL = "https://api.notion.com"
+	// APIVersion is the Notion API version this client speaks
+	APIVersion = "2022-06-28"
+	// DefaultMaxRetries is how often a rate-limited request is retried
+	DefaultMaxRetries = 3
+	// DefaultRetryAfter is used when a 429 response has no usable Retry-After header
+	DefaultRetryAfter = time.Second
+	// DefaultTimeout is the default timeout for a single Notion request
+	DefaultTimeout = 30 * time.Second
+)
+
+// ErrRateLimited is returned when Notion keeps rate limiting after all retries
+var ErrRateLimited = errors.New("notion rate limit exceeded")
+
+// ClientConfig holds configuration for the Notion client
+type ClientConfig struct {
+	Token      string
+	BaseURL    string
+	MaxRetries int
+	Timeout    time.Duration
+}
+
+// Client talks to the Notion REST API
+type Client struct {
+	token      string
+	baseURL    string
+	maxRetries int
+	httpClient *http.Client
+}
+
+// NewClient creates a new Notion client
+func NewClient(cfg ClientConfig) *Client {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	maxRetries := cfg.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	} else if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Client{
+		token:      cfg.Token,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		maxRetries: maxRetries,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// APIError is returned when Notion responds with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("notion api error (status %d, code %s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Retryable reports whether the request may succeed later
+// Client errors such as a missing database or invalid property are permanent
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// CreatePage creates a page in a database
+func (c *Client) CreatePage(ctx context.Context, req CreatePageRequest) (*Page, error) {
+	var page Page
+	if err := c.do(ctx, http.MethodPost, "/v1/pages", req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// AppendBlocks appends child blocks to an existing page or block
+func (c *Client) AppendBlocks(ctx context.Context, blockID string, blocks []Block) error {
+	path := "/v1/blocks/" + blockID + "/children"
+	return c.do(ctx, http.MethodPatch, path, AppendBlocksRequest{Children: blocks}, nil)
+}
+
+// ArchivePage moves a page to the trash
+func (c *Client) ArchivePage(ctx context.Context, pageID string) error {
+	return c.do(ctx, http.MethodPatch, "/v1/pages/"+pageID, UpdatePageRequest{Archived: true}, nil)
+}
+
+// do sends a request, retrying when Notion answers 429 Too Many Requests
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := c.send(ctx, method, path, payload, out)
+		if !errors.Is(err, ErrRateLimited) {
+			return err
+		}
+
+		if attempt >= c.maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("waiting for rate limit: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// send performs a single HTTP round trip
+// When rate limited it returns ErrRateLimited and how long to wait
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, out any) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Notion-Version", APIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("notion request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return parseRetryAfter(resp.Header.Get("Retry-After")), ErrRateLimited
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, readAPIError(resp)
+	}
+
+	if out == nil {
+		return 0, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return 0, fmt.Errorf("failed to decode notion response: %w", err)
+	}
+
+	return 0, nil
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || seconds < 0 {
+		return DefaultRetryAfter
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// readAPIError decodes a Notion error object
+func readAPIError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return apiErr
+	}
+
+	var payload struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &payload) == nil {
+		apiErr.Code = payload.Code
+		apiErr.Message = payload.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(raw))
+	}
+
+	return apiErr
+}
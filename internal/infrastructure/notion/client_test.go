@@ -0,0 +1,102 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"recipe-processor/internal/infrastructure/notion"
+	"testing"
+)
+
+func TestClient_CreatePage_SendsAuthHeaders(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	client := notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()})
+
+	page, err := client.CreatePage(context.Background(), notion.CreatePageRequest{
+		Parent:     notion.Parent{DatabaseID: "db-1"},
+		Properties: map[string]notion.PropertyValue{"Name": {Title: notion.NewRichText("Soup")}},
+	})
+	if err != nil {
+		t.Fatalf("CreatePage() error = %v", err)
+	}
+
+	if page.ID == "" || page.URL == "" {
+		t.Errorf("expected page ID and URL, got %+v", page)
+	}
+
+	req := api.Requests()[0]
+	if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+	}
+	if got := req.Header.Get("Notion-Version"); got != notion.APIVersion {
+		t.Errorf("Notion-Version = %q, want %q", got, notion.APIVersion)
+	}
+}
+
+func TestClient_CreatePage_RetriesAfterRateLimit(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	api.RateLimit(2, "0")
+	client := notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()})
+
+	_, err := client.CreatePage(context.Background(), notion.CreatePageRequest{
+		Parent: notion.Parent{DatabaseID: "db-1"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePage() error = %v", err)
+	}
+
+	if n := len(api.Requests()); n != 3 {
+		t.Errorf("expected 3 requests (2 rate limited + 1 success), got %d", n)
+	}
+}
+
+func TestClient_CreatePage_GivesUpAfterMaxRetries(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	api.RateLimit(10, "0")
+	client := notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL(), MaxRetries: 2})
+
+	_, err := client.CreatePage(context.Background(), notion.CreatePageRequest{
+		Parent: notion.Parent{DatabaseID: "db-1"},
+	})
+	if !errors.Is(err, notion.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	if n := len(api.Requests()); n != 3 {
+		t.Errorf("expected 3 requests (1 + 2 retries), got %d", n)
+	}
+}
+
+func TestClient_CreatePage_RespectsContextWhileWaiting(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	api.RateLimit(1, "60")
+	client := notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	api.OnRequest(func(*http.Request) { cancel() })
+
+	_, err := client.CreatePage(ctx, notion.CreatePageRequest{
+		Parent: notion.Parent{DatabaseID: "db-1"},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClient_CreatePage_APIError(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	client := notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()})
+
+	_, err := client.CreatePage(context.Background(), notion.CreatePageRequest{})
+
+	var apiErr *notion.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != "validation_error" {
+		t.Errorf("unexpected api error: %+v", apiErr)
+	}
+	if apiErr.Retryable() {
+		t.Error("expected validation error not to be retryable")
+	}
+}
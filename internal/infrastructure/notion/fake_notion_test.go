@@ -0,0 +1,206 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"recipe-processor/internal/infrastructure/notion"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordedRequest is a request received by the fake Notion API
+type recordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// fakeNotionAPI is a minimal local stand-in for the Notion REST API
+type fakeNotionAPI struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	requests    []recordedRequest
+	pages       map[string]*notion.CreatePageRequest
+	archived    map[string]bool
+	failAppends bool
+	rateLimited int
+	retryAfter  string
+	onRequest   func(*http.Request)
+}
+
+func newFakeNotionAPI(t *testing.T) *fakeNotionAPI {
+	t.Helper()
+
+	api := &fakeNotionAPI{pages: make(map[string]*notion.CreatePageRequest), archived: make(map[string]bool)}
+	api.server = httptest.NewServer(http.HandlerFunc(api.handle))
+	t.Cleanup(api.server.Close)
+
+	return api
+}
+
+func (a *fakeNotionAPI) URL() string {
+	return a.server.URL
+}
+
+// RateLimit makes the next n requests fail with 429 and the given Retry-After
+func (a *fakeNotionAPI) RateLimit(n int, retryAfter string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.rateLimited = n
+	a.retryAfter = retryAfter
+}
+
+// FailAppends makes appending blocks fail with 502 Bad Gateway
+func (a *fakeNotionAPI) FailAppends() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.failAppends = true
+}
+
+// Archived reports whether a page was archived
+func (a *fakeNotionAPI) Archived(id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.archived[id]
+}
+
+// OnRequest registers a hook invoked for every incoming request
+func (a *fakeNotionAPI) OnRequest(fn func(*http.Request)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onRequest = fn
+}
+
+func (a *fakeNotionAPI) Requests() []recordedRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]recordedRequest(nil), a.requests...)
+}
+
+// Page returns the stored page including all appended children
+func (a *fakeNotionAPI) Page(id string) *notion.CreatePageRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pages[id]
+}
+
+func (a *fakeNotionAPI) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	a.mu.Lock()
+	a.requests = append(a.requests, recordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	hook := a.onRequest
+	limited := a.rateLimited > 0
+	if limited {
+		a.rateLimited--
+	}
+	retryAfter := a.retryAfter
+	a.mu.Unlock()
+
+	if hook != nil {
+		hook(r)
+	}
+
+	if limited {
+		w.Header().Set("Retry-After", retryAfter)
+		writeJSON(w, http.StatusTooManyRequests, map[string]string{"code": "rate_limited", "message": "slow down"})
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/pages":
+		a.createPage(w, body)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/v1/pages/"):
+		a.updatePage(w, strings.TrimPrefix(r.URL.Path, "/v1/pages/"), body)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/v1/blocks/"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/blocks/"), "/children")
+		a.appendBlocks(w, id, body)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"code": "object_not_found", "message": r.URL.Path})
+	}
+}
+
+func (a *fakeNotionAPI) createPage(w http.ResponseWriter, body []byte) {
+	var req notion.CreatePageRequest
+	if err := json.Unmarshal(body, &req); err != nil || req.Parent.DatabaseID == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"code": "validation_error", "message": "parent required"})
+		return
+	}
+	if len(req.Children) > 100 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"code": "validation_error", "message": "too many children"})
+		return
+	}
+
+	a.mu.Lock()
+	id := fmt.Sprintf("page-%d", len(a.pages)+1)
+	a.pages[id] = &req
+	a.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, notion.Page{ID: id, URL: "https://www.notion.so/" + id})
+}
+
+func (a *fakeNotionAPI) appendBlocks(w http.ResponseWriter, id string, body []byte) {
+	var req notion.AppendBlocksRequest
+	if err := json.Unmarshal(body, &req); err != nil || len(req.Children) > 100 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"code": "validation_error", "message": "invalid children"})
+		return
+	}
+
+	a.mu.Lock()
+	if a.failAppends {
+		a.mu.Unlock()
+		writeJSON(w, http.StatusBadGateway, map[string]string{"code": "bad_gateway", "message": "upstream failed"})
+		return
+	}
+	page, ok := a.pages[id]
+	if ok {
+		page.Children = append(page.Children, req.Children...)
+	}
+	a.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"code": "object_not_found", "message": id})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"object": "list"})
+}
+
+func (a *fakeNotionAPI) updatePage(w http.ResponseWriter, id string, body []byte) {
+	var req notion.UpdatePageRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"code": "validation_error", "message": "invalid body"})
+		return
+	}
+
+	a.mu.Lock()
+	_, ok := a.pages[id]
+	if ok {
+		a.archived[id] = req.Archived
+	}
+	a.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"code": "object_not_found", "message": id})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, notion.Page{ID: id, URL: "https://www.notion.so/" + id})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
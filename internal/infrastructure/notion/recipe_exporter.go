@@ -0,0 +1,213 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"recipe-processor/internal/domain"
+	"strings"
+)
+
+// Database property names the exporter writes to
+// The target database must define them with the matching types
+const (
+	PropertyName      = "Name"             // title
+	PropertyServings  = "Servings"         // number
+	PropertyTags      = "Tags"             // multi-select
+	PropertyPrepTime  = "Prep Time (min)"  // number
+	PropertyCookTime  = "Cook Time (min)"  // number
+	PropertyTotalTime = "Total Time (min)" // number
+
+	// maxBlocksPerRequest is the Notion limit for children in a single request
+	maxBlocksPerRequest = 100
+	// maxTextLength is the Notion limit for the content of a rich text object
+	maxTextLength = 2000
+)
+
+// ErrTextTooLong is returned by Validate for recipes Notion would reject
+var ErrTextTooLong = errors.New("text exceeds the notion limit")
+
+// RecipeExporter writes parsed recipes as pages into a Notion database
+type RecipeExporter struct {
+	client     *Client
+	databaseID string
+}
+
+// NewRecipeExporter creates a new Notion recipe exporter
+func NewRecipeExporter(client *Client, databaseID string) *RecipeExporter {
+	return &RecipeExporter{
+		client:     client,
+		databaseID: databaseID,
+	}
+}
+
+// Export creates a database page for the recipe
+// Recipes with more blocks than fit into one request are appended to the page
+// afterwards; if that fails the half-written page is reported with a
+// *domain.IncompleteExportError
+func (e *RecipeExporter) Export(ctx context.Context, recipe *domain.Recipe) (*domain.ExportReference, error) {
+	blocks := recipeBlocks(recipe)
+
+	first := blocks
+	if len(first) > maxBlocksPerRequest {
+		first = first[:maxBlocksPerRequest]
+	}
+
+	page, err := e.client.CreatePage(ctx, CreatePageRequest{
+		Parent:     Parent{DatabaseID: e.databaseID},
+		Properties: recipeProperties(recipe),
+		Children:   first,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notion page: %w", err)
+	}
+
+	for rest := blocks[len(first):]; len(rest) > 0; {
+		n := min(len(rest), maxBlocksPerRequest)
+		if err := e.client.AppendBlocks(ctx, page.ID, rest[:n]); err != nil {
+			return nil, &domain.IncompleteExportError{
+				Reference: domain.ExportReference{PageID: page.ID, URL: page.URL},
+				Err:       fmt.Errorf("failed to append blocks to page %s: %w", page.ID, err),
+			}
+		}
+		rest = rest[n:]
+	}
+
+	return &domain.ExportReference{
+		PageID: page.ID,
+		URL:    page.URL,
+	}, nil
+}
+
+// Archive moves an exported page to the trash
+// Pages that no longer exist count as archived
+func (e *RecipeExporter) Archive(ctx context.Context, ref domain.ExportReference) error {
+	err := e.client.ArchivePage(ctx, ref.PageID)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to archive notion page %s: %w", ref.PageID, err)
+	}
+	return nil
+}
+
+// Validate checks that Notion accepts every text block of the recipe's page
+// Titles are short enough already, see domain.NewRecipe
+func (e *RecipeExporter) Validate(ctx context.Context, recipe *domain.Recipe) error {
+	for _, block := range recipeBlocks(recipe) {
+		for _, content := range []*TextBlockContent{block.Heading2, block.Paragraph, block.BulletedListItem, block.NumberedListItem} {
+			if content == nil {
+				continue
+			}
+			for _, text := range content.RichText {
+				if len([]rune(text.Text.Content)) > maxTextLength {
+					return fmt.Errorf("%w: %s %q has more than %d characters",
+						ErrTextTooLong, block.Type, truncate(text.Text.Content, 40), maxTextLength)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// recipeProperties maps recipe metadata to database properties
+func recipeProperties(recipe *domain.Recipe) map[string]PropertyValue {
+	props := map[string]PropertyValue{
+		PropertyName: {Title: NewRichText(recipe.Title())},
+	}
+
+	if recipe.Servings() > 0 {
+		props[PropertyServings] = PropertyValue{Number: number(float64(recipe.Servings()))}
+	}
+
+	if recipe.PrepTime() > 0 {
+		props[PropertyPrepTime] = PropertyValue{Number: number(recipe.PrepTime().Minutes())}
+	}
+
+	if recipe.CookTime() > 0 {
+		props[PropertyCookTime] = PropertyValue{Number: number(recipe.CookTime().Minutes())}
+	}
+
+	if recipe.TotalTime() > 0 {
+		props[PropertyTotalTime] = PropertyValue{Number: number(recipe.TotalTime().Minutes())}
+	}
+
+	if tags := recipe.Tags(); len(tags) > 0 {
+		options := make([]SelectOption, len(tags))
+		for i, tag := range tags {
+			options[i] = SelectOption{Name: tag}
+		}
+		props[PropertyTags] = PropertyValue{MultiSelect: options}
+	}
+
+	return props
+}
+
+// recipeBlocks renders ingredients and steps as page content
+func recipeBlocks(recipe *domain.Recipe) []Block {
+	ingredients := recipe.Ingredients()
+	steps := recipe.Steps()
+	blocks := make([]Block, 0, len(ingredients)+len(steps)+4)
+
+	if details := recipeDetails(recipe); details != "" {
+		blocks = append(blocks, NewParagraph(details))
+	}
+
+	blocks = append(blocks, NewHeading2("Ingredients"))
+	for _, ingredient := range ingredients {
+		blocks = append(blocks, NewBulletedListItem(ingredient.String()))
+	}
+
+	blocks = append(blocks, NewHeading2("Steps"))
+	for _, step := range steps {
+		blocks = append(blocks, NewNumberedListItem(stepText(step)))
+	}
+
+	return blocks
+}
+
+// recipeDetails renders yield and source as a single line
+func recipeDetails(recipe *domain.Recipe) string {
+	var parts []string
+	if recipe.Yield() != "" {
+		parts = append(parts, "Yield: "+recipe.Yield())
+	}
+	if recipe.Source() != "" {
+		parts = append(parts, "Source: "+recipe.Source())
+	}
+	return strings.Join(parts, " · ")
+}
+
+// stepText renders a step with its duration and temperature, if any
+func stepText(step domain.Step) string {
+	var extras []string
+	if step.Duration() > 0 {
+		extras = append(extras, fmt.Sprintf("%g min", step.Duration().Minutes()))
+	}
+	if t := step.Temperature(); t != nil {
+		extras = append(extras, t.String())
+	}
+
+	if len(extras) == 0 {
+		return step.Text()
+	}
+	return fmt.Sprintf("%s (%s)", step.Text(), strings.Join(extras, ", "))
+}
+
+func number(v float64) *float64 {
+	return &v
+}
+
+// truncate shortens s to n runes for error messages
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "…"
+}
@@ -0,0 +1,234 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/notion"
+	"recipe-processor/internal/shared/events"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newRecipe builds a recipe aggregate with the given ingredient and step texts
+func newRecipe(t *testing.T, params domain.RecipeParams, ingredients, steps []string) *domain.Recipe {
+	t.Helper()
+
+	for _, name := range ingredients {
+		ingredient, err := domain.NewIngredient(domain.IngredientParams{Name: name})
+		if err != nil {
+			t.Fatalf("NewIngredient() error = %v", err)
+		}
+		params.Ingredients = append(params.Ingredients, *ingredient)
+	}
+
+	for _, text := range steps {
+		step, err := domain.NewStep(domain.StepParams{Text: text})
+		if err != nil {
+			t.Fatalf("NewStep() error = %v", err)
+		}
+		params.Steps = append(params.Steps, *step)
+	}
+
+	params.ID = "recipe-1"
+	recipe, err := domain.NewRecipe(params)
+	if err != nil {
+		t.Fatalf("NewRecipe() error = %v", err)
+	}
+
+	return recipe
+}
+
+func TestRecipeExporter_Export_MapsPropertiesAndBlocks(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	exporter := notion.NewRecipeExporter(notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()}), "db-1")
+
+	flour, _ := domain.NewIngredient(domain.IngredientParams{Quantity: 200, Unit: "g", Name: "flour", Preparation: "sifted"})
+	temp, _ := domain.NewTemperature(180, domain.Celsius)
+	fry, _ := domain.NewStep(domain.StepParams{Text: "Fry", Duration: 5 * time.Minute, Temperature: temp})
+
+	recipe := newRecipe(t, domain.RecipeParams{
+		Title:       "Pancakes",
+		Servings:    4,
+		PrepTime:    10 * time.Minute,
+		CookTime:    20 * time.Minute,
+		Yield:       "12 pancakes",
+		Tags:        []string{"breakfast", "sweet"},
+		Ingredients: []domain.Ingredient{*flour},
+		Steps:       []domain.Step{*fry},
+	}, []string{"eggs"}, []string{"Serve"})
+
+	ref, err := exporter.Export(context.Background(), recipe)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if ref.PageID != "page-1" || ref.URL != "https://www.notion.so/page-1" {
+		t.Errorf("unexpected reference: %+v", ref)
+	}
+
+	page := api.Page("page-1")
+	if page.Parent.DatabaseID != "db-1" {
+		t.Errorf("DatabaseID = %q, want db-1", page.Parent.DatabaseID)
+	}
+
+	if got := page.Properties[notion.PropertyName].Title[0].Text.Content; got != "Pancakes" {
+		t.Errorf("title property = %q, want Pancakes", got)
+	}
+	if got := *page.Properties[notion.PropertyServings].Number; got != 4 {
+		t.Errorf("servings property = %v, want 4", got)
+	}
+	if got := *page.Properties[notion.PropertyPrepTime].Number; got != 10 {
+		t.Errorf("prep time property = %v, want 10", got)
+	}
+	if got := *page.Properties[notion.PropertyCookTime].Number; got != 20 {
+		t.Errorf("cook time property = %v, want 20", got)
+	}
+	if got := *page.Properties[notion.PropertyTotalTime].Number; got != 30 {
+		t.Errorf("total time property = %v, want 30", got)
+	}
+	if got := page.Properties[notion.PropertyTags].MultiSelect; len(got) != 2 || got[1].Name != "sweet" {
+		t.Errorf("unexpected tags property: %+v", got)
+	}
+
+	wantBlocks := []struct{ kind, text string }{
+		{"paragraph", "Yield: 12 pancakes"},
+		{"heading_2", "Ingredients"},
+		{"bulleted_list_item", "200 g flour, sifted"},
+		{"bulleted_list_item", "eggs"},
+		{"heading_2", "Steps"},
+		{"numbered_list_item", "Fry (5 min, 180°C)"},
+		{"numbered_list_item", "Serve"},
+	}
+	if len(page.Children) != len(wantBlocks) {
+		t.Fatalf("expected %d blocks, got %d", len(wantBlocks), len(page.Children))
+	}
+	for i, want := range wantBlocks {
+		if page.Children[i].Type != want.kind {
+			t.Errorf("block %d type = %q, want %q", i, page.Children[i].Type, want.kind)
+		}
+		if got := blockText(page.Children[i]); got != want.text {
+			t.Errorf("block %d text = %q, want %q", i, got, want.text)
+		}
+	}
+}
+
+func TestRecipeExporter_Export_OmitsUnknownValues(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	exporter := notion.NewRecipeExporter(notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()}), "db-1")
+
+	recipe := newRecipe(t, domain.RecipeParams{Title: "Toast"}, []string{"bread"}, []string{"Toast it"})
+
+	if _, err := exporter.Export(context.Background(), recipe); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	page := api.Page("page-1")
+	if len(page.Properties) != 1 {
+		t.Errorf("expected only the title property, got %v", page.Properties)
+	}
+	if page.Children[0].Type != "heading_2" {
+		t.Errorf("expected no details paragraph, got %q block first", page.Children[0].Type)
+	}
+}
+
+func TestRecipeExporter_Export_AppendsBlocksBeyondLimit(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	exporter := notion.NewRecipeExporter(notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()}), "db-1")
+
+	ingredients := make([]string, 150)
+	for i := range ingredients {
+		ingredients[i] = fmt.Sprintf("ingredient %d", i)
+	}
+	recipe := newRecipe(t, domain.RecipeParams{Title: "Big batch"}, ingredients, []string{"Combine"})
+
+	if _, err := exporter.Export(context.Background(), recipe); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if got := len(api.Page("page-1").Children); got != 153 {
+		t.Errorf("expected 153 blocks on page, got %d", got)
+	}
+	if got := len(api.Requests()); got != 2 {
+		t.Errorf("expected create + append requests, got %d", got)
+	}
+}
+
+func TestRecipeExporter_Export_FailedAppendReportsIncompletePage(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	api.FailAppends()
+	exporter := notion.NewRecipeExporter(notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()}), "db-1")
+
+	ingredients := make([]string, 150)
+	for i := range ingredients {
+		ingredients[i] = fmt.Sprintf("ingredient %d", i)
+	}
+	recipe := newRecipe(t, domain.RecipeParams{Title: "Big batch"}, ingredients, []string{"Combine"})
+
+	_, err := exporter.Export(context.Background(), recipe)
+
+	var incomplete *domain.IncompleteExportError
+	if !errors.As(err, &incomplete) {
+		t.Fatalf("expected *domain.IncompleteExportError, got %v", err)
+	}
+	if incomplete.Reference.PageID != "page-1" || incomplete.Reference.URL != "https://www.notion.so/page-1" {
+		t.Errorf("unexpected partial page %+v", incomplete.Reference)
+	}
+	if !events.IsRetryable(err) {
+		t.Error("expected a 502 while appending to stay retryable")
+	}
+}
+
+func TestRecipeExporter_Archive(t *testing.T) {
+	api := newFakeNotionAPI(t)
+	exporter := notion.NewRecipeExporter(notion.NewClient(notion.ClientConfig{Token: "secret", BaseURL: api.URL()}), "db-1")
+
+	ref, err := exporter.Export(context.Background(), newRecipe(t, domain.RecipeParams{Title: "Soup"}, []string{"water"}, []string{"Boil"}))
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if err := exporter.Archive(context.Background(), *ref); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if !api.Archived(ref.PageID) {
+		t.Errorf("expected page %s to be archived", ref.PageID)
+	}
+
+	if err := exporter.Archive(context.Background(), domain.ExportReference{PageID: "missing"}); err != nil {
+		t.Errorf("expected a missing page to count as archived, got %v", err)
+	}
+}
+
+func TestRecipeExporter_Validate_RejectsTextsOverLimit(t *testing.T) {
+	exporter := notion.NewRecipeExporter(notion.NewClient(notion.ClientConfig{Token: "secret"}), "db-1")
+
+	ok := newRecipe(t, domain.RecipeParams{Title: "Soup"}, []string{"water"}, []string{strings.Repeat("a", 2000)})
+	if err := exporter.Validate(context.Background(), ok); err != nil {
+		t.Errorf("expected 2000 characters to pass, got %v", err)
+	}
+
+	long := newRecipe(t, domain.RecipeParams{Title: "Soup"}, []string{"water"}, []string{strings.Repeat("a", 2001)})
+	if err := exporter.Validate(context.Background(), long); !errors.Is(err, notion.ErrTextTooLong) {
+		t.Errorf("expected ErrTextTooLong, got %v", err)
+	}
+}
+
+func blockText(b notion.Block) string {
+	var content *notion.TextBlockContent
+	switch {
+	case b.Paragraph != nil:
+		content = b.Paragraph
+	case b.Heading2 != nil:
+		content = b.Heading2
+	case b.BulletedListItem != nil:
+		content = b.BulletedListItem
+	case b.NumberedListItem != nil:
+		content = b.NumberedListItem
+	default:
+		return ""
+	}
+	return content.RichText[0].Text.Content
+}
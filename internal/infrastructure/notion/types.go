@@ -0,0 +1,94 @@
+package notion
+
+// Parent identifies the database a page is created in
+type Parent struct {
+	DatabaseID string `json:"database_id"`
+}
+
+// Text is the plain text content of a rich text object
+type Text struct {
+	Content string `json:"content"`
+}
+
+// RichText is a Notion rich text object
+type RichText struct {
+	Type string `json:"type"`
+	Text Text   `json:"text"`
+}
+
+// SelectOption is an option of a select or multi-select property
+type SelectOption struct {
+	Name string `json:"name"`
+}
+
+// PropertyValue is the value of a single database property
+// Only the field matching the property type should be set
+type PropertyValue struct {
+	Title       []RichText     `json:"title,omitempty"`
+	RichText    []RichText     `json:"rich_text,omitempty"`
+	Number      *float64       `json:"number,omitempty"`
+	MultiSelect []SelectOption `json:"multi_select,omitempty"`
+}
+
+// TextBlockContent is the content of a text-based block
+type TextBlockContent struct {
+	RichText []RichText `json:"rich_text"`
+}
+
+// Block is a Notion content block
+type Block struct {
+	Object           string            `json:"object"`
+	Type             string            `json:"type"`
+	Heading2         *TextBlockContent `json:"heading_2,omitempty"`
+	Paragraph        *TextBlockContent `json:"paragraph,omitempty"`
+	BulletedListItem *TextBlockContent `json:"bulleted_list_item,omitempty"`
+	NumberedListItem *TextBlockContent `json:"numbered_list_item,omitempty"`
+}
+
+// CreatePageRequest is the body of POST /v1/pages
+type CreatePageRequest struct {
+	Parent     Parent                   `json:"parent"`
+	Properties map[string]PropertyValue `json:"properties"`
+	Children   []Block                  `json:"children,omitempty"`
+}
+
+// AppendBlocksRequest is the body of PATCH /v1/blocks/{id}/children
+type AppendBlocksRequest struct {
+	Children []Block `json:"children"`
+}
+
+// UpdatePageRequest is the body of PATCH /v1/pages/{id}
+type UpdatePageRequest struct {
+	Archived bool `json:"archived"`
+}
+
+// Page is the subset of a Notion page object used by this package
+type Page struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// NewRichText creates a plain rich text slice from content
+func NewRichText(content string) []RichText {
+	return []RichText{{Type: "text", Text: Text{Content: content}}}
+}
+
+// NewHeading2 creates a level 2 heading block
+func NewHeading2(content string) Block {
+	return Block{Object: "block", Type: "heading_2", Heading2: &TextBlockContent{RichText: NewRichText(content)}}
+}
+
+// NewParagraph creates a paragraph block
+func NewParagraph(content string) Block {
+	return Block{Object: "block", Type: "paragraph", Paragraph: &TextBlockContent{RichText: NewRichText(content)}}
+}
+
+// NewBulletedListItem creates a bulleted list item block
+func NewBulletedListItem(content string) Block {
+	return Block{Object: "block", Type: "bulleted_list_item", BulletedListItem: &TextBlockContent{RichText: NewRichText(content)}}
+}
+
+// NewNumberedListItem creates a numbered list item block
+func NewNumberedListItem(content string) Block {
+	return Block{Object: "block", Type: "numbered_list_item", NumberedListItem: &TextBlockContent{RichText: NewRichText(content)}}
+}
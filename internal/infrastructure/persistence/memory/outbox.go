@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"recipe-processor/internal/shared/outbox"
+	"sort"
+	"sync"
+	"time"
+)
+
+// outboxEntry is a stored message and its delivery state
+type outboxEntry struct {
+	message     outbox.Message
+	availableAt time.Time
+	parked      bool
+	deliveredAt time.Time
+}
+
+// Outbox is an in-memory outbox store
+// Messages are added by RecipeRepository.SaveWithEvents
+type Outbox struct {
+	mu      sync.Mutex
+	entries map[string]*outboxEntry
+}
+
+// newOutbox creates an empty in-memory outbox
+func newOutbox() *Outbox {
+	return &Outbox{entries: make(map[string]*outboxEntry)}
+}
+
+// add stores the messages as pending
+func (o *Outbox) add(messages []outbox.Message) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, msg := range messages {
+		o.entries[msg.ID] = &outboxEntry{message: msg, availableAt: msg.CreatedAt}
+	}
+}
+
+// Pending returns undelivered messages that are due at now, oldest first
+func (o *Outbox) Pending(ctx context.Context, now time.Time, limit int) ([]outbox.Message, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var pending []outbox.Message
+	for _, e := range o.entries {
+		if e.deliveredAt.IsZero() && !e.parked && !e.availableAt.After(now) {
+			pending = append(pending, e.message)
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	return pending, nil
+}
+
+// MarkDelivered records that all handlers processed the message
+func (o *Outbox) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if e, ok := o.entries[id]; ok {
+		e.deliveredAt = at
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt
+func (o *Outbox) MarkFailed(ctx context.Context, id string, reason string, retryAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if e, ok := o.entries[id]; ok {
+		e.message.Attempts++
+		e.message.LastError = reason
+		e.availableAt = retryAt
+		e.parked = retryAt.IsZero()
+	}
+	return nil
+}
+
+var _ outbox.Store = (*Outbox)(nil)
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/outbox"
+	"sync"
+)
+
+// RecipeRepository is an in-memory recipe repository
+// State is lost on restart; use it for tests and local development
+type RecipeRepository struct {
+	mu       sync.RWMutex
+	recipes  map[string]domain.RecipeLifecycleSnapshot
+	outbox   *Outbox
+	registry *events.Registry
+}
+
+// NewRecipeRepository creates a new in-memory recipe repository
+// registry encodes the events saved with SaveWithEvents
+func NewRecipeRepository(registry *events.Registry) *RecipeRepository {
+	return &RecipeRepository{
+		recipes:  make(map[string]domain.RecipeLifecycleSnapshot),
+		outbox:   newOutbox(),
+		registry: registry,
+	}
+}
+
+// Save inserts or replaces the lifecycle
+func (r *RecipeRepository) Save(ctx context.Context, lifecycle *domain.RecipeLifecycle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recipes[lifecycle.ID()] = lifecycle.Snapshot()
+	return nil
+}
+
+// SaveWithEvents inserts or replaces the lifecycle and enqueues the events
+func (r *RecipeRepository) SaveWithEvents(ctx context.Context, lifecycle *domain.RecipeLifecycle, evts ...events.Event) error {
+	messages, err := outbox.NewMessages(ctx, r.registry, evts...)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recipes[lifecycle.ID()] = lifecycle.Snapshot()
+	r.outbox.add(messages)
+	return nil
+}
+
+// Outbox returns the store holding events saved with SaveWithEvents
+func (r *RecipeRepository) Outbox() *Outbox {
+	return r.outbox
+}
+
+// FindByID returns the lifecycle with the given ID
+func (r *RecipeRepository) FindByID(ctx context.Context, id string) (*domain.RecipeLifecycle, error) {
+	r.mu.RLock()
+	snapshot, ok := r.recipes[id]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, recipe.ErrRecipeNotFound
+	}
+
+	return domain.RestoreRecipeLifecycle(snapshot)
+}
+
+var _ recipe.OutboxRepository = (*RecipeRepository)(nil)
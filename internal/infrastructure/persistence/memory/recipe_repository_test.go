@@ -0,0 +1,52 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/memory"
+	"testing"
+	"time"
+)
+
+func newRepository(t *testing.T) *memory.RecipeRepository {
+	t.Helper()
+
+	registry, err := recipe.NewEventRegistry()
+	if err != nil {
+		t.Fatalf("NewEventRegistry() error = %v", err)
+	}
+	return memory.NewRecipeRepository(registry)
+}
+
+func TestRecipeRepository_SaveAndFind(t *testing.T) {
+	repo := newRepository(t)
+	text, _ := domain.NewRecipeText("Pancakes")
+	lifecycle, _ := domain.NewRecipeLifecycle("recipe-1", text, time.Now())
+
+	if err := repo.Save(context.Background(), lifecycle); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Changes after saving must not leak into the stored copy
+	_ = lifecycle.StartParsing(time.Now())
+
+	found, err := repo.FindByID(context.Background(), "recipe-1")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+
+	if found.Status() != domain.StatusSubmitted {
+		t.Errorf("Status() = %q, want %q", found.Status(), domain.StatusSubmitted)
+	}
+}
+
+func TestRecipeRepository_FindByID_NotFound(t *testing.T) {
+	repo := newRepository(t)
+
+	_, err := repo.FindByID(context.Background(), "missing")
+	if !errors.Is(err, recipe.ErrRecipeNotFound) {
+		t.Fatalf("expected ErrRecipeNotFound, got %v", err)
+	}
+}
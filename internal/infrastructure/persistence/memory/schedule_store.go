@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"recipe-processor/internal/shared/scheduler"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ScheduleStore is an in-memory store of scheduled events
+// Unlike the SQLite store it loses its events when the process stops
+type ScheduleStore struct {
+	mu      sync.Mutex
+	events  map[string]scheduler.ScheduledEvent
+	claimed map[string]time.Time
+}
+
+// NewScheduleStore creates an empty in-memory schedule store
+func NewScheduleStore() *ScheduleStore {
+	return &ScheduleStore{
+		events:  make(map[string]scheduler.ScheduledEvent),
+		claimed: make(map[string]time.Time),
+	}
+}
+
+// Add stores a scheduled event
+func (s *ScheduleStore) Add(ctx context.Context, scheduled scheduler.ScheduledEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[scheduled.ID] = scheduled
+	return nil
+}
+
+// Claim returns events due at now that are not claimed, earliest first, and
+// claims them until now+lease
+func (s *ScheduleStore) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]scheduler.ScheduledEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []scheduler.ScheduledEvent
+	for id, scheduled := range s.events {
+		if !scheduled.DueAt.After(now) && !s.claimed[id].After(now) {
+			due = append(due, scheduled)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].DueAt.Before(due[j].DueAt)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	for _, scheduled := range due {
+		s.claimed[scheduled.ID] = now.Add(lease)
+	}
+
+	return due, nil
+}
+
+// List returns every scheduled event, claimed or not, earliest first
+func (s *ScheduleStore) List() []scheduler.ScheduledEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var scheduled []scheduler.ScheduledEvent
+	for _, e := range s.events {
+		scheduled = append(scheduled, e)
+	}
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].DueAt.Before(scheduled[j].DueAt)
+	})
+	return scheduled
+}
+
+// Remove deletes a scheduled event
+func (s *ScheduleStore) Remove(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.events[id]; !ok {
+		return scheduler.ErrNotFound
+	}
+	delete(s.events, id)
+	delete(s.claimed, id)
+	return nil
+}
+
+var _ scheduler.Store = (*ScheduleStore)(nil)
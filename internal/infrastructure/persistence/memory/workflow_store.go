@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"recipe-processor/internal/application/recipe"
+	"sync"
+)
+
+// WorkflowStore is an in-memory store of recipe workflows
+type WorkflowStore struct {
+	mu        sync.Mutex
+	workflows map[string]recipe.Workflow
+}
+
+// NewWorkflowStore creates an empty in-memory workflow store
+func NewWorkflowStore() *WorkflowStore {
+	return &WorkflowStore{workflows: make(map[string]recipe.Workflow)}
+}
+
+// Find returns a copy of the recipe's workflow
+func (s *WorkflowStore) Find(ctx context.Context, recipeID string) (*recipe.Workflow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[recipeID]
+	if !ok {
+		return nil, recipe.ErrWorkflowNotFound
+	}
+
+	return copyWorkflow(workflow), nil
+}
+
+// Save inserts or replaces the workflow if its version is the stored one
+func (s *WorkflowStore) Save(ctx context.Context, workflow *recipe.Workflow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.workflows[workflow.RecipeID]
+	if (ok && stored.Version != workflow.Version) || (!ok && workflow.Version != 0) {
+		return recipe.ErrWorkflowConflict
+	}
+
+	workflow.Version++
+	s.workflows[workflow.RecipeID] = *copyWorkflow(*workflow)
+	return nil
+}
+
+func copyWorkflow(workflow recipe.Workflow) *recipe.Workflow {
+	if workflow.Export != nil {
+		ref := *workflow.Export
+		workflow.Export = &ref
+	}
+	return &workflow
+}
+
+var _ recipe.WorkflowStore = (*WorkflowStore)(nil)
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	// Pure-Go SQLite driver, keeps CGO_ENABLED=0 builds working
+	_ "modernc.org/sqlite"
+)
+
+const driverName = "sqlite"
+
+// Open opens the SQLite database at path and applies pending migrations
+// Use ":memory:" for a throwaway database
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	dsn := "file:" + path +
+		"?_pragma=busy_timeout(5000)" +
+		"&_pragma=foreign_keys(1)" +
+		"&_pragma=journal_mode(WAL)" +
+		"&_txlock=immediate"
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY between
+	// our own goroutines and keeps ":memory:" databases shared
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
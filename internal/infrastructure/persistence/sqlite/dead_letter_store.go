@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+)
+
+// DeadLetterStore keeps dead letters in SQLite so they survive restarts
+type DeadLetterStore struct {
+	db       *sql.DB
+	registry *events.Registry
+}
+
+// NewDeadLetterStore creates a dead letter store backed by db
+// registry encodes added events and restores them when listing them
+func NewDeadLetterStore(db *sql.DB, registry *events.Registry) *DeadLetterStore {
+	return &DeadLetterStore{db: db, registry: registry}
+}
+
+// Add stores a dead letter
+func (s *DeadLetterStore) Add(ctx context.Context, letter events.DeadLetter) error {
+	payload, version, err := s.registry.Encode(letter.Event)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter %s: %w", letter.ID, err)
+	}
+	letter.Envelope.SchemaVersion = version
+
+	envelope, err := json.Marshal(letter.Envelope)
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope of dead letter %s: %w", letter.ID, err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO dead_letters (id, event_type, payload, envelope, handler, attempts, last_error, failed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		letter.ID, letter.Event.EventType(), string(payload), string(envelope), letter.Handler,
+		letter.Attempts, letter.LastError, formatTime(letter.FailedAt),
+	); err != nil {
+		return fmt.Errorf("failed to store dead letter %s: %w", letter.ID, err)
+	}
+
+	return nil
+}
+
+// List returns all dead letters, oldest first
+func (s *DeadLetterStore) List(ctx context.Context) ([]events.DeadLetter, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, event_type, payload, envelope, handler, attempts, last_error, failed_at
+		FROM dead_letters ORDER BY failed_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dead letters: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var letters []events.DeadLetter
+	for rows.Next() {
+		letter, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+
+	return letters, rows.Err()
+}
+
+// Remove deletes and returns the dead letter
+func (s *DeadLetterStore) Remove(ctx context.Context, id string) (events.DeadLetter, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return events.DeadLetter{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	letter, err := s.scan(tx.QueryRowContext(ctx, `
+		SELECT id, event_type, payload, envelope, handler, attempts, last_error, failed_at
+		FROM dead_letters WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return events.DeadLetter{}, events.ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return events.DeadLetter{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM dead_letters WHERE id = ?`, id); err != nil {
+		return events.DeadLetter{}, fmt.Errorf("failed to delete dead letter %s: %w", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return events.DeadLetter{}, fmt.Errorf("failed to commit dead letter removal: %w", err)
+	}
+
+	return letter, nil
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func (s *DeadLetterStore) scan(row scanner) (events.DeadLetter, error) {
+	var (
+		letter    events.DeadLetter
+		eventType string
+		payload   string
+		envelope  string
+		failedAt  string
+	)
+
+	if err := row.Scan(&letter.ID, &eventType, &payload, &envelope, &letter.Handler, &letter.Attempts, &letter.LastError, &failedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return letter, err
+		}
+		return letter, fmt.Errorf("failed to scan dead letter: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(envelope), &letter.Envelope); err != nil {
+		return letter, fmt.Errorf("dead letter %s: invalid envelope: %w", letter.ID, err)
+	}
+
+	event, err := s.registry.Decode(eventType, letter.Envelope.SchemaVersion, []byte(payload))
+	if err != nil {
+		return letter, fmt.Errorf("dead letter %s: %w", letter.ID, err)
+	}
+	letter.Event = event
+
+	if letter.FailedAt, err = parseTime(failedAt); err != nil {
+		return letter, fmt.Errorf("dead letter %s: invalid failed_at: %w", letter.ID, err)
+	}
+
+	return letter, nil
+}
+
+var _ events.DeadLetterStore = (*DeadLetterStore)(nil)
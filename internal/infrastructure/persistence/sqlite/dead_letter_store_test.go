@@ -0,0 +1,129 @@
+package sqlite_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"recipe-processor/internal/shared/events"
+	"testing"
+	"time"
+)
+
+func TestDeadLetterStore_AddListRemove(t *testing.T) {
+	db, _ := openTestDB(t)
+	registry, _ := recipe.NewEventRegistry()
+	store := sqlite.NewDeadLetterStore(db, registry)
+	ctx := context.Background()
+
+	failedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"dl-2", "dl-1"} {
+		letter := events.DeadLetter{
+			ID:        id,
+			Event:     domain.NewRecipeSubmitted("recipe-"+id, "Pancakes"),
+			Envelope:  events.Envelope{EventID: "event-" + id, CorrelationID: "request-" + id},
+			Handler:   "parse-recipe",
+			Attempts:  3,
+			LastError: "ollama unavailable",
+			FailedAt:  failedAt.Add(-time.Duration(i) * time.Minute),
+		}
+		if err := store.Add(ctx, letter); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	letters, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(letters) != 2 || letters[0].ID != "dl-1" {
+		t.Fatalf("expected both letters oldest first, got %+v", letters)
+	}
+
+	submitted, ok := letters[0].Event.(*domain.RecipeSubmitted)
+	if !ok || submitted.RecipeID != "recipe-dl-1" {
+		t.Errorf("expected decoded RecipeSubmitted, got %+v", letters[0].Event)
+	}
+	if letters[0].Handler != "parse-recipe" || letters[0].Attempts != 3 || letters[0].LastError != "ollama unavailable" {
+		t.Errorf("unexpected dead letter: %+v", letters[0])
+	}
+	if letters[0].Envelope.EventID != "event-dl-1" || letters[0].Envelope.CorrelationID != "request-dl-1" {
+		t.Errorf("expected envelope to round-trip, got %+v", letters[0].Envelope)
+	}
+
+	removed, err := store.Remove(ctx, "dl-1")
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if removed.ID != "dl-1" {
+		t.Errorf("Remove() returned %s", removed.ID)
+	}
+
+	if _, err := store.Remove(ctx, "dl-1"); !errors.Is(err, events.ErrDeadLetterNotFound) {
+		t.Errorf("expected ErrDeadLetterNotFound, got %v", err)
+	}
+}
+
+// renamedEvent is at v2, where v1 called its title "name"
+type renamedEvent struct {
+	Title string `json:"title"`
+}
+
+func (e *renamedEvent) EventType() string     { return "test.renamed" }
+func (e *renamedEvent) OccurredAt() time.Time { return time.Time{} }
+func (e *renamedEvent) SchemaVersion() int    { return 2 }
+
+// newUpcastingRegistry registers renamedEvent with its v1 upcaster
+// A v2 payload upcast again loses its title
+func newUpcastingRegistry(t *testing.T) *events.Registry {
+	t.Helper()
+
+	registry := events.NewRegistry()
+	upcast := func(payload []byte) ([]byte, error) {
+		var v1 struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(payload, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(renamedEvent{Title: v1.Name})
+	}
+	if err := registry.Register(func() events.Event { return &renamedEvent{} }, events.WithUpcaster(1, upcast)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	return registry
+}
+
+func TestDeadLetterStore_StoresTheEncodedSchemaVersion(t *testing.T) {
+	db, _ := openTestDB(t)
+	store := sqlite.NewDeadLetterStore(db, newUpcastingRegistry(t))
+	ctx := context.Background()
+
+	// The event was upcast from v1 when it was read, its envelope still says v1
+	letter := events.DeadLetter{
+		ID:       "dl-1",
+		Event:    &renamedEvent{Title: "Pancakes"},
+		Envelope: events.Envelope{EventID: "event-1", SchemaVersion: 1},
+		Handler:  "parse-recipe",
+		FailedAt: time.Now(),
+	}
+	if err := store.Add(ctx, letter); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	letters, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(letters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(letters))
+	}
+	if renamed := letters[0].Event.(*renamedEvent); renamed.Title != "Pancakes" {
+		t.Errorf("expected the title to survive without a second upcast, got %+v", renamed)
+	}
+	if letters[0].Envelope.SchemaVersion != 2 {
+		t.Errorf("expected schema version 2, got %d", letters[0].Envelope.SchemaVersion)
+	}
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+	"strings"
+)
+
+// EventStore keeps every published event in an append-only table
+// Triggers reject updates and deletes, so history can only grow
+type EventStore struct {
+	db       *sql.DB
+	registry *events.Registry
+}
+
+// NewEventStore creates an event store backed by db
+// registry encodes appended events and restores them when loading
+func NewEventStore(db *sql.DB, registry *events.Registry) *EventStore {
+	return &EventStore{db: db, registry: registry}
+}
+
+// Append records a published event; an event ID seen before is ignored
+func (s *EventStore) Append(ctx context.Context, event events.Event, env events.Envelope) error {
+	payload, version, err := s.registry.Encode(event)
+	if err != nil {
+		return err
+	}
+	env.SchemaVersion = version
+
+	envelope, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope of event %s: %w", env.EventID, err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO events (event_id, event_type, aggregate_id, schema_version, payload, envelope, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (event_id) DO NOTHING`,
+		env.EventID, event.EventType(), events.AggregateIDOf(event), version,
+		string(payload), string(envelope), formatTime(event.OccurredAt()),
+	); err != nil {
+		return fmt.Errorf("failed to append event %s: %w", env.EventID, err)
+	}
+
+	return nil
+}
+
+// Load returns the events matching filter in append order
+func (s *EventStore) Load(ctx context.Context, filter events.EventFilter) ([]events.StoredEvent, error) {
+	query := `
+		SELECT sequence, event_type, aggregate_id, schema_version, payload, envelope
+		FROM events
+		WHERE sequence > ?`
+	args := []any{filter.AfterSequence}
+
+	if filter.UpToSequence > 0 {
+		query += ` AND sequence <= ?`
+		args = append(args, filter.UpToSequence)
+	}
+	if filter.AggregateID != "" {
+		query += ` AND aggregate_id = ?`
+		args = append(args, filter.AggregateID)
+	}
+	if len(filter.EventTypes) > 0 {
+		query += ` AND event_type IN (?` + strings.Repeat(`, ?`, len(filter.EventTypes)-1) + `)`
+		for _, eventType := range filter.EventTypes {
+			args = append(args, eventType)
+		}
+	}
+	if !filter.From.IsZero() {
+		query += ` AND occurred_at >= ?`
+		args = append(args, formatTime(filter.From))
+	}
+	if !filter.To.IsZero() {
+		query += ` AND occurred_at < ?`
+		args = append(args, formatTime(filter.To))
+	}
+
+	query += ` ORDER BY sequence`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var stored []events.StoredEvent
+	for rows.Next() {
+		var (
+			se        events.StoredEvent
+			eventType string
+			version   int
+			payload   string
+			envelope  string
+		)
+		if err := rows.Scan(&se.Sequence, &eventType, &se.AggregateID, &version, &payload, &envelope); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(envelope), &se.Envelope); err != nil {
+			return nil, fmt.Errorf("event %d: invalid envelope: %w", se.Sequence, err)
+		}
+		if se.Event, err = s.registry.Decode(eventType, version, []byte(payload)); err != nil {
+			return nil, fmt.Errorf("event %d: %w", se.Sequence, err)
+		}
+
+		stored = append(stored, se)
+	}
+
+	return stored, rows.Err()
+}
+
+// LastSequence returns the sequence of the newest event, 0 when empty
+func (s *EventStore) LastSequence(ctx context.Context) (int64, error) {
+	var last int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(sequence), 0) FROM events`).Scan(&last); err != nil {
+		return 0, fmt.Errorf("failed to query last sequence: %w", err)
+	}
+	return last, nil
+}
+
+var _ events.EventStore = (*EventStore)(nil)
@@ -0,0 +1,94 @@
+package sqlite_test
+
+import (
+	"context"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"recipe-processor/internal/shared/events"
+	"testing"
+	"time"
+)
+
+func TestEventStore_AppendLoad(t *testing.T) {
+	db, _ := openTestDB(t)
+	registry, _ := recipe.NewEventRegistry()
+	store := sqlite.NewEventStore(db, registry)
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+
+	submitted := domain.NewRecipeSubmitted("recipe-1", "Pancakes")
+	env := events.NewEnvelope(ctx, submitted, "tests")
+	for _, evt := range []struct {
+		event events.Event
+		env   events.Envelope
+	}{
+		{submitted, env},
+		{submitted, env}, // redelivered, must not be stored twice
+		{domain.NewRecipeSubmitted("recipe-2", "Waffles"), events.NewEnvelope(ctx, submitted, "tests")},
+		{domain.NewRecipeProcessingFailed("recipe-1", domain.StatusParsing, "ollama unavailable"), events.NewEnvelope(ctx, submitted, "tests")},
+	} {
+		if err := store.Append(ctx, evt.event, evt.env); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	all, err := store.Load(ctx, events.EventFilter{})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 stored events, got %d", len(all))
+	}
+
+	first, ok := all[0].Event.(*domain.RecipeSubmitted)
+	if !ok || first.RecipeID != "recipe-1" || all[0].AggregateID != "recipe-1" {
+		t.Errorf("unexpected first event %+v", all[0])
+	}
+	if all[0].Envelope.EventID != env.EventID || all[0].Envelope.CorrelationID != "request-1" {
+		t.Errorf("expected envelope to round-trip, got %+v", all[0].Envelope)
+	}
+
+	byRecipe, _ := store.Load(ctx, events.EventFilter{AggregateID: "recipe-1", EventTypes: []string{domain.EventTypeRecipeFailed}})
+	if len(byRecipe) != 1 || byRecipe[0].Event.EventType() != domain.EventTypeRecipeFailed {
+		t.Errorf("expected the failure of recipe-1, got %+v", byRecipe)
+	}
+
+	future, _ := store.Load(ctx, events.EventFilter{From: time.Now().Add(time.Hour)})
+	if len(future) != 0 {
+		t.Errorf("expected no events after now, got %d", len(future))
+	}
+
+	page, _ := store.Load(ctx, events.EventFilter{AfterSequence: all[0].Sequence, Limit: 1})
+	if len(page) != 1 || page[0].Sequence != all[1].Sequence {
+		t.Errorf("expected the second event only, got %+v", page)
+	}
+
+	bounded, _ := store.Load(ctx, events.EventFilter{UpToSequence: all[1].Sequence})
+	if len(bounded) != 2 {
+		t.Errorf("expected the first two events, got %d", len(bounded))
+	}
+
+	last, err := store.LastSequence(ctx)
+	if err != nil || last != all[2].Sequence {
+		t.Errorf("LastSequence() = %d, %v; want %d", last, err, all[2].Sequence)
+	}
+}
+
+func TestEventStore_IsAppendOnly(t *testing.T) {
+	db, _ := openTestDB(t)
+	registry, _ := recipe.NewEventRegistry()
+	store := sqlite.NewEventStore(db, registry)
+	ctx := context.Background()
+
+	event := domain.NewRecipeSubmitted("recipe-1", "Pancakes")
+	if err := store.Append(ctx, event, events.NewEnvelope(ctx, event, "tests")); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `DELETE FROM events`); err == nil {
+		t.Error("expected deleting events to fail")
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE events SET payload = '{}'`); err == nil {
+		t.Error("expected updating events to fail")
+	}
+}
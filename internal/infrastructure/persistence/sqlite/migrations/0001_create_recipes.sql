@@ -0,0 +1,14 @@
+CREATE TABLE recipes (
+    id              TEXT PRIMARY KEY,
+    raw_text        TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    failure_reason  TEXT NOT NULL DEFAULT '',
+    transitions     TEXT NOT NULL,
+    parsed_recipe   TEXT,
+    notion_page_id  TEXT,
+    notion_page_url TEXT,
+    submitted_at    TEXT NOT NULL,
+    updated_at      TEXT NOT NULL
+);
+
+CREATE INDEX idx_recipes_status ON recipes (status);
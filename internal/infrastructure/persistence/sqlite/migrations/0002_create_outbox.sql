@@ -0,0 +1,14 @@
+CREATE TABLE outbox (
+    id           TEXT PRIMARY KEY,
+    event_type   TEXT NOT NULL,
+    payload      TEXT NOT NULL,
+    created_at   TEXT NOT NULL,
+    available_at TEXT,
+    attempts     INTEGER NOT NULL DEFAULT 0,
+    last_error   TEXT NOT NULL DEFAULT '',
+    delivered_at TEXT
+);
+
+-- Pending messages are undelivered and not parked
+CREATE INDEX idx_outbox_pending ON outbox (available_at, created_at)
+    WHERE delivered_at IS NULL AND available_at IS NOT NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE dead_letters (
+    id         TEXT PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    payload    TEXT NOT NULL,
+    handler    TEXT NOT NULL,
+    attempts   INTEGER NOT NULL,
+    last_error TEXT NOT NULL,
+    failed_at  TEXT NOT NULL
+);
+
+CREATE INDEX idx_dead_letters_failed_at ON dead_letters (failed_at);
@@ -0,0 +1,2 @@
+ALTER TABLE outbox ADD COLUMN envelope TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE dead_letters ADD COLUMN envelope TEXT NOT NULL DEFAULT '{}';
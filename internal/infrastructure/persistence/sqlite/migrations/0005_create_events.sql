@@ -0,0 +1,23 @@
+CREATE TABLE events (
+    sequence       INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id       TEXT NOT NULL UNIQUE,
+    event_type     TEXT NOT NULL,
+    aggregate_id   TEXT NOT NULL,
+    schema_version INTEGER NOT NULL,
+    payload        TEXT NOT NULL,
+    envelope       TEXT NOT NULL,
+    occurred_at    TEXT NOT NULL
+);
+
+CREATE INDEX idx_events_aggregate ON events (aggregate_id, sequence);
+CREATE INDEX idx_events_occurred_at ON events (occurred_at);
+
+CREATE TRIGGER events_no_update BEFORE UPDATE ON events
+BEGIN
+    SELECT RAISE(ABORT, 'events are append-only');
+END;
+
+CREATE TRIGGER events_no_delete BEFORE DELETE ON events
+BEGIN
+    SELECT RAISE(ABORT, 'events are append-only');
+END;
@@ -0,0 +1,8 @@
+CREATE TABLE event_spill (
+    sequence   INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id   TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload    TEXT NOT NULL,
+    envelope   TEXT NOT NULL,
+    spilled_at TEXT NOT NULL
+);
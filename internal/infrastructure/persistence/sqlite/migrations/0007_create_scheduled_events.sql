@@ -0,0 +1,10 @@
+CREATE TABLE scheduled_events (
+    id         TEXT PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    payload    TEXT NOT NULL,
+    envelope   TEXT NOT NULL,
+    due_at     TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_scheduled_events_due_at ON scheduled_events (due_at);
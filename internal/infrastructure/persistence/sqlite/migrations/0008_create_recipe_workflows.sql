@@ -0,0 +1,17 @@
+CREATE TABLE recipe_workflows (
+    recipe_id             TEXT PRIMARY KEY,
+    run                   INTEGER NOT NULL,
+    status                TEXT NOT NULL,
+    step                  TEXT NOT NULL,
+    step_deadline         TEXT,
+    timeout_id            TEXT NOT NULL DEFAULT '',
+    export_page_id        TEXT,
+    export_page_url       TEXT,
+    pending_compensations INTEGER NOT NULL DEFAULT 0,
+    failure_reason        TEXT NOT NULL DEFAULT '',
+    started_at            TEXT NOT NULL,
+    updated_at            TEXT NOT NULL,
+    version               INTEGER NOT NULL
+);
+
+CREATE INDEX idx_recipe_workflows_status ON recipe_workflows (status);
@@ -0,0 +1 @@
+ALTER TABLE scheduled_events ADD COLUMN parked_reason TEXT;
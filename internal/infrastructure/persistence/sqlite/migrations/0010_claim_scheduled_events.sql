@@ -0,0 +1 @@
+ALTER TABLE scheduled_events ADD COLUMN claimed_until TEXT;
@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"recipe-processor/internal/shared/outbox"
+	"time"
+)
+
+// OutboxStore reads and updates the outbox table
+// Messages are written by RecipeRepository.SaveWithEvents
+type OutboxStore struct {
+	db *sql.DB
+}
+
+// NewOutboxStore creates an outbox store backed by db
+func NewOutboxStore(db *sql.DB) *OutboxStore {
+	return &OutboxStore{db: db}
+}
+
+// Pending returns undelivered messages that are due at now, oldest first
+func (s *OutboxStore) Pending(ctx context.Context, now time.Time, limit int) ([]outbox.Message, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, event_type, payload, envelope, created_at, attempts, last_error
+		FROM outbox
+		WHERE delivered_at IS NULL AND available_at IS NOT NULL AND available_at <= ?
+		ORDER BY created_at
+		LIMIT ?`, formatTime(now), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messages []outbox.Message
+	for rows.Next() {
+		var (
+			msg       outbox.Message
+			payload   string
+			envelope  string
+			createdAt string
+		)
+		if err := rows.Scan(&msg.ID, &msg.EventType, &payload, &envelope, &createdAt, &msg.Attempts, &msg.LastError); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+
+		msg.Payload = []byte(payload)
+		if err := json.Unmarshal([]byte(envelope), &msg.Envelope); err != nil {
+			return nil, fmt.Errorf("outbox message %s: invalid envelope: %w", msg.ID, err)
+		}
+		if msg.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("outbox message %s: invalid created_at: %w", msg.ID, err)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+// MarkDelivered records that all handlers processed the message
+func (s *OutboxStore) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	if _, err := s.db.ExecContext(ctx,
+		`UPDATE outbox SET delivered_at = ? WHERE id = ?`, formatTime(at), id,
+	); err != nil {
+		return fmt.Errorf("failed to mark outbox message %s delivered: %w", id, err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt
+func (s *OutboxStore) MarkFailed(ctx context.Context, id string, reason string, retryAt time.Time) error {
+	var availableAt sql.NullString
+	if !retryAt.IsZero() {
+		availableAt = sql.NullString{String: formatTime(retryAt), Valid: true}
+	}
+
+	if _, err := s.db.ExecContext(ctx,
+		`UPDATE outbox SET attempts = attempts + 1, last_error = ?, available_at = ? WHERE id = ?`,
+		reason, availableAt, id,
+	); err != nil {
+		return fmt.Errorf("failed to record outbox failure of %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// insertMessages adds messages to the outbox as immediately due
+func insertMessages(ctx context.Context, db execer, messages []outbox.Message) error {
+	for _, msg := range messages {
+		envelope, err := json.Marshal(msg.Envelope)
+		if err != nil {
+			return fmt.Errorf("failed to encode envelope of %s event: %w", msg.EventType, err)
+		}
+
+		createdAt := formatTime(msg.CreatedAt)
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO outbox (id, event_type, payload, envelope, created_at, available_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			msg.ID, msg.EventType, string(msg.Payload), string(envelope), createdAt, createdAt,
+		); err != nil {
+			return fmt.Errorf("failed to enqueue %s event: %w", msg.EventType, err)
+		}
+	}
+
+	return nil
+}
+
+var _ outbox.Store = (*OutboxStore)(nil)
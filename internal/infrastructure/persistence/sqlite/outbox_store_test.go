@@ -0,0 +1,121 @@
+package sqlite_test
+
+import (
+	"context"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"recipe-processor/internal/shared/events"
+	"testing"
+	"time"
+)
+
+func TestRecipeRepository_SaveWithEvents_EnqueuesEvents(t *testing.T) {
+	db, _ := openTestDB(t)
+	repo := newTestRepository(t, db)
+	store := sqlite.NewOutboxStore(db)
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+
+	text, _ := domain.NewRecipeText("Pancakes")
+	lifecycle, _ := domain.NewRecipeLifecycle("recipe-1", text, time.Now())
+	if err := repo.SaveWithEvents(ctx, lifecycle, domain.NewRecipeSubmitted("recipe-1", "Pancakes")); err != nil {
+		t.Fatalf("SaveWithEvents() error = %v", err)
+	}
+
+	if _, err := repo.FindByID(ctx, "recipe-1"); err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+
+	messages, err := store.Pending(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	if len(messages) != 1 || messages[0].EventType != domain.EventTypeRecipeSubmitted {
+		t.Fatalf("expected one recipe.submitted message, got %+v", messages)
+	}
+	if env := messages[0].Envelope; env.EventID == "" || env.CorrelationID != "request-1" {
+		t.Errorf("expected stored envelope with correlation ID 'request-1', got %+v", env)
+	}
+}
+
+func TestRecipeRepository_SaveWithEvents_RollsBackOnFailure(t *testing.T) {
+	db, _ := openTestDB(t)
+	repo := newTestRepository(t, db)
+	store := sqlite.NewOutboxStore(db)
+	ctx := context.Background()
+
+	text, _ := domain.NewRecipeText("Pancakes")
+	first, _ := domain.NewRecipeLifecycle("recipe-1", text, time.Now())
+	event := domain.NewRecipeSubmitted("recipe-1", "Pancakes")
+	if err := repo.SaveWithEvents(ctx, first, event); err != nil {
+		t.Fatalf("SaveWithEvents() error = %v", err)
+	}
+
+	// A cancelled context fails the transaction before anything is committed
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	second, _ := domain.NewRecipeLifecycle("recipe-2", text, time.Now())
+	if err := repo.SaveWithEvents(cancelled, second, domain.NewRecipeSubmitted("recipe-2", "Pancakes")); err == nil {
+		t.Fatal("expected SaveWithEvents() to fail with a cancelled context")
+	}
+
+	if _, err := repo.FindByID(ctx, "recipe-2"); err == nil {
+		t.Error("expected recipe-2 not to be saved")
+	}
+	if messages, _ := store.Pending(ctx, time.Now(), 10); len(messages) != 1 {
+		t.Errorf("expected only the first message in the outbox, got %d", len(messages))
+	}
+}
+
+func TestOutboxStore_DeliveryState(t *testing.T) {
+	db, path := openTestDB(t)
+	repo := newTestRepository(t, db)
+	store := sqlite.NewOutboxStore(db)
+	ctx := context.Background()
+
+	text, _ := domain.NewRecipeText("Pancakes")
+	for _, id := range []string{"recipe-1", "recipe-2", "recipe-3"} {
+		lifecycle, _ := domain.NewRecipeLifecycle(id, text, time.Now())
+		if err := repo.SaveWithEvents(ctx, lifecycle, domain.NewRecipeSubmitted(id, "Pancakes")); err != nil {
+			t.Fatalf("SaveWithEvents() error = %v", err)
+		}
+	}
+
+	messages, _ := store.Pending(ctx, time.Now(), 10)
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 pending messages, got %d", len(messages))
+	}
+
+	retryAt := time.Now().Add(time.Minute)
+	if err := store.MarkDelivered(ctx, messages[0].ID, time.Now()); err != nil {
+		t.Fatalf("MarkDelivered() error = %v", err)
+	}
+	if err := store.MarkFailed(ctx, messages[1].ID, "handler failed", retryAt); err != nil {
+		t.Fatalf("MarkFailed() error = %v", err)
+	}
+	if err := store.MarkFailed(ctx, messages[2].ID, "gave up", time.Time{}); err != nil {
+		t.Fatalf("MarkFailed() error = %v", err)
+	}
+
+	if pending, _ := store.Pending(ctx, time.Now(), 10); len(pending) != 0 {
+		t.Errorf("expected nothing due now, got %d", len(pending))
+	}
+
+	// Pending state survives a restart
+	_ = db.Close()
+	reopened, err := sqlite.Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = reopened.Close() }()
+
+	due, err := sqlite.NewOutboxStore(reopened).Pending(ctx, retryAt.Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	if len(due) != 1 || due[0].ID != messages[1].ID {
+		t.Fatalf("expected only the retried message to be due, got %+v", due)
+	}
+	if due[0].Attempts != 1 || due[0].LastError != "handler failed" {
+		t.Errorf("unexpected delivery state: attempts=%d last_error=%q", due[0].Attempts, due[0].LastError)
+	}
+}
@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/outbox"
+	"time"
+)
+
+// transitionJSON is the stored form of a status transition
+type transitionJSON struct {
+	Status domain.RecipeStatus `json:"status"`
+	At     time.Time           `json:"at"`
+}
+
+// RecipeRepository stores recipe lifecycles in SQLite
+type RecipeRepository struct {
+	db       *sql.DB
+	registry *events.Registry
+}
+
+// NewRecipeRepository creates a recipe repository backed by db
+// The schema must already be migrated, see Open; registry encodes the events
+// saved with SaveWithEvents
+func NewRecipeRepository(db *sql.DB, registry *events.Registry) *RecipeRepository {
+	return &RecipeRepository{db: db, registry: registry}
+}
+
+// Save inserts or replaces the lifecycle
+func (r *RecipeRepository) Save(ctx context.Context, lifecycle *domain.RecipeLifecycle) error {
+	return saveLifecycle(ctx, r.db, lifecycle)
+}
+
+// SaveWithEvents inserts or replaces the lifecycle and enqueues the events
+// in the outbox table, in a single transaction
+func (r *RecipeRepository) SaveWithEvents(ctx context.Context, lifecycle *domain.RecipeLifecycle, evts ...events.Event) error {
+	messages, err := outbox.NewMessages(ctx, r.registry, evts...)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := saveLifecycle(ctx, tx, lifecycle); err != nil {
+		return err
+	}
+
+	if err := insertMessages(ctx, tx, messages); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit recipe %s: %w", lifecycle.ID(), err)
+	}
+
+	return nil
+}
+
+// FindByID returns the lifecycle with the given ID
+func (r *RecipeRepository) FindByID(ctx context.Context, id string) (*domain.RecipeLifecycle, error) {
+	var (
+		s            domain.RecipeLifecycleSnapshot
+		status       string
+		transitions  string
+		parsedRecipe sql.NullString
+		pageID       sql.NullString
+		pageURL      sql.NullString
+	)
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, raw_text, status, failure_reason, transitions, parsed_recipe, notion_page_id, notion_page_url
+		FROM recipes WHERE id = ?`, id,
+	).Scan(&s.ID, &s.RawText, &status, &s.FailureReason, &transitions, &parsedRecipe, &pageID, &pageURL)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, recipe.ErrRecipeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recipe %s: %w", id, err)
+	}
+
+	s.Status = domain.RecipeStatus(status)
+
+	var stored []transitionJSON
+	if err := json.Unmarshal([]byte(transitions), &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode transitions of recipe %s: %w", id, err)
+	}
+	for _, t := range stored {
+		s.Transitions = append(s.Transitions, domain.StatusTransition{Status: t.Status, At: t.At})
+	}
+
+	if parsedRecipe.Valid {
+		var parsed domain.Recipe
+		if err := json.Unmarshal([]byte(parsedRecipe.String), &parsed); err != nil {
+			return nil, fmt.Errorf("failed to decode parsed recipe %s: %w", id, err)
+		}
+		s.Recipe = &parsed
+	}
+
+	if pageID.Valid {
+		s.Export = &domain.ExportReference{PageID: pageID.String, URL: pageURL.String}
+	}
+
+	return domain.RestoreRecipeLifecycle(s)
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// saveLifecycle upserts the lifecycle using db, which may be a transaction
+func saveLifecycle(ctx context.Context, db execer, lifecycle *domain.RecipeLifecycle) error {
+	s := lifecycle.Snapshot()
+
+	stored := make([]transitionJSON, len(s.Transitions))
+	for i, t := range s.Transitions {
+		stored[i] = transitionJSON{Status: t.Status, At: t.At.UTC()}
+	}
+	transitions, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode transitions: %w", err)
+	}
+
+	var parsedRecipe, pageID, pageURL sql.NullString
+	if s.Recipe != nil {
+		data, err := json.Marshal(s.Recipe)
+		if err != nil {
+			return fmt.Errorf("failed to encode parsed recipe: %w", err)
+		}
+		parsedRecipe = sql.NullString{String: string(data), Valid: true}
+	}
+	if s.Export != nil {
+		pageID = sql.NullString{String: s.Export.PageID, Valid: true}
+		pageURL = sql.NullString{String: s.Export.URL, Valid: true}
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO recipes (id, raw_text, status, failure_reason, transitions, parsed_recipe,
+			notion_page_id, notion_page_url, submitted_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			raw_text        = excluded.raw_text,
+			status          = excluded.status,
+			failure_reason  = excluded.failure_reason,
+			transitions     = excluded.transitions,
+			parsed_recipe   = excluded.parsed_recipe,
+			notion_page_id  = excluded.notion_page_id,
+			notion_page_url = excluded.notion_page_url,
+			updated_at      = excluded.updated_at`,
+		s.ID, s.RawText, string(s.Status), s.FailureReason, string(transitions), parsedRecipe,
+		pageID, pageURL, formatTime(lifecycle.SubmittedAt()), formatTime(lifecycle.UpdatedAt()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save recipe %s: %w", s.ID, err)
+	}
+
+	return nil
+}
+
+// timeLayout is RFC 3339 with a fixed number of fractional digits,
+// so stored timestamps compare correctly as text
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// formatTime renders t in a sortable text form
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// parseTime reads a timestamp written by formatTime
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(timeLayout, s)
+}
+
+var _ recipe.OutboxRepository = (*RecipeRepository)(nil)
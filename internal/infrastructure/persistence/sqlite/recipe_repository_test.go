@@ -0,0 +1,151 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"testing"
+	"time"
+)
+
+// openTestDB opens a migrated database in a temporary directory
+func openTestDB(t *testing.T) (*sql.DB, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "recipes.db")
+	db, err := sqlite.Open(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, path
+}
+
+// newTestRepository creates a repository encoding events with the recipe registry
+func newTestRepository(t *testing.T, db *sql.DB) *sqlite.RecipeRepository {
+	t.Helper()
+
+	registry, err := recipe.NewEventRegistry()
+	if err != nil {
+		t.Fatalf("NewEventRegistry() error = %v", err)
+	}
+	return sqlite.NewRecipeRepository(db, registry)
+}
+
+func newParsedRecipe(t *testing.T, id string) *domain.Recipe {
+	t.Helper()
+
+	ingredient, _ := domain.NewIngredient(domain.IngredientParams{Quantity: 2, Name: "eggs"})
+	temp, _ := domain.NewTemperature(180, domain.Celsius)
+	step, _ := domain.NewStep(domain.StepParams{Text: "Bake", Duration: 20 * time.Minute, Temperature: temp})
+
+	r, err := domain.NewRecipe(domain.RecipeParams{
+		ID:          id,
+		Title:       "Frittata",
+		Servings:    2,
+		Tags:        []string{"eggs"},
+		Ingredients: []domain.Ingredient{*ingredient},
+		Steps:       []domain.Step{*step},
+	})
+	if err != nil {
+		t.Fatalf("NewRecipe() error = %v", err)
+	}
+
+	return r
+}
+
+func TestRecipeRepository_SaveAndFind(t *testing.T) {
+	db, _ := openTestDB(t)
+	repo := newTestRepository(t, db)
+	ctx := context.Background()
+
+	text, _ := domain.NewRecipeText("Frittata with eggs")
+	submittedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lifecycle, _ := domain.NewRecipeLifecycle("recipe-1", text, submittedAt)
+
+	if err := repo.Save(ctx, lifecycle); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	_ = lifecycle.StartParsing(submittedAt.Add(time.Second))
+	_ = lifecycle.MarkParsed(newParsedRecipe(t, "recipe-1"), submittedAt.Add(2*time.Second))
+	_ = lifecycle.StartExporting(submittedAt.Add(3 * time.Second))
+	_ = lifecycle.MarkExported(domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}, submittedAt.Add(4*time.Second))
+
+	if err := repo.Save(ctx, lifecycle); err != nil {
+		t.Fatalf("Save() update error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, "recipe-1")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+
+	if found.Status() != domain.StatusExported {
+		t.Errorf("Status() = %q, want %q", found.Status(), domain.StatusExported)
+	}
+	if found.RawText() != "Frittata with eggs" {
+		t.Errorf("RawText() = %q", found.RawText())
+	}
+	if len(found.Transitions()) != 5 {
+		t.Errorf("expected 5 transitions, got %d", len(found.Transitions()))
+	}
+	if !found.SubmittedAt().Equal(submittedAt) {
+		t.Errorf("SubmittedAt() = %v, want %v", found.SubmittedAt(), submittedAt)
+	}
+	if found.Recipe() == nil || found.Recipe().Title() != "Frittata" {
+		t.Fatalf("expected parsed recipe to be restored, got %+v", found.Recipe())
+	}
+	if got := found.Recipe().Steps()[0].Temperature().String(); got != "180°C" {
+		t.Errorf("step temperature = %q, want 180°C", got)
+	}
+	if ref := found.ExportReference(); ref == nil || ref.PageID != "page-1" {
+		t.Errorf("unexpected export reference: %+v", ref)
+	}
+}
+
+func TestRecipeRepository_FindByID_NotFound(t *testing.T) {
+	db, _ := openTestDB(t)
+	repo := newTestRepository(t, db)
+
+	_, err := repo.FindByID(context.Background(), "missing")
+	if !errors.Is(err, recipe.ErrRecipeNotFound) {
+		t.Fatalf("expected ErrRecipeNotFound, got %v", err)
+	}
+}
+
+func TestRecipeRepository_SurvivesReopen(t *testing.T) {
+	db, path := openTestDB(t)
+	ctx := context.Background()
+
+	text, _ := domain.NewRecipeText("Toast")
+	lifecycle, _ := domain.NewRecipeLifecycle("recipe-1", text, time.Now())
+	_ = lifecycle.StartParsing(time.Now())
+	_ = lifecycle.Fail("model unavailable", time.Now())
+
+	if err := newTestRepository(t, db).Save(ctx, lifecycle); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	_ = db.Close()
+
+	// Reopening runs the migrations again, which must be a no-op
+	reopened, err := sqlite.Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = reopened.Close() }()
+
+	found, err := newTestRepository(t, reopened).FindByID(ctx, "recipe-1")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+
+	if found.Status() != domain.StatusFailed || found.FailureReason() != "model unavailable" {
+		t.Errorf("unexpected state after reopen: %s %q", found.Status(), found.FailureReason())
+	}
+}
@@ -0,0 +1,168 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/scheduler"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ScheduleStore keeps scheduled events in SQLite so they survive restarts
+type ScheduleStore struct {
+	db       *sql.DB
+	registry *events.Registry
+}
+
+// NewScheduleStore creates a schedule store backed by db
+// registry encodes scheduled events and restores them when they are due
+func NewScheduleStore(db *sql.DB, registry *events.Registry) *ScheduleStore {
+	return &ScheduleStore{db: db, registry: registry}
+}
+
+// Add stores a scheduled event
+func (s *ScheduleStore) Add(ctx context.Context, scheduled scheduler.ScheduledEvent) error {
+	payload, version, err := s.registry.Encode(scheduled.Event)
+	if err != nil {
+		return err
+	}
+	scheduled.Envelope.SchemaVersion = version
+
+	envelope, err := json.Marshal(scheduled.Envelope)
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope of scheduled event %s: %w", scheduled.ID, err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO scheduled_events (id, event_type, payload, envelope, due_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		scheduled.ID, scheduled.Event.EventType(), string(payload), string(envelope),
+		formatTime(scheduled.DueAt), formatTime(scheduled.CreatedAt),
+	); err != nil {
+		return fmt.Errorf("failed to store scheduled event %s: %w", scheduled.ID, err)
+	}
+
+	return nil
+}
+
+// Claim returns events due at now that are not claimed, earliest first, and
+// claims them until now+lease in the same statement, so schedulers sharing
+// the database never both get an event
+// Rows that cannot be decoded are parked: they stay in the table for
+// inspection but are never returned again
+func (s *ScheduleStore) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]scheduler.ScheduledEvent, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE scheduled_events SET claimed_until = ?
+		WHERE id IN (
+			SELECT id FROM scheduled_events
+			WHERE due_at <= ? AND parked_reason IS NULL
+				AND (claimed_until IS NULL OR claimed_until <= ?)
+			ORDER BY due_at
+			LIMIT ?
+		)
+		RETURNING id, event_type, payload, envelope, due_at, created_at`,
+		formatTime(now.Add(lease)), formatTime(now), formatTime(now), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim scheduled events: %w", err)
+	}
+
+	var found []scheduledRow
+	for rows.Next() {
+		var r scheduledRow
+		if err := rows.Scan(&r.id, &r.eventType, &r.payload, &r.envelope, &r.dueAt, &r.createdAt); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan scheduled event: %w", err)
+		}
+		found = append(found, r)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to read scheduled events: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read scheduled events: %w", err)
+	}
+	// RETURNING does not keep the order of the subquery
+	slices.SortFunc(found, func(a, b scheduledRow) int { return strings.Compare(a.dueAt, b.dueAt) })
+
+	var (
+		due     []scheduler.ScheduledEvent
+		invalid []error
+	)
+	for _, r := range found {
+		scheduled, err := s.decode(r)
+		if err != nil {
+			if parkErr := s.park(ctx, r.id, err); parkErr != nil {
+				return nil, parkErr
+			}
+			invalid = append(invalid, fmt.Errorf("%w %s: %w", scheduler.ErrInvalidEvent, r.id, err))
+			continue
+		}
+		due = append(due, scheduled)
+	}
+
+	return due, errors.Join(invalid...)
+}
+
+// scheduledRow is a scheduled_events row before decoding
+type scheduledRow struct {
+	id, eventType, payload, envelope, dueAt, createdAt string
+}
+
+func (s *ScheduleStore) decode(r scheduledRow) (scheduler.ScheduledEvent, error) {
+	scheduled := scheduler.ScheduledEvent{ID: r.id}
+
+	var err error
+	if err = json.Unmarshal([]byte(r.envelope), &scheduled.Envelope); err != nil {
+		return scheduled, fmt.Errorf("invalid envelope: %w", err)
+	}
+	if scheduled.Event, err = s.registry.Decode(r.eventType, scheduled.Envelope.SchemaVersion, []byte(r.payload)); err != nil {
+		return scheduled, err
+	}
+	if scheduled.DueAt, err = parseTime(r.dueAt); err != nil {
+		return scheduled, fmt.Errorf("invalid due_at: %w", err)
+	}
+	if scheduled.CreatedAt, err = parseTime(r.createdAt); err != nil {
+		return scheduled, fmt.Errorf("invalid created_at: %w", err)
+	}
+	return scheduled, nil
+}
+
+// park excludes a scheduled event from Due, keeping why it could not be restored
+func (s *ScheduleStore) park(ctx context.Context, id string, reason error) error {
+	if _, err := s.db.ExecContext(ctx,
+		`UPDATE scheduled_events SET parked_reason = ? WHERE id = ?`, reason.Error(), id,
+	); err != nil {
+		return fmt.Errorf("failed to park scheduled event %s: %w", id, err)
+	}
+	return nil
+}
+
+// Remove deletes a scheduled event
+func (s *ScheduleStore) Remove(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM scheduled_events WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete scheduled event %s: %w", id, err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete scheduled event %s: %w", id, err)
+	}
+	if n == 0 {
+		return scheduler.ErrNotFound
+	}
+
+	return nil
+}
+
+var _ scheduler.Store = (*ScheduleStore)(nil)
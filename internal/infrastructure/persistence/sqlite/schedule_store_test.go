@@ -0,0 +1,150 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/scheduler"
+	"testing"
+	"time"
+)
+
+func TestScheduleStore_AddClaimRemove(t *testing.T) {
+	db, path := openTestDB(t)
+	registry, _ := recipe.NewEventRegistry()
+	store := sqlite.NewScheduleStore(db, registry)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+	schedule := []struct {
+		id    string
+		dueAt time.Time
+	}{
+		{"later", now.Add(-time.Minute)},
+		{"sooner", now.Add(-2 * time.Minute)},
+		{"tomorrow", now.Add(24 * time.Hour)},
+	}
+	for _, s := range schedule {
+		scheduled := scheduler.ScheduledEvent{
+			ID:        s.id,
+			Event:     domain.NewRecipeSubmitted("recipe-"+s.id, "Pancakes"),
+			Envelope:  events.Envelope{EventID: s.id, CorrelationID: "request-" + s.id, SchemaVersion: 1},
+			DueAt:     s.dueAt,
+			CreatedAt: now.Add(-time.Hour),
+		}
+		if err := store.Add(ctx, scheduled); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	// A new store on the reopened database sees the same schedule
+	reopened, err := sqlite.Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = reopened.Close() }()
+	store = sqlite.NewScheduleStore(reopened, registry)
+
+	due, err := store.Claim(ctx, now, 0, 0)
+	if err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if len(due) != 2 || due[0].ID != "sooner" || due[1].ID != "later" {
+		t.Fatalf("expected sooner and later, got %+v", due)
+	}
+	submitted, ok := due[0].Event.(*domain.RecipeSubmitted)
+	if !ok || submitted.RecipeID != "recipe-sooner" {
+		t.Errorf("expected decoded RecipeSubmitted, got %+v", due[0].Event)
+	}
+	if due[0].Envelope.CorrelationID != "request-sooner" || !due[0].DueAt.Equal(now.Add(-2*time.Minute)) {
+		t.Errorf("expected envelope and due time to round-trip, got %+v", due[0])
+	}
+
+	if err := store.Remove(ctx, "sooner"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := store.Remove(ctx, "sooner"); !errors.Is(err, scheduler.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if due, _ := store.Claim(ctx, now, 0, 1); len(due) != 1 || due[0].ID != "later" {
+		t.Errorf("expected only later to be due, got %+v", due)
+	}
+}
+
+func TestScheduleStore_ClaimHidesEventsUntilTheLeaseEnds(t *testing.T) {
+	db, _ := openTestDB(t)
+	registry, _ := recipe.NewEventRegistry()
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+	if err := sqlite.NewScheduleStore(db, registry).Add(ctx, scheduler.ScheduledEvent{
+		ID:        "timeout",
+		Event:     domain.NewRecipeSubmitted("recipe-1", "Pancakes"),
+		Envelope:  events.Envelope{EventID: "timeout", SchemaVersion: 1},
+		DueAt:     now.Add(-time.Minute),
+		CreatedAt: now.Add(-time.Hour),
+	}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	// Two processes polling the same database
+	api := sqlite.NewScheduleStore(db, registry)
+	worker := sqlite.NewScheduleStore(db, registry)
+
+	if due, err := api.Claim(ctx, now, time.Minute, 0); err != nil || len(due) != 1 {
+		t.Fatalf("Claim() = %+v, %v; want the due event", due, err)
+	}
+	if due, err := worker.Claim(ctx, now.Add(30*time.Second), time.Minute, 0); err != nil || len(due) != 0 {
+		t.Errorf("Claim() = %+v, %v; want nothing while the event is claimed", due, err)
+	}
+	if due, err := worker.Claim(ctx, now.Add(time.Minute), time.Minute, 0); err != nil || len(due) != 1 {
+		t.Errorf("Claim() = %+v, %v; want the event once the claim ended", due, err)
+	}
+}
+
+func TestScheduleStore_ParksUndecodableEvents(t *testing.T) {
+	db, _ := openTestDB(t)
+	registry, _ := recipe.NewEventRegistry()
+	store := sqlite.NewScheduleStore(db, registry)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+	if err := store.Add(ctx, scheduler.ScheduledEvent{
+		ID:        "valid",
+		Event:     domain.NewRecipeSubmitted("recipe-1", "Pancakes"),
+		Envelope:  events.Envelope{EventID: "valid", SchemaVersion: 1},
+		DueAt:     now.Add(-time.Minute),
+		CreatedAt: now.Add(-time.Hour),
+	}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	// Earliest due, so it comes first in every batch
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO scheduled_events (id, event_type, payload, envelope, due_at, created_at)
+		VALUES ('broken', ?, '{}', 'not json', ?, ?)`,
+		domain.EventTypeRecipeSubmitted, now.Add(-time.Hour).Format("2006-01-02T15:04:05.000000000Z07:00"), now.Format("2006-01-02T15:04:05.000000000Z07:00"),
+	); err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+
+	due, err := store.Claim(ctx, now, 0, 1)
+	if !errors.Is(err, scheduler.ErrInvalidEvent) {
+		t.Fatalf("expected ErrInvalidEvent, got %v", err)
+	}
+	if len(due) != 0 {
+		t.Errorf("expected the broken event to fill the batch of 1, got %+v", due)
+	}
+
+	due, err = store.Claim(ctx, now, 0, 1)
+	if err != nil || len(due) != 1 || due[0].ID != "valid" {
+		t.Errorf("Claim() = %+v, %v; want the valid event once the broken one is parked", due, err)
+	}
+
+	var reason string
+	if err := db.QueryRowContext(ctx, `SELECT parked_reason FROM scheduled_events WHERE id = 'broken'`).Scan(&reason); err != nil || reason == "" {
+		t.Errorf("expected the broken event to be kept with its reason, got %q, %v", reason, err)
+	}
+}
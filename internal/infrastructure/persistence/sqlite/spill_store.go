@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+	"time"
+)
+
+// SpillStore keeps the events a full memory bus queue had no room for, so
+// they are delivered after a restart instead of being lost
+type SpillStore struct {
+	db       *sql.DB
+	registry *events.Registry
+}
+
+// NewSpillStore creates a spill store backed by db
+// registry encodes pushed events and restores them when popping
+func NewSpillStore(db *sql.DB, registry *events.Registry) *SpillStore {
+	return &SpillStore{db: db, registry: registry}
+}
+
+// Push appends an event
+func (s *SpillStore) Push(ctx context.Context, event events.Event, env events.Envelope) error {
+	payload, version, err := s.registry.Encode(event)
+	if err != nil {
+		return err
+	}
+	env.SchemaVersion = version
+
+	envelope, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope of event %s: %w", env.EventID, err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO event_spill (event_id, event_type, payload, envelope, spilled_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		env.EventID, event.EventType(), string(payload), string(envelope), formatTime(time.Now()),
+	); err != nil {
+		return fmt.Errorf("failed to spill event %s: %w", env.EventID, err)
+	}
+
+	return nil
+}
+
+// Pop removes and returns up to n of the oldest events
+func (s *SpillStore) Pop(ctx context.Context, n int) ([]events.SpilledEvent, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT sequence, event_type, payload, envelope
+		FROM event_spill ORDER BY sequence LIMIT ?`, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query spilled events: %w", err)
+	}
+
+	var (
+		spilled []events.SpilledEvent
+		last    int64
+	)
+	for rows.Next() {
+		var (
+			eventType string
+			payload   string
+			envelope  string
+			event     events.SpilledEvent
+		)
+		if err := rows.Scan(&last, &eventType, &payload, &envelope); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan spilled event: %w", err)
+		}
+		if err := json.Unmarshal([]byte(envelope), &event.Envelope); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("spilled event %d: invalid envelope: %w", last, err)
+		}
+		if event.Event, err = s.registry.Decode(eventType, event.Envelope.SchemaVersion, []byte(payload)); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("spilled event %d: %w", last, err)
+		}
+		spilled = append(spilled, event)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to read spilled events: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read spilled events: %w", err)
+	}
+	if len(spilled) == 0 {
+		return nil, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM event_spill WHERE sequence <= ?`, last); err != nil {
+		return nil, fmt.Errorf("failed to delete spilled events: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit spill pop: %w", err)
+	}
+
+	return spilled, nil
+}
+
+// Len returns the number of spilled events
+func (s *SpillStore) Len(ctx context.Context) (int, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM event_spill`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count spilled events: %w", err)
+	}
+	return n, nil
+}
+
+var _ events.SpillStore = (*SpillStore)(nil)
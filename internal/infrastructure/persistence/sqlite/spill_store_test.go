@@ -0,0 +1,80 @@
+package sqlite_test
+
+import (
+	"context"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"recipe-processor/internal/shared/events"
+	"testing"
+)
+
+func TestSpillStore_PushPopInOrder(t *testing.T) {
+	db, _ := openTestDB(t)
+	registry, _ := recipe.NewEventRegistry()
+	store := sqlite.NewSpillStore(db, registry)
+	ctx := context.Background()
+
+	for _, id := range []string{"recipe-1", "recipe-2", "recipe-3"} {
+		env := events.Envelope{EventID: "event-" + id, CorrelationID: "request-" + id, SchemaVersion: 1}
+		if err := store.Push(ctx, domain.NewRecipeSubmitted(id, "Pancakes"), env); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+
+	if n, err := store.Len(ctx); err != nil || n != 3 {
+		t.Fatalf("Len() = %d, %v; expected 3", n, err)
+	}
+
+	popped, err := store.Pop(ctx, 2)
+	if err != nil {
+		t.Fatalf("Pop() error = %v", err)
+	}
+	if len(popped) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(popped))
+	}
+	submitted, ok := popped[0].Event.(*domain.RecipeSubmitted)
+	if !ok || submitted.RecipeID != "recipe-1" {
+		t.Errorf("expected decoded RecipeSubmitted of recipe-1, got %+v", popped[0].Event)
+	}
+	if popped[1].Envelope.EventID != "event-recipe-2" || popped[1].Envelope.CorrelationID != "request-recipe-2" {
+		t.Errorf("expected envelope to round-trip, got %+v", popped[1].Envelope)
+	}
+
+	rest, err := store.Pop(ctx, 10)
+	if err != nil {
+		t.Fatalf("Pop() error = %v", err)
+	}
+	if len(rest) != 1 || rest[0].Envelope.EventID != "event-recipe-3" {
+		t.Errorf("expected only recipe-3 left, got %+v", rest)
+	}
+	if n, _ := store.Len(ctx); n != 0 {
+		t.Errorf("expected empty spill, got %d", n)
+	}
+}
+
+func TestSpillStore_StoresTheEncodedSchemaVersion(t *testing.T) {
+	db, _ := openTestDB(t)
+	store := sqlite.NewSpillStore(db, newUpcastingRegistry(t))
+	ctx := context.Background()
+
+	// The event was upcast from v1 when it was read, its envelope still says v1
+	env := events.Envelope{EventID: "event-1", SchemaVersion: 1}
+	if err := store.Push(ctx, &renamedEvent{Title: "Pancakes"}, env); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	popped, err := store.Pop(ctx, 1)
+	if err != nil {
+		t.Fatalf("Pop() error = %v", err)
+	}
+	if len(popped) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(popped))
+	}
+	if renamed := popped[0].Event.(*renamedEvent); renamed.Title != "Pancakes" {
+		t.Errorf("expected the title to survive without a second upcast, got %+v", renamed)
+	}
+	if popped[0].Envelope.SchemaVersion != 2 {
+		t.Errorf("expected schema version 2, got %d", popped[0].Envelope.SchemaVersion)
+	}
+}
@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+)
+
+// WorkflowStore keeps the process manager's recipe workflows in SQLite
+type WorkflowStore struct {
+	db *sql.DB
+}
+
+// NewWorkflowStore creates a workflow store backed by db
+func NewWorkflowStore(db *sql.DB) *WorkflowStore {
+	return &WorkflowStore{db: db}
+}
+
+// Find returns the recipe's workflow
+func (s *WorkflowStore) Find(ctx context.Context, recipeID string) (*recipe.Workflow, error) {
+	var (
+		w            recipe.Workflow
+		status, step string
+		deadline     sql.NullString
+		pageID       sql.NullString
+		pageURL      sql.NullString
+		startedAt    string
+		updatedAt    string
+	)
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT recipe_id, run, status, step, step_deadline, timeout_id, export_page_id, export_page_url,
+		       pending_compensations, failure_reason, started_at, updated_at, version
+		FROM recipe_workflows WHERE recipe_id = ?`, recipeID,
+	).Scan(&w.RecipeID, &w.Run, &status, &step, &deadline, &w.TimeoutID, &pageID, &pageURL,
+		&w.PendingCompensations, &w.FailureReason, &startedAt, &updatedAt, &w.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, recipe.ErrWorkflowNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow of recipe %s: %w", recipeID, err)
+	}
+
+	w.Status = recipe.WorkflowStatus(status)
+	w.Step = domain.ProcessingStep(step)
+	if deadline.Valid {
+		if w.StepDeadline, err = parseTime(deadline.String); err != nil {
+			return nil, fmt.Errorf("workflow of recipe %s: invalid step_deadline: %w", recipeID, err)
+		}
+	}
+	if pageID.Valid {
+		w.Export = &domain.ExportReference{PageID: pageID.String, URL: pageURL.String}
+	}
+	if w.StartedAt, err = parseTime(startedAt); err != nil {
+		return nil, fmt.Errorf("workflow of recipe %s: invalid started_at: %w", recipeID, err)
+	}
+	if w.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, fmt.Errorf("workflow of recipe %s: invalid updated_at: %w", recipeID, err)
+	}
+
+	return &w, nil
+}
+
+// Save inserts or replaces the workflow if its version is the stored one
+func (s *WorkflowStore) Save(ctx context.Context, w *recipe.Workflow) error {
+	var deadline, pageID, pageURL sql.NullString
+	if !w.StepDeadline.IsZero() {
+		deadline = sql.NullString{String: formatTime(w.StepDeadline), Valid: true}
+	}
+	if w.Export != nil {
+		pageID = sql.NullString{String: w.Export.PageID, Valid: true}
+		pageURL = sql.NullString{String: w.Export.URL, Valid: true}
+	}
+
+	// Inserting ignores an existing row and updating only matches the
+	// stored version, so either way no row changes on a conflict
+	var (
+		result sql.Result
+		err    error
+	)
+	if w.Version == 0 {
+		result, err = s.db.ExecContext(ctx, `
+			INSERT INTO recipe_workflows (recipe_id, run, status, step, step_deadline, timeout_id, export_page_id,
+				export_page_url, pending_compensations, failure_reason, started_at, updated_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)
+			ON CONFLICT (recipe_id) DO NOTHING`,
+			w.RecipeID, w.Run, string(w.Status), string(w.Step), deadline, w.TimeoutID, pageID, pageURL,
+			w.PendingCompensations, w.FailureReason, formatTime(w.StartedAt), formatTime(w.UpdatedAt),
+		)
+	} else {
+		result, err = s.db.ExecContext(ctx, `
+			UPDATE recipe_workflows
+			SET run = ?, status = ?, step = ?, step_deadline = ?, timeout_id = ?, export_page_id = ?,
+				export_page_url = ?, pending_compensations = ?, failure_reason = ?, started_at = ?,
+				updated_at = ?, version = version + 1
+			WHERE recipe_id = ? AND version = ?`,
+			w.Run, string(w.Status), string(w.Step), deadline, w.TimeoutID, pageID, pageURL,
+			w.PendingCompensations, w.FailureReason, formatTime(w.StartedAt), formatTime(w.UpdatedAt),
+			w.RecipeID, w.Version,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save workflow of recipe %s: %w", w.RecipeID, err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to save workflow of recipe %s: %w", w.RecipeID, err)
+	}
+	if n == 0 {
+		return recipe.ErrWorkflowConflict
+	}
+
+	w.Version++
+	return nil
+}
+
+var _ recipe.WorkflowStore = (*WorkflowStore)(nil)
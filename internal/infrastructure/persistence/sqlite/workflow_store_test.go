@@ -0,0 +1,98 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/application/recipe"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/sqlite"
+	"testing"
+	"time"
+)
+
+func TestWorkflowStore_SaveAndFind(t *testing.T) {
+	db, _ := openTestDB(t)
+	store := sqlite.NewWorkflowStore(db)
+	ctx := context.Background()
+
+	if _, err := store.Find(ctx, "recipe-1"); !errors.Is(err, recipe.ErrWorkflowNotFound) {
+		t.Fatalf("expected ErrWorkflowNotFound, got %v", err)
+	}
+
+	now := time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+	workflow := &recipe.Workflow{
+		RecipeID:     "recipe-1",
+		Run:          1,
+		Status:       recipe.WorkflowRunning,
+		Step:         domain.StepParse,
+		StepDeadline: now.Add(10 * time.Minute),
+		TimeoutID:    "timeout-1",
+		StartedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := store.Save(ctx, workflow); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if workflow.Version != 1 {
+		t.Fatalf("expected version 1 after insert, got %d", workflow.Version)
+	}
+
+	workflow.Status = recipe.WorkflowCompensating
+	workflow.Step = domain.StepNotify
+	workflow.StepDeadline = time.Time{}
+	workflow.TimeoutID = ""
+	workflow.Export = &domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"}
+	workflow.PendingCompensations = 1
+	workflow.FailureReason = "notification failed"
+	if err := store.Save(ctx, workflow); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	found, err := store.Find(ctx, "recipe-1")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.Status != recipe.WorkflowCompensating || found.Step != domain.StepNotify || found.Version != 2 {
+		t.Errorf("unexpected workflow %+v", found)
+	}
+	if !found.StepDeadline.IsZero() || found.TimeoutID != "" || found.PendingCompensations != 1 {
+		t.Errorf("unexpected step state %+v", found)
+	}
+	if found.Export == nil || *found.Export != *workflow.Export || found.FailureReason != "notification failed" {
+		t.Errorf("unexpected export or failure %+v", found)
+	}
+	if !found.StartedAt.Equal(now) {
+		t.Errorf("StartedAt = %v, want %v", found.StartedAt, now)
+	}
+}
+
+func TestWorkflowStore_SaveDetectsConflicts(t *testing.T) {
+	db, _ := openTestDB(t)
+	store := sqlite.NewWorkflowStore(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	first := &recipe.Workflow{RecipeID: "recipe-1", Run: 1, Status: recipe.WorkflowRunning, Step: domain.StepParse, StartedAt: now, UpdatedAt: now}
+	if err := store.Save(ctx, first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	duplicate := &recipe.Workflow{RecipeID: "recipe-1", Run: 1, Status: recipe.WorkflowRunning, Step: domain.StepParse, StartedAt: now, UpdatedAt: now}
+	if err := store.Save(ctx, duplicate); !errors.Is(err, recipe.ErrWorkflowConflict) {
+		t.Fatalf("expected ErrWorkflowConflict inserting twice, got %v", err)
+	}
+
+	stale, _ := store.Find(ctx, "recipe-1")
+	first.Step = domain.StepExport
+	if err := store.Save(ctx, first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	stale.Step = domain.StepNotify
+	if err := store.Save(ctx, stale); !errors.Is(err, recipe.ErrWorkflowConflict) {
+		t.Fatalf("expected ErrWorkflowConflict saving a stale workflow, got %v", err)
+	}
+	if stale.Version != 1 {
+		t.Errorf("expected a conflicting save to keep the version, got %d", stale.Version)
+	}
+}
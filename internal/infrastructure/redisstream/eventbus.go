@@ -0,0 +1,774 @@
+package redisstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultKeyPrefix is the first segment of every stream key the bus uses
+	DefaultKeyPrefix = "recipes"
+	// DefaultMaxLen is the approximate number of entries kept per stream
+	DefaultMaxLen = 100_000
+	// DefaultMaxDeliver is how often a message is handed to a worker before it
+	// is dead-lettered without running the handler
+	DefaultMaxDeliver = 5
+	// DefaultWorkers is the number of messages handled concurrently per subscription
+	DefaultWorkers = 4
+	// DefaultBlock is how long a worker waits for new messages per read
+	DefaultBlock = time.Second
+	// DefaultClaimInterval is how often pending messages of crashed workers are reclaimed
+	DefaultClaimInterval = 30 * time.Second
+	// claimBatch is the number of messages reclaimed per XAUTOCLAIM call
+	claimBatch = 10
+)
+
+// ErrPatternUnsupported is returned when subscribing to a pattern such as "recipe.*"
+var ErrPatternUnsupported = errors.New("pattern subscriptions are not supported by the Redis Streams bus")
+
+// Stream entry fields; the payload is the registry's JSON encoding
+const (
+	fieldEventType = "type"
+	fieldEnvelope  = "envelope"
+	fieldPayload   = "payload"
+)
+
+// Config holds configuration for the Redis Streams event bus
+type Config struct {
+	Addr      string
+	Password  string
+	DB        int
+	KeyPrefix string
+	// MaxLen trims every stream to about this many entries on publish
+	// Entries trimmed before every group acknowledged them are lost
+	MaxLen int64
+	// MaxDeliver caps how often a message left pending by crashed workers is reclaimed
+	MaxDeliver int
+	// Consumer names this process within the consumer groups; defaults to host and PID
+	Consumer       string
+	Workers        int
+	Block          time.Duration
+	HandlerTimeout time.Duration
+	RetryPolicy    events.RetryPolicy
+	ClaimInterval  time.Duration
+	// ClaimMinIdle is how long a message stays pending before another worker
+	// may take it over; defaults to twice the longer of HandlerTimeout and
+	// RetryPolicy.MaxBackoff
+	ClaimMinIdle time.Duration
+	// DeadLetters receives messages handlers gave up on; defaults to an in-memory store
+	DeadLetters events.DeadLetterStore
+	// Store records every published event; optional
+	Store    events.EventStore
+	Producer string
+	// Middleware wraps every handler; nil means events.DefaultMiddleware
+	Middleware []events.HandlerMiddleware
+}
+
+// EventBus is an EventBus backed by Redis Streams
+// Every event type has its own stream and every subscription is a consumer
+// group named after the subscription, so processes subscribing under the
+// same name share the work and messages published while no consumer runs
+// are delivered once one starts
+// Handlers are retried in-process; a message is acknowledged once its handler
+// succeeded or it was dead-lettered, and messages left pending by a crashed
+// worker are reclaimed with XAUTOCLAIM
+type EventBus struct {
+	client   *redis.Client
+	registry *events.Registry
+	config   Config
+	logger   logger.Logger
+	mu       sync.Mutex
+	subs     []*subscription
+	started  bool
+	stopped  bool
+	quit     chan struct{}
+	wg       sync.WaitGroup
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// subscription is a handler bound to a consumer group
+type subscription struct {
+	name      string
+	eventType string
+	// handler is wrapped in the middleware chain
+	handler events.EventHandler
+	retry   events.RetryPolicy
+	timeout time.Duration
+	// quit is closed by Unsubscribe
+	quit chan struct{}
+	bus  *EventBus
+}
+
+func (s *subscription) Name() string      { return s.name }
+func (s *subscription) EventType() string { return s.eventType }
+
+// Unsubscribe stops reading and reclaiming; the consumer group is kept, so
+// subscribing the same name again resumes where it left off
+func (s *subscription) Unsubscribe() {
+	s.bus.unsubscribe(s)
+}
+
+func (s *subscription) unsubscribed() bool {
+	select {
+	case <-s.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewEventBus connects to Redis; consumer groups are created by Start
+// registry encodes published events and decodes delivered ones
+func NewEventBus(registry *events.Registry, log logger.Logger, cfg Config) (*EventBus, error) {
+	if cfg.KeyPrefix == "" {
+		cfg.KeyPrefix = DefaultKeyPrefix
+	}
+	if cfg.MaxLen <= 0 {
+		cfg.MaxLen = DefaultMaxLen
+	}
+	if cfg.MaxDeliver <= 0 {
+		cfg.MaxDeliver = DefaultMaxDeliver
+	}
+	if cfg.Consumer == "" {
+		cfg.Consumer = defaultConsumer()
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkers
+	}
+	if cfg.Block <= 0 {
+		cfg.Block = DefaultBlock
+	}
+	if cfg.HandlerTimeout <= 0 {
+		cfg.HandlerTimeout = events.DefaultHandlerTimeout
+	}
+	if cfg.RetryPolicy.MaxAttempts <= 0 {
+		cfg.RetryPolicy = events.DefaultRetryPolicy()
+	}
+	if cfg.ClaimInterval <= 0 {
+		cfg.ClaimInterval = DefaultClaimInterval
+	}
+	if cfg.ClaimMinIdle <= 0 {
+		cfg.ClaimMinIdle = 2 * max(cfg.HandlerTimeout, cfg.RetryPolicy.MaxBackoff)
+	}
+	if cfg.DeadLetters == nil {
+		cfg.DeadLetters = events.NewMemoryDeadLetterStore()
+	}
+	if cfg.Producer == "" {
+		cfg.Producer = events.DefaultProducer
+	}
+	if cfg.Middleware == nil {
+		cfg.Middleware = events.DefaultMiddleware(log)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:       cfg.Addr,
+		Password:   cfg.Password,
+		DB:         cfg.DB,
+		ClientName: cfg.Producer,
+	})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis at %s: %w", cfg.Addr, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &EventBus{
+		client:   client,
+		registry: registry,
+		config:   cfg,
+		logger:   log,
+		quit:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}, nil
+}
+
+// Start creates the consumer groups and starts consuming for every subscription
+func (b *EventBus) Start(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.ctx, b.cancel = context.WithCancel(ctx)
+	b.started = true
+	for _, sub := range b.subs {
+		if err := b.consume(sub); err != nil {
+			return err
+		}
+	}
+
+	b.logger.Info("Event bus started",
+		logger.String("consumer", b.config.Consumer),
+		logger.Int("subscriptions", len(b.subs)),
+	)
+	return nil
+}
+
+// Stop stops reading, lets running handlers finish until ctx is done and
+// closes the connection
+// Messages that were not acknowledged are reclaimed after ClaimMinIdle
+func (b *EventBus) Stop(ctx context.Context) error {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return nil
+	}
+	b.stopped = true
+	close(b.quit)
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	var stopErr error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// Out of time: interrupt running handlers, their messages stay pending
+		b.cancel()
+		<-done
+		stopErr = fmt.Errorf("%w: handlers interrupted, their messages will be reclaimed", events.ErrDrainIncomplete)
+	}
+	b.cancel()
+
+	if err := b.client.Close(); err != nil {
+		b.logger.Error("Failed to close Redis connection", logger.Error(err))
+	}
+
+	b.logger.Info("Event bus stopped")
+	return stopErr
+}
+
+// Publish appends the event to the stream of its type
+func (b *EventBus) Publish(ctx context.Context, event events.Event) error {
+	if b.stopping() {
+		return events.ErrBusStopped
+	}
+
+	env := events.NewEnvelope(ctx, event, b.config.Producer)
+	if b.config.Store != nil {
+		if err := b.config.Store.Append(ctx, event, env); err != nil {
+			return fmt.Errorf("failed to store %s event: %w", event.EventType(), err)
+		}
+	}
+
+	if err := b.publish(ctx, b.eventStream(event.EventType()), event, env); err != nil {
+		return err
+	}
+
+	b.logger.Debug("Event published",
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+	)
+	return nil
+}
+
+// Subscribe registers handler under its function name, see events.Subscribe
+// Subscription errors are logged; use SubscribeNamed to handle them
+func (b *EventBus) Subscribe(eventType string, handler events.EventHandler) {
+	if _, err := events.Subscribe(b, eventType, handler); err != nil {
+		b.logger.Error("Failed to subscribe handler", logger.String("event_type", eventType), logger.Error(err))
+	}
+}
+
+// SubscribeNamed registers a handler for a specific event type
+// The subscription name becomes the consumer group name, so it must be
+// unique on the bus
+// Patterns such as "recipe.*" are not supported, every event type is its own
+// stream
+func (b *EventBus) SubscribeNamed(eventType, name string, handler events.EventHandler, opts ...events.SubscribeOption) (events.Subscription, error) {
+	if events.IsPattern(eventType) {
+		return nil, fmt.Errorf("%w: %s", ErrPatternUnsupported, eventType)
+	}
+
+	cfg := events.ApplyNamedSubscribeOptions(name, handler, b.config.RetryPolicy, opts...)
+	sub := &subscription{
+		name:      cfg.Name,
+		eventType: eventType,
+		handler:   events.WrapHandler(handler, b.config.Middleware, cfg.Middleware),
+		retry:     cfg.Retry,
+		timeout:   cfg.HandlerTimeout(b.config.HandlerTimeout),
+		quit:      make(chan struct{}),
+		bus:       b,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.findSubscription(sub.name) != nil {
+		return nil, fmt.Errorf("%w: %s", events.ErrSubscriptionExists, sub.name)
+	}
+
+	if b.started && !b.stopped {
+		if err := b.consume(sub); err != nil {
+			return nil, err
+		}
+	}
+	b.subs = append(b.subs, sub)
+
+	b.logger.Info("Handler subscribed",
+		logger.String("event_type", eventType),
+		logger.String("handler", sub.name),
+	)
+	return sub, nil
+}
+
+// unsubscribe stops the workers of sub; a message whose handler is being
+// retried stays pending and is reclaimed once the group is consumed again
+func (b *EventBus) unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if sub.unsubscribed() {
+		return
+	}
+	close(sub.quit)
+	for i, s := range b.subs {
+		if s == sub {
+			b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+			break
+		}
+	}
+
+	b.logger.Info("Handler unsubscribed",
+		logger.String("event_type", sub.eventType),
+		logger.String("handler", sub.name),
+	)
+}
+
+// Ping reports whether Redis is reachable; it returns ErrBusStopped once
+// Stop was called
+func (b *EventBus) Ping(ctx context.Context) error {
+	if b.stopping() {
+		return events.ErrBusStopped
+	}
+	return b.client.Ping(ctx).Err()
+}
+
+// DeadLetters returns the events handlers gave up on, oldest first
+func (b *EventBus) DeadLetters(ctx context.Context) ([]events.DeadLetter, error) {
+	return b.config.DeadLetters.List(ctx)
+}
+
+// Redrive delivers a dead letter to its handler again, with fresh attempts
+// The message goes to the subscription's redrive stream, so other
+// subscribers of the event type do not see it again
+func (b *EventBus) Redrive(ctx context.Context, id string) error {
+	letter, err := b.config.DeadLetters.Remove(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := b.publish(ctx, b.redriveStream(letter.Handler), letter.Event, letter.Envelope); err != nil {
+		_ = b.config.DeadLetters.Add(ctx, letter)
+		return err
+	}
+
+	b.logger.Info("Dead letter redriven",
+		logger.String("dead_letter_id", id),
+		logger.String("event_type", letter.Event.EventType()),
+		logger.String("handler", letter.Handler),
+	)
+	return nil
+}
+
+// consume creates the consumer groups of sub and starts its workers and
+// reclaimer; callers hold b.mu
+func (b *EventBus) consume(sub *subscription) error {
+	for _, stream := range b.streams(sub) {
+		// A new group starts at the beginning of the stream, like a new
+		// JetStream consumer, so nothing published before it existed is missed
+		err := b.client.XGroupCreateMkStream(b.ctx, stream, sub.name, "0").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return fmt.Errorf("failed to create consumer group %s on %s: %w", sub.name, stream, err)
+		}
+	}
+
+	b.wg.Add(b.config.Workers + 1)
+	for i := 0; i < b.config.Workers; i++ {
+		go b.read(sub)
+	}
+	go b.reclaim(sub)
+
+	return nil
+}
+
+// read hands new messages of sub to its handler until the bus stops
+func (b *EventBus) read(sub *subscription) {
+	defer b.wg.Done()
+
+	streams := b.streams(sub)
+	for !b.quitting(sub) {
+		result, err := b.client.XReadGroup(b.ctx, &redis.XReadGroupArgs{
+			Group:    sub.name,
+			Consumer: b.config.Consumer,
+			Streams:  append(streams, ">", ">"),
+			Count:    1,
+			Block:    b.config.Block,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if b.quitting(sub) {
+				return
+			}
+			b.logger.Error("Failed to read stream",
+				logger.String("handler", sub.name),
+				logger.Error(err),
+			)
+			b.sleep(sub, b.config.Block)
+			continue
+		}
+
+		for _, stream := range result {
+			for _, msg := range stream.Messages {
+				if sub.unsubscribed() {
+					// Read while unsubscribing; left pending for the next
+					// consumer of the group to reclaim
+					continue
+				}
+				b.handle(sub, stream.Stream, msg)
+			}
+		}
+	}
+}
+
+// reclaim periodically takes over messages other workers left pending for
+// longer than ClaimMinIdle, typically because their process crashed
+func (b *EventBus) reclaim(sub *subscription) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.config.ClaimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.quit:
+			return
+		case <-sub.quit:
+			return
+		case <-ticker.C:
+		}
+
+		for _, stream := range b.streams(sub) {
+			if err := b.reclaimStream(sub, stream); err != nil && !b.quitting(sub) {
+				b.logger.Error("Failed to reclaim pending messages",
+					logger.String("stream", stream),
+					logger.String("handler", sub.name),
+					logger.Error(err),
+				)
+			}
+		}
+	}
+}
+
+func (b *EventBus) reclaimStream(sub *subscription, stream string) error {
+	start := "0-0"
+	for !b.quitting(sub) {
+		msgs, next, err := b.client.XAutoClaim(b.ctx, &redis.XAutoClaimArgs{
+			Stream:   stream,
+			Group:    sub.name,
+			Consumer: b.config.Consumer,
+			// A handler with a longer timeout must not be reclaimed while it runs
+			MinIdle: max(b.config.ClaimMinIdle, 2*sub.timeout),
+			Start:   start,
+			Count:   claimBatch,
+		}).Result()
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range msgs {
+			b.handleClaimed(sub, stream, msg)
+		}
+
+		if next == "0-0" || len(msgs) == 0 {
+			return nil
+		}
+		start = next
+	}
+	return nil
+}
+
+// handleClaimed handles a reclaimed message unless it was delivered too
+// often, which means it keeps crashing the workers
+func (b *EventBus) handleClaimed(sub *subscription, stream string, msg redis.XMessage) {
+	pending, err := b.client.XPendingExt(b.ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  sub.name,
+		Start:  msg.ID,
+		End:    msg.ID,
+		Count:  1,
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		b.handle(sub, stream, msg)
+		return
+	}
+
+	deliveries := int(pending[0].RetryCount)
+	b.logger.Warn("Reclaimed pending message",
+		logger.String("stream", stream),
+		logger.String("message_id", msg.ID),
+		logger.String("handler", sub.name),
+		logger.Int("deliveries", deliveries),
+	)
+
+	if deliveries <= b.config.MaxDeliver {
+		b.handle(sub, stream, msg)
+		return
+	}
+
+	event, env, err := b.decode(msg)
+	if err != nil {
+		b.drop(sub, stream, msg, err)
+		return
+	}
+	b.deadLetter(sub, event, env, deliveries, fmt.Errorf("abandoned after %d deliveries without acknowledgement", deliveries))
+	b.ack(sub, stream, msg.ID)
+}
+
+// handle runs the handler for one message, retrying in-process, and
+// acknowledges it once it succeeded or was dead-lettered
+func (b *EventBus) handle(sub *subscription, stream string, msg redis.XMessage) {
+	event, env, err := b.decode(msg)
+	if err != nil {
+		b.drop(sub, stream, msg, err)
+		return
+	}
+
+	for attempt := 1; ; attempt++ {
+		handlerCtx := events.WithHandlerInfo(events.ContextWithEnvelope(b.ctx, env), events.HandlerInfo{
+			Name:        sub.name,
+			Attempt:     attempt,
+			MaxAttempts: sub.retry.MaxAttempts,
+		})
+		handlerCtx, cancel := context.WithTimeout(handlerCtx, sub.timeout)
+		err := events.CallHandler(handlerCtx, sub.handler, event)
+		cancel()
+		events.LogPanic(handlerCtx, b.logger, event, err)
+
+		if err == nil {
+			b.ack(sub, stream, msg.ID)
+			return
+		}
+
+		if !events.IsRetryable(err) || attempt >= sub.retry.MaxAttempts {
+			b.deadLetter(sub, event, env, attempt, err)
+			b.ack(sub, stream, msg.ID)
+			return
+		}
+
+		// Keep the message ours while waiting, then retry unless the bus stops;
+		// an unacknowledged message is reclaimed after the restart
+		b.touch(sub, stream, msg.ID)
+		if !b.sleep(sub, sub.retry.Backoff(attempt)) {
+			return
+		}
+		b.touch(sub, stream, msg.ID)
+	}
+}
+
+// touch resets the idle time of a pending message so it is not reclaimed
+// while its handler is being retried
+func (b *EventBus) touch(sub *subscription, stream, id string) {
+	err := b.client.XClaimJustID(context.WithoutCancel(b.ctx), &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    sub.name,
+		Consumer: b.config.Consumer,
+		Messages: []string{id},
+	}).Err()
+	if err != nil {
+		b.logger.Warn("Failed to refresh pending message",
+			logger.String("stream", stream),
+			logger.String("message_id", id),
+			logger.Error(err),
+		)
+	}
+}
+
+// ack removes a message from the group's pending list
+func (b *EventBus) ack(sub *subscription, stream, id string) {
+	if err := b.client.XAck(context.WithoutCancel(b.ctx), stream, sub.name, id).Err(); err != nil {
+		// The message is reclaimed and handled again after ClaimMinIdle
+		b.logger.Warn("Failed to acknowledge message",
+			logger.String("stream", stream),
+			logger.String("message_id", id),
+			logger.Error(err),
+		)
+	}
+}
+
+// drop acknowledges a message that cannot be read; redelivering cannot fix it
+func (b *EventBus) drop(sub *subscription, stream string, msg redis.XMessage, err error) {
+	b.logger.Error("Dropping undecodable message",
+		logger.String("stream", stream),
+		logger.String("message_id", msg.ID),
+		logger.String("handler", sub.name),
+		logger.Error(err),
+	)
+	b.ack(sub, stream, msg.ID)
+}
+
+func (b *EventBus) deadLetter(sub *subscription, event events.Event, env events.Envelope, attempts int, cause error) {
+	letter := events.DeadLetter{
+		ID:        uuid.New().String(),
+		Event:     event,
+		Envelope:  env,
+		Handler:   sub.name,
+		Attempts:  attempts,
+		LastError: cause.Error(),
+		FailedAt:  time.Now(),
+	}
+
+	if err := b.config.DeadLetters.Add(context.WithoutCancel(b.ctx), letter); err != nil {
+		// Last resort: the event only survives in the logs
+		b.logger.Error("Failed to store dead letter",
+			logger.String("event_type", event.EventType()),
+			logger.String("handler", sub.name),
+			logger.Any("event", event),
+			logger.Error(err),
+		)
+		return
+	}
+
+	b.logger.Warn("Event dead-lettered",
+		logger.String("dead_letter_id", letter.ID),
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+		logger.String("handler", sub.name),
+		logger.Int("attempts", attempts),
+		logger.String("reason", letter.LastError),
+	)
+}
+
+func (b *EventBus) publish(ctx context.Context, stream string, event events.Event, env events.Envelope) error {
+	payload, version, err := b.registry.Encode(event)
+	if err != nil {
+		return err
+	}
+	env.SchemaVersion = version
+
+	envelope, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope of event %s: %w", env.EventID, err)
+	}
+
+	err = b.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: b.config.MaxLen,
+		Approx: true,
+		Values: []any{
+			fieldEventType, event.EventType(),
+			fieldEnvelope, string(envelope),
+			fieldPayload, string(payload),
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.EventType(), err)
+	}
+	return nil
+}
+
+func (b *EventBus) decode(msg redis.XMessage) (events.Event, events.Envelope, error) {
+	var env events.Envelope
+	eventType, _ := msg.Values[fieldEventType].(string)
+	envelope, _ := msg.Values[fieldEnvelope].(string)
+	payload, _ := msg.Values[fieldPayload].(string)
+
+	if err := json.Unmarshal([]byte(envelope), &env); err != nil {
+		return nil, env, fmt.Errorf("invalid envelope: %w", err)
+	}
+
+	event, err := b.registry.Decode(eventType, env.SchemaVersion, []byte(payload))
+	return event, env, err
+}
+
+// streams returns the streams sub reads: its event type and its redrives
+func (b *EventBus) streams(sub *subscription) []string {
+	return []string{b.eventStream(sub.eventType), b.redriveStream(sub.name)}
+}
+
+func (b *EventBus) eventStream(eventType string) string {
+	return b.config.KeyPrefix + ":events:" + eventType
+}
+
+func (b *EventBus) redriveStream(group string) string {
+	return b.config.KeyPrefix + ":redrive:" + group
+}
+
+// sleep waits for d and reports false if the bus stopped or sub was
+// unsubscribed meanwhile
+func (b *EventBus) sleep(sub *subscription, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-b.quit:
+		return false
+	case <-sub.quit:
+		return false
+	}
+}
+
+// findSubscription looks up a subscription by name; callers hold b.mu
+func (b *EventBus) findSubscription(name string) *subscription {
+	for _, sub := range b.subs {
+		if sub.name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+func (b *EventBus) stopping() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.stopped
+}
+
+// quitting reports whether the workers of sub should exit
+func (b *EventBus) quitting(sub *subscription) bool {
+	select {
+	case <-b.quit:
+		return true
+	default:
+		return sub.unsubscribed()
+	}
+}
+
+// defaultConsumer names the consumer after the host and process, so a
+// restarted process does not inherit the pending messages of its predecessor
+func defaultConsumer() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return host + "-" + strconv.Itoa(os.Getpid())
+}
+
+var (
+	_ events.EventBus        = (*EventBus)(nil)
+	_ events.DeadLetterQueue = (*EventBus)(nil)
+)
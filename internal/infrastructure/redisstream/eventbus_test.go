@@ -0,0 +1,435 @@
+package redisstream_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/infrastructure/redisstream"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+type orderPlaced struct {
+	OrderID string    `json:"order_id"`
+	At      time.Time `json:"at"`
+}
+
+func (e *orderPlaced) EventType() string     { return "order.placed" }
+func (e *orderPlaced) OccurredAt() time.Time { return e.At }
+
+const orderStream = "recipes:events:order.placed"
+
+func newRegistry(t *testing.T) *events.Registry {
+	t.Helper()
+
+	registry := events.NewRegistry()
+	if err := registry.Register(func() events.Event { return &orderPlaced{} }); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	return registry
+}
+
+// newBus creates a bus with fast retries and reclaims; the caller subscribes and starts it
+func newBus(t *testing.T, addr string, configure ...func(*redisstream.Config)) *redisstream.EventBus {
+	t.Helper()
+
+	cfg := redisstream.Config{
+		Addr:           addr,
+		Consumer:       "test",
+		Workers:        1,
+		Block:          10 * time.Millisecond,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    events.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 1},
+		ClaimInterval:  10 * time.Millisecond,
+		ClaimMinIdle:   50 * time.Millisecond,
+	}
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+
+	bus, err := redisstream.NewEventBus(newRegistry(t), logger.NewNoopLogger(), cfg)
+	if err != nil {
+		t.Fatalf("NewEventBus() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	return bus
+}
+
+func newClient(t *testing.T, addr string) *redis.Client {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func start(t *testing.T, bus *redisstream.EventBus) {
+	t.Helper()
+
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+}
+
+// waitFor polls cond until it holds or the test times out
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func pendingCount(t *testing.T, client *redis.Client, group string) int64 {
+	t.Helper()
+
+	pending, err := client.XPending(context.Background(), orderStream, group).Result()
+	if err != nil {
+		t.Fatalf("XPending() error = %v", err)
+	}
+	return pending.Count
+}
+
+func TestEventBus_DeliversEventWithEnvelopeAndAcks(t *testing.T) {
+	mr := miniredis.RunT(t)
+	bus := newBus(t, mr.Addr())
+
+	received := make(chan events.Envelope, 1)
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		if event.(*orderPlaced).OrderID != "order-1" {
+			t.Errorf("unexpected event %+v", event)
+		}
+		env, _ := events.EnvelopeFromContext(ctx)
+		received <- env
+		return nil
+	})
+	start(t, bus)
+
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+	if err := bus.Publish(ctx, &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case env := <-received:
+		if env.CorrelationID != "request-1" || env.EventID == "" {
+			t.Errorf("unexpected envelope %+v", env)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	client := newClient(t, mr.Addr())
+	waitFor(t, "ack", func() bool { return pendingCount(t, client, "ship-order") == 0 })
+}
+
+func TestEventBus_RetriesHandlerUntilSuccess(t *testing.T) {
+	mr := miniredis.RunT(t)
+	bus := newBus(t, mr.Addr())
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		if calls.Add(1) < 3 {
+			return errors.New("warehouse unavailable")
+		}
+		return nil
+	})
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	client := newClient(t, mr.Addr())
+	waitFor(t, "third attempt", func() bool { return calls.Load() == 3 })
+	waitFor(t, "ack", func() bool { return pendingCount(t, client, "ship-order") == 0 })
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 0 {
+		t.Errorf("expected no dead letters, got %d", len(letters))
+	}
+}
+
+func TestEventBus_DeadLettersAfterMaxAttemptsAndRedrives(t *testing.T) {
+	mr := miniredis.RunT(t)
+	bus := newBus(t, mr.Addr())
+
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		if healthy.Load() {
+			return nil
+		}
+		return errors.New("warehouse unavailable")
+	})
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	var letters []events.DeadLetter
+	waitFor(t, "dead letter", func() bool {
+		letters, _ = bus.DeadLetters(context.Background())
+		return len(letters) == 1
+	})
+	if letters[0].Attempts != 3 || letters[0].Handler != "ship-order" {
+		t.Errorf("unexpected dead letter %+v", letters[0])
+	}
+
+	healthy.Store(true)
+	if err := bus.Redrive(context.Background(), letters[0].ID); err != nil {
+		t.Fatalf("Redrive() error = %v", err)
+	}
+	waitFor(t, "redelivery", func() bool { return calls.Load() == 4 })
+}
+
+func TestEventBus_PermanentErrorsAreNotRetried(t *testing.T) {
+	mr := miniredis.RunT(t)
+	bus := newBus(t, mr.Addr())
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return events.Permanent(errors.New("order does not exist"))
+	})
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	waitFor(t, "dead letter", func() bool {
+		letters, _ := bus.DeadLetters(context.Background())
+		return len(letters) == 1
+	})
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestEventBus_ConsumerGroupReceivesEventsPublishedWhileDown(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	// Create the consumer group, then go away
+	first := newBus(t, mr.Addr())
+	first.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error { return nil })
+	start(t, first)
+	if err := first.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	publisher := newBus(t, mr.Addr())
+	if err := publisher.Publish(context.Background(), &orderPlaced{OrderID: "order-2"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	received := make(chan string, 1)
+	second := newBus(t, mr.Addr())
+	second.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		received <- event.(*orderPlaced).OrderID
+		return nil
+	})
+	start(t, second)
+
+	select {
+	case id := <-received:
+		if id != "order-2" {
+			t.Errorf("expected order-2, got %s", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
+
+// crash delivers the pending message to a consumer that never acknowledges it
+func crash(t *testing.T, client *redis.Client, group string) {
+	t.Helper()
+
+	err := client.XGroupCreateMkStream(context.Background(), orderStream, group, "0").Err()
+	if err != nil {
+		t.Fatalf("XGroupCreateMkStream() error = %v", err)
+	}
+	_, err = client.XReadGroup(context.Background(), &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: "crashed-worker",
+		Streams:  []string{orderStream, ">"},
+		Count:    1,
+	}).Result()
+	if err != nil {
+		t.Fatalf("XReadGroup() error = %v", err)
+	}
+}
+
+func TestEventBus_ReclaimsMessagesOfCrashedWorkers(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := newClient(t, mr.Addr())
+
+	publisher := newBus(t, mr.Addr())
+	if err := publisher.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	crash(t, client, "ship-order")
+
+	received := make(chan string, 1)
+	bus := newBus(t, mr.Addr())
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		received <- event.(*orderPlaced).OrderID
+		return nil
+	})
+	start(t, bus)
+
+	select {
+	case id := <-received:
+		if id != "order-1" {
+			t.Errorf("expected order-1, got %s", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the reclaimed message")
+	}
+	waitFor(t, "ack", func() bool { return pendingCount(t, client, "ship-order") == 0 })
+}
+
+func TestEventBus_DeadLettersMessagesReclaimedTooOften(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := newClient(t, mr.Addr())
+
+	publisher := newBus(t, mr.Addr())
+	if err := publisher.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	crash(t, client, "ship-order")
+
+	var calls atomic.Int32
+	bus := newBus(t, mr.Addr(), func(cfg *redisstream.Config) { cfg.MaxDeliver = 1 })
+	bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return nil
+	})
+	start(t, bus)
+
+	var letters []events.DeadLetter
+	waitFor(t, "dead letter", func() bool {
+		letters, _ = bus.DeadLetters(context.Background())
+		return len(letters) == 1
+	})
+	if letters[0].Attempts != 2 {
+		t.Errorf("expected 2 deliveries, got %d", letters[0].Attempts)
+	}
+	if got := calls.Load(); got != 0 {
+		t.Errorf("expected the handler not to run, got %d calls", got)
+	}
+	waitFor(t, "ack", func() bool { return pendingCount(t, client, "ship-order") == 0 })
+}
+
+func TestEventBus_TrimsStreams(t *testing.T) {
+	mr := miniredis.RunT(t)
+	bus := newBus(t, mr.Addr(), func(cfg *redisstream.Config) { cfg.MaxLen = 5 })
+
+	for range 20 {
+		if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	length, err := newClient(t, mr.Addr()).XLen(context.Background(), orderStream).Result()
+	if err != nil {
+		t.Fatalf("XLen() error = %v", err)
+	}
+	if length != 5 {
+		t.Errorf("expected 5 entries, got %d", length)
+	}
+}
+
+func TestEventBus_PublishAfterStop(t *testing.T) {
+	mr := miniredis.RunT(t)
+	bus := newBus(t, mr.Addr())
+	start(t, bus)
+
+	if err := bus.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if err := bus.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"})
+	if !errors.Is(err, events.ErrBusStopped) {
+		t.Errorf("expected ErrBusStopped, got %v", err)
+	}
+	if err := bus.Ping(context.Background()); !errors.Is(err, events.ErrBusStopped) {
+		t.Errorf("expected Ping() to return ErrBusStopped, got %v", err)
+	}
+}
+
+func TestEventBus_UnsubscribeKeepsConsumerGroup(t *testing.T) {
+	mr := miniredis.RunT(t)
+	bus := newBus(t, mr.Addr())
+
+	var before atomic.Int32
+	sub, err := bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		before.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	start(t, bus)
+
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	waitFor(t, "first delivery", func() bool { return before.Load() == 1 })
+
+	sub.Unsubscribe()
+	if err := bus.Publish(context.Background(), &orderPlaced{OrderID: "order-2"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	// Subscribing the name again resumes the consumer group
+	received := make(chan string, 1)
+	if _, err := bus.SubscribeNamed("order.placed", "ship-order", func(ctx context.Context, event events.Event) error {
+		received <- event.(*orderPlaced).OrderID
+		return nil
+	}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	select {
+	case orderID := <-received:
+		if orderID != "order-2" {
+			t.Errorf("expected order-2, got %s", orderID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+	if got := before.Load(); got != 1 {
+		t.Errorf("expected unsubscribed handler to run once, got %d", got)
+	}
+}
+
+func TestEventBus_RejectsPatternsAndTakenNames(t *testing.T) {
+	bus := newBus(t, miniredis.RunT(t).Addr())
+	noop := func(ctx context.Context, event events.Event) error { return nil }
+
+	if _, err := bus.SubscribeNamed("order.*", "audit", noop); !errors.Is(err, redisstream.ErrPatternUnsupported) {
+		t.Errorf("expected ErrPatternUnsupported, got %v", err)
+	}
+	if _, err := bus.SubscribeNamed("order.placed", "ship-order", noop); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if _, err := bus.SubscribeNamed("order.placed", "ship-order", noop); !errors.Is(err, events.ErrSubscriptionExists) {
+		t.Errorf("expected ErrSubscriptionExists, got %v", err)
+	}
+}
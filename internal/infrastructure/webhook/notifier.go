@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"recipe-processor/internal/domain"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the default timeout for a single webhook request
+	DefaultTimeout = 10 * time.Second
+
+	// EventRecipeExported is the event field of the notification payload
+	EventRecipeExported = "recipe.exported"
+)
+
+// NotifierConfig holds configuration for the webhook notifier
+type NotifierConfig struct {
+	URL     string
+	Timeout time.Duration
+}
+
+// Notifier posts a JSON notification to a webhook when a recipe was exported
+type Notifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewNotifier creates a new webhook notifier
+func NewNotifier(cfg NotifierConfig) *Notifier {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Notifier{
+		url:        cfg.URL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Notification is the body posted to the webhook
+type Notification struct {
+	Event    string `json:"event"`
+	RecipeID string `json:"recipe_id"`
+	PageID   string `json:"page_id"`
+	URL      string `json:"url"`
+}
+
+// StatusError is returned when the webhook responds with a non-2xx status
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook responded with status %d", e.StatusCode)
+}
+
+// Retryable reports whether the notification may succeed later
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// Notify posts the export of a recipe to the webhook
+func (n *Notifier) Notify(ctx context.Context, recipeID string, ref domain.ExportReference) error {
+	payload, err := json.Marshal(Notification{
+		Event:    EventRecipeExported,
+		RecipeID: recipeID,
+		PageID:   ref.PageID,
+		URL:      ref.URL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
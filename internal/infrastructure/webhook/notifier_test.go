@@ -0,0 +1,67 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/webhook"
+	"recipe-processor/internal/shared/events"
+	"testing"
+)
+
+func TestNotifier_Notify_PostsExport(t *testing.T) {
+	var got webhook.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := webhook.NewNotifier(webhook.NotifierConfig{URL: server.URL})
+	err := notifier.Notify(context.Background(), "recipe-1", domain.ExportReference{PageID: "page-1", URL: "https://notion.so/page-1"})
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	want := webhook.Notification{Event: "recipe.exported", RecipeID: "recipe-1", PageID: "page-1", URL: "https://notion.so/page-1"}
+	if got != want {
+		t.Errorf("posted %+v, want %+v", got, want)
+	}
+}
+
+func TestNotifier_Notify_StatusErrors(t *testing.T) {
+	tests := []struct {
+		status    int
+		retryable bool
+	}{
+		{http.StatusBadRequest, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusServiceUnavailable, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := webhook.NewNotifier(webhook.NotifierConfig{URL: server.URL}).
+				Notify(context.Background(), "recipe-1", domain.ExportReference{PageID: "page-1"})
+
+			var statusErr *webhook.StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+				t.Fatalf("expected *StatusError with %d, got %v", tt.status, err)
+			}
+			if events.IsRetryable(err) != tt.retryable {
+				t.Errorf("IsRetryable() = %v, want %v", events.IsRetryable(err), tt.retryable)
+			}
+		})
+	}
+}
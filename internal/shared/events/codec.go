@@ -0,0 +1,161 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrUnknownEventType is returned for event types that were never registered
+	ErrUnknownEventType = errors.New("unknown event type")
+	// ErrEventTypeRegistered is returned when an event type is registered twice
+	ErrEventTypeRegistered = errors.New("event type already registered")
+	// ErrUnsupportedSchemaVersion is returned for payloads that cannot be
+	// brought to the registered schema version
+	ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+)
+
+// Decoder restores events from their wire form
+type Decoder interface {
+	// Decode restores an event of eventType from a payload written with
+	// schema version; version 0 means DefaultSchemaVersion
+	Decode(eventType string, version int, payload []byte) (Event, error)
+}
+
+// Encoder writes events in their wire form
+type Encoder interface {
+	// Encode returns the JSON payload of event and its schema version
+	Encode(event Event) ([]byte, int, error)
+}
+
+// Upcaster migrates a JSON payload from one schema version to the next
+type Upcaster func(payload []byte) ([]byte, error)
+
+// RegisterOption configures a registered event type
+type RegisterOption func(*registeredType)
+
+// WithUpcaster migrates payloads of fromVersion to fromVersion+1
+// Register one upcaster per retired version so old payloads can still be read
+func WithUpcaster(fromVersion int, upcast Upcaster) RegisterOption {
+	return func(rt *registeredType) {
+		rt.upcasters[fromVersion] = upcast
+	}
+}
+
+type registeredType struct {
+	version   int
+	newEvent  func() Event
+	upcasters map[int]Upcaster
+}
+
+// Registry maps event types to their JSON codecs
+// Every event that leaves the process (outbox, dead letters, brokers) must
+// be registered so it can be read back
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]*registeredType
+}
+
+// NewRegistry creates an empty event registry
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[string]*registeredType)}
+}
+
+// Register adds the event type created by newEvent
+// The event type and current schema version are taken from the event itself,
+// see Versioned; newEvent must return a pointer that JSON can decode into
+func (r *Registry) Register(newEvent func() Event, opts ...RegisterOption) error {
+	prototype := newEvent()
+	rt := &registeredType{
+		version:   schemaVersion(prototype),
+		newEvent:  newEvent,
+		upcasters: make(map[int]Upcaster),
+	}
+	for _, opt := range opts {
+		opt(rt)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	eventType := prototype.EventType()
+	if _, ok := r.types[eventType]; ok {
+		return fmt.Errorf("%w: %s", ErrEventTypeRegistered, eventType)
+	}
+	r.types[eventType] = rt
+
+	return nil
+}
+
+// Encode returns the JSON payload of event and its schema version
+func (r *Registry) Encode(event Event) ([]byte, int, error) {
+	rt, err := r.lookup(event.EventType())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+
+	return payload, rt.version, nil
+}
+
+// Decode restores an event, upcasting payloads written with older schema versions
+func (r *Registry) Decode(eventType string, version int, payload []byte) (Event, error) {
+	rt, err := r.lookup(eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == 0 {
+		version = DefaultSchemaVersion
+	}
+	if version > rt.version {
+		return nil, fmt.Errorf("%w: %s v%d, newest known is v%d",
+			ErrUnsupportedSchemaVersion, eventType, version, rt.version)
+	}
+
+	for ; version < rt.version; version++ {
+		upcast, ok := rt.upcasters[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s v%d has no upcaster", ErrUnsupportedSchemaVersion, eventType, version)
+		}
+		if payload, err = upcast(payload); err != nil {
+			return nil, fmt.Errorf("failed to upcast %s from v%d: %w", eventType, version, err)
+		}
+	}
+
+	event := rt.newEvent()
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", eventType, err)
+	}
+
+	return event, nil
+}
+
+// EventTypes returns the registered event types in sorted order
+func (r *Registry) EventTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(r.types))
+}
+
+func (r *Registry) lookup(eventType string) (*registeredType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rt, ok := r.types[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+	return rt, nil
+}
+
+var _ Decoder = (*Registry)(nil)
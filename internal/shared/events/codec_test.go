@@ -0,0 +1,123 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"slices"
+	"testing"
+	"time"
+)
+
+// renamedEvent is at schema v2; v1 called the field "name"
+type renamedEvent struct {
+	Title string `json:"title"`
+}
+
+func (e *renamedEvent) EventType() string     { return "test.renamed" }
+func (e *renamedEvent) OccurredAt() time.Time { return time.Time{} }
+func (e *renamedEvent) SchemaVersion() int    { return 2 }
+
+// renameField upcasts renamedEvent from v1 to v2
+func renameField(payload []byte) ([]byte, error) {
+	var v1 struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(payload, &v1); err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]string{"title": v1.Name})
+}
+
+func newRenamedRegistry(t *testing.T) *events.Registry {
+	t.Helper()
+
+	registry := events.NewRegistry()
+	err := registry.Register(func() events.Event { return &renamedEvent{} }, events.WithUpcaster(1, renameField))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	return registry
+}
+
+func TestRegistry_EncodeDecodeRoundTrip(t *testing.T) {
+	registry := newRenamedRegistry(t)
+
+	payload, version, err := registry.Encode(&renamedEvent{Title: "Pancakes"})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if version != 2 {
+		t.Errorf("expected schema version 2, got %d", version)
+	}
+	if !bytes.Contains(payload, []byte(`"title":"Pancakes"`)) {
+		t.Errorf("unexpected payload %s", payload)
+	}
+
+	event, err := registry.Decode("test.renamed", version, payload)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := event.(*renamedEvent).Title; got != "Pancakes" {
+		t.Errorf("expected title 'Pancakes', got %q", got)
+	}
+}
+
+func TestRegistry_DecodeUpcastsOldVersions(t *testing.T) {
+	registry := newRenamedRegistry(t)
+
+	event, err := registry.Decode("test.renamed", 1, []byte(`{"name":"Waffles"}`))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := event.(*renamedEvent).Title; got != "Waffles" {
+		t.Errorf("expected title 'Waffles', got %q", got)
+	}
+}
+
+func TestRegistry_DecodeErrors(t *testing.T) {
+	registry := newRenamedRegistry(t)
+	if err := registry.Register(func() events.Event { return &versionedEvent{} }); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		version   int
+		want      error
+	}{
+		{name: "unknown type", eventType: "test.unknown", version: 1, want: events.ErrUnknownEventType},
+		{name: "newer than registered", eventType: "test.renamed", version: 3, want: events.ErrUnsupportedSchemaVersion},
+		{name: "missing upcaster", eventType: "test.versioned", version: 1, want: events.ErrUnsupportedSchemaVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := registry.Decode(tt.eventType, tt.version, []byte(`{}`)); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestRegistry_RegisterRejectsDuplicates(t *testing.T) {
+	registry := newRenamedRegistry(t)
+
+	err := registry.Register(func() events.Event { return &renamedEvent{} })
+	if !errors.Is(err, events.ErrEventTypeRegistered) {
+		t.Errorf("expected ErrEventTypeRegistered, got %v", err)
+	}
+
+	if got := registry.EventTypes(); !slices.Equal(got, []string{"test.renamed"}) {
+		t.Errorf("unexpected event types %v", got)
+	}
+}
+
+func TestRegistry_EncodeRequiresRegistration(t *testing.T) {
+	_, _, err := events.NewRegistry().Encode(&testEvent{})
+	if !errors.Is(err, events.ErrUnknownEventType) {
+		t.Errorf("expected ErrUnknownEventType, got %v", err)
+	}
+}
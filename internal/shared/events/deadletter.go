@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/logger"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is an event a handler gave up on
+type DeadLetter struct {
+	ID        string
+	Event     Event
+	Envelope  Envelope
+	Handler   string
+	Attempts  int
+	LastError string
+	FailedAt  time.Time
+}
+
+// DeadLetterStore keeps dead letters until they are redriven
+type DeadLetterStore interface {
+	Add(ctx context.Context, letter DeadLetter) error
+	// List returns all dead letters, oldest first
+	List(ctx context.Context) ([]DeadLetter, error)
+	// Remove deletes and returns the dead letter, or ErrDeadLetterNotFound
+	Remove(ctx context.Context, id string) (DeadLetter, error)
+}
+
+// DeadLetterQueue gives operators access to dead letters
+type DeadLetterQueue interface {
+	DeadLetters(ctx context.Context) ([]DeadLetter, error)
+	// Redrive delivers the dead letter to its handler again, with fresh attempts
+	Redrive(ctx context.Context, id string) error
+}
+
+// MemoryDeadLetterStore is an in-memory dead letter store
+type MemoryDeadLetterStore struct {
+	mu      sync.Mutex
+	letters map[string]DeadLetter
+}
+
+// NewMemoryDeadLetterStore creates an empty in-memory dead letter store
+func NewMemoryDeadLetterStore() *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{letters: make(map[string]DeadLetter)}
+}
+
+// Add stores a dead letter
+func (s *MemoryDeadLetterStore) Add(ctx context.Context, letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters[letter.ID] = letter
+	return nil
+}
+
+// List returns all dead letters, oldest first
+func (s *MemoryDeadLetterStore) List(ctx context.Context) ([]DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letters := make([]DeadLetter, 0, len(s.letters))
+	for _, letter := range s.letters {
+		letters = append(letters, letter)
+	}
+
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].FailedAt.Before(letters[j].FailedAt)
+	})
+
+	return letters, nil
+}
+
+// Remove deletes and returns the dead letter
+func (s *MemoryDeadLetterStore) Remove(ctx context.Context, id string) (DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letter, ok := s.letters[id]
+	if !ok {
+		return DeadLetter{}, ErrDeadLetterNotFound
+	}
+
+	delete(s.letters, id)
+	return letter, nil
+}
+
+// storeDeadLetter adds the event sub gave up on to store, logging it instead
+// when the store fails
+func storeDeadLetter(ctx context.Context, store DeadLetterStore, log logger.Logger, event Event, env Envelope, sub *subscription, attempts int, cause error) {
+	letter := DeadLetter{
+		ID:        uuid.New().String(),
+		Event:     event,
+		Envelope:  env,
+		Handler:   sub.name,
+		Attempts:  attempts,
+		LastError: cause.Error(),
+		FailedAt:  time.Now(),
+	}
+
+	if err := store.Add(context.WithoutCancel(ctx), letter); err != nil {
+		// Last resort: the event only survives in the logs
+		log.Error("Failed to store dead letter",
+			logger.String("event_type", event.EventType()),
+			logger.String("handler", sub.name),
+			logger.Any("event", event),
+			logger.Error(err),
+		)
+		return
+	}
+
+	log.Warn("Event dead-lettered",
+		logger.String("dead_letter_id", letter.ID),
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+		logger.String("handler", sub.name),
+		logger.Int("attempts", attempts),
+		logger.String("reason", letter.LastError),
+	)
+}
+
+var _ DeadLetterStore = (*MemoryDeadLetterStore)(nil)
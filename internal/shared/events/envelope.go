@@ -0,0 +1,149 @@
+package events
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultSchemaVersion is used for events that do not declare a version
+	DefaultSchemaVersion = 1
+	// DefaultProducer names the service publishing events when none is configured
+	DefaultProducer = "recipe-processor"
+)
+
+// Envelope is the metadata travelling with every published event
+type Envelope struct {
+	EventID       string `json:"event_id"`
+	SchemaVersion int    `json:"schema_version"`
+	// CorrelationID ties together every event caused by one request
+	CorrelationID string `json:"correlation_id"`
+	// CausationID is the EventID of the event whose handler published this one
+	CausationID string            `json:"causation_id,omitempty"`
+	Producer    string            `json:"producer"`
+	PublishedAt time.Time         `json:"published_at"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+// Versioned is implemented by events whose payload schema has evolved
+type Versioned interface {
+	SchemaVersion() int
+}
+
+type contextKey int
+
+const (
+	incomingEnvelopeKey contextKey = iota
+	outgoingEnvelopeKey
+	correlationIDKey
+	headersKey
+)
+
+// WithCorrelationID sets the correlation ID of events published with ctx
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID set with WithCorrelationID
+// or, inside a handler, the one of the event being handled
+func CorrelationIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(correlationIDKey).(string); ok && id != "" {
+		return id
+	}
+	if env, ok := EnvelopeFromContext(ctx); ok {
+		return env.CorrelationID
+	}
+	return ""
+}
+
+// WithHeaders adds headers to events published with ctx
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	merged := make(map[string]string)
+	if existing, ok := ctx.Value(headersKey).(map[string]string); ok {
+		maps.Copy(merged, existing)
+	}
+	maps.Copy(merged, headers)
+	return context.WithValue(ctx, headersKey, merged)
+}
+
+// WithOutgoingEnvelope publishes with this envelope instead of a new one
+// Relays and redrives use it to keep the identity of an event stored earlier;
+// its schema version is replaced by the event's own, as a decoded event was
+// upcast to the current version
+func WithOutgoingEnvelope(ctx context.Context, env Envelope) context.Context {
+	return context.WithValue(ctx, outgoingEnvelopeKey, env)
+}
+
+// ContextWithEnvelope hands the envelope of the event being handled to its handler
+// Bus implementations call it; handlers read it with EnvelopeFromContext
+func ContextWithEnvelope(ctx context.Context, env Envelope) context.Context {
+	return context.WithValue(ctx, incomingEnvelopeKey, env)
+}
+
+// EnvelopeFromContext returns the envelope of the event being handled
+func EnvelopeFromContext(ctx context.Context) (Envelope, bool) {
+	env, ok := ctx.Value(incomingEnvelopeKey).(Envelope)
+	return env, ok
+}
+
+// NewEnvelope builds the envelope for publishing event with ctx
+// Inside a handler the new event inherits the correlation ID of the handled
+// event and records it as its cause; otherwise the event starts a new
+// correlation unless one was set with WithCorrelationID
+func NewEnvelope(ctx context.Context, event Event, producer string) Envelope {
+	if env, ok := ctx.Value(outgoingEnvelopeKey).(Envelope); ok {
+		return completeEnvelope(env, event, producer)
+	}
+
+	env := Envelope{
+		EventID:       uuid.New().String(),
+		SchemaVersion: schemaVersion(event),
+		Producer:      producer,
+		PublishedAt:   time.Now(),
+	}
+
+	if cause, ok := EnvelopeFromContext(ctx); ok {
+		env.CorrelationID = cause.CorrelationID
+		env.CausationID = cause.EventID
+	}
+	if id, ok := ctx.Value(correlationIDKey).(string); ok && id != "" {
+		env.CorrelationID = id
+	}
+	if env.CorrelationID == "" {
+		env.CorrelationID = env.EventID
+	}
+
+	if headers, ok := ctx.Value(headersKey).(map[string]string); ok {
+		env.Headers = maps.Clone(headers)
+	}
+
+	return env
+}
+
+// completeEnvelope fills in what a stored envelope may lack
+func completeEnvelope(env Envelope, event Event, producer string) Envelope {
+	if env.EventID == "" {
+		env.EventID = uuid.New().String()
+	}
+	env.SchemaVersion = schemaVersion(event)
+	if env.CorrelationID == "" {
+		env.CorrelationID = env.EventID
+	}
+	if env.Producer == "" {
+		env.Producer = producer
+	}
+	if env.PublishedAt.IsZero() {
+		env.PublishedAt = time.Now()
+	}
+	return env
+}
+
+func schemaVersion(event Event) int {
+	if v, ok := event.(Versioned); ok {
+		return v.SchemaVersion()
+	}
+	return DefaultSchemaVersion
+}
@@ -0,0 +1,101 @@
+package events_test
+
+import (
+	"context"
+	"recipe-processor/internal/shared/events"
+	"testing"
+	"time"
+)
+
+type versionedEvent struct{}
+
+func (e *versionedEvent) EventType() string     { return "test.versioned" }
+func (e *versionedEvent) OccurredAt() time.Time { return time.Time{} }
+func (e *versionedEvent) SchemaVersion() int    { return 3 }
+
+func TestNewEnvelope_StartsNewCorrelation(t *testing.T) {
+	env := events.NewEnvelope(context.Background(), &testEvent{}, "tests")
+
+	if env.EventID == "" {
+		t.Fatal("expected an event ID")
+	}
+	if env.CorrelationID != env.EventID {
+		t.Errorf("expected correlation ID %q, got %q", env.EventID, env.CorrelationID)
+	}
+	if env.CausationID != "" {
+		t.Errorf("expected no causation ID, got %q", env.CausationID)
+	}
+	if env.Producer != "tests" {
+		t.Errorf("expected producer 'tests', got %q", env.Producer)
+	}
+	if env.SchemaVersion != events.DefaultSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", events.DefaultSchemaVersion, env.SchemaVersion)
+	}
+}
+
+func TestNewEnvelope_UsesCorrelationIDFromContext(t *testing.T) {
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+
+	env := events.NewEnvelope(ctx, &testEvent{}, "tests")
+
+	if env.CorrelationID != "request-1" {
+		t.Errorf("expected correlation ID 'request-1', got %q", env.CorrelationID)
+	}
+}
+
+func TestNewEnvelope_InheritsFromHandledEvent(t *testing.T) {
+	cause := events.NewEnvelope(events.WithCorrelationID(context.Background(), "request-1"), &testEvent{}, "tests")
+	ctx := events.ContextWithEnvelope(context.Background(), cause)
+
+	env := events.NewEnvelope(ctx, &testEvent{}, "tests")
+
+	if env.CorrelationID != "request-1" {
+		t.Errorf("expected correlation ID 'request-1', got %q", env.CorrelationID)
+	}
+	if env.CausationID != cause.EventID {
+		t.Errorf("expected causation ID %q, got %q", cause.EventID, env.CausationID)
+	}
+	if env.EventID == cause.EventID {
+		t.Error("expected a new event ID")
+	}
+}
+
+func TestNewEnvelope_OutgoingEnvelopeIsKept(t *testing.T) {
+	stored := events.Envelope{EventID: "event-1", CorrelationID: "request-1"}
+	ctx := events.WithOutgoingEnvelope(context.Background(), stored)
+
+	env := events.NewEnvelope(ctx, &versionedEvent{}, "tests")
+
+	if env.EventID != "event-1" || env.CorrelationID != "request-1" {
+		t.Errorf("expected stored IDs, got %+v", env)
+	}
+	if env.SchemaVersion != 3 {
+		t.Errorf("expected schema version 3, got %d", env.SchemaVersion)
+	}
+	if env.Producer != "tests" {
+		t.Errorf("expected missing producer to be filled in, got %q", env.Producer)
+	}
+}
+
+func TestNewEnvelope_OutgoingEnvelopeTakesTheEventVersion(t *testing.T) {
+	// The stored event was written at v1 and upcast when it was decoded
+	stored := events.Envelope{EventID: "event-1", SchemaVersion: 1}
+	ctx := events.WithOutgoingEnvelope(context.Background(), stored)
+
+	env := events.NewEnvelope(ctx, &versionedEvent{}, "tests")
+
+	if env.SchemaVersion != 3 {
+		t.Errorf("expected schema version 3, got %d", env.SchemaVersion)
+	}
+}
+
+func TestNewEnvelope_MergesHeaders(t *testing.T) {
+	ctx := events.WithHeaders(context.Background(), map[string]string{"tenant": "a", "source": "api"})
+	ctx = events.WithHeaders(ctx, map[string]string{"tenant": "b"})
+
+	env := events.NewEnvelope(ctx, &testEvent{}, "tests")
+
+	if env.Headers["tenant"] != "b" || env.Headers["source"] != "api" {
+		t.Errorf("unexpected headers %v", env.Headers)
+	}
+}
@@ -14,7 +14,25 @@ type EventHandler func(ctx context.Context, event Event) error
 
 type EventBus interface {
 	Publish(ctx context.Context, event Event) error
+	// Subscribe registers handler under its function name and cannot be undone
+	// It is kept for existing callers; prefer SubscribeNamed
 	Subscribe(eventType string, handler EventHandler)
+	// SubscribeNamed registers handler under name, which identifies it in logs
+	// and dead letters; an empty name defaults to WithHandlerName or the
+	// handler's function name
+	SubscribeNamed(eventType, name string, handler EventHandler, opts ...SubscribeOption) (Subscription, error)
 	Start(ctx context.Context) error
-	Stop() error
+	// Stop stops accepting events and drains queued ones until ctx is done
+	Stop(ctx context.Context) error
+}
+
+// AckFunc is called once every handler has finished processing an event
+// err is nil only when all handlers succeeded
+type AckFunc func(err error)
+
+// AckPublisher is implemented by buses that can report when an event was handled
+// Publishers that must not lose events (e.g. the outbox relay) use it to
+// confirm delivery before forgetting an event
+type AckPublisher interface {
+	PublishWithAck(ctx context.Context, event Event, ack AckFunc) error
 }
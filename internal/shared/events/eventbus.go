@@ -2,9 +2,12 @@ package events
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"recipe-processor/internal/shared/logger"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -15,45 +18,201 @@ const (
 	DefaultChannelBuffer = 100
 	// DefaultHandlerTimeout is the default timeout for event handlers
 	DefaultHandlerTimeout = 30 * time.Second
+	// spillPollInterval is how often spilled events are moved to a queue with room
+	spillPollInterval = 50 * time.Millisecond
+)
+
+var (
+	// ErrDeadLettered is reported to AckFuncs when a handler gave up on an event
+	ErrDeadLettered = errors.New("event dead-lettered")
+	// ErrBusStopped is returned when publishing to a bus that is stopping or stopped
+	ErrBusStopped = errors.New("event bus stopped")
+	// ErrDrainIncomplete is returned by Stop when queued events were left unprocessed
+	ErrDrainIncomplete = errors.New("event bus drain incomplete")
 )
 
 // MemoryEventBus is an in-memory event bus using channels and goroutines
 type MemoryEventBus struct {
-	handlers       map[string][]EventHandler
-	eventQueue     chan Event
+	subs           []*subscription
+	middleware     []HandlerMiddleware
+	matched        map[string][]*subscription
+	queue          chan delivery
+	overflow       OverflowPolicy
+	blockTimeout   time.Duration
+	spill          SpillStore
+	spillMu        sync.Mutex
+	spilled        int
+	spillAcks      map[string]*ackTracker
+	spillSignal    chan struct{}
+	refilled       chan struct{}
+	orderingKey    func(Event) string
+	receiveMu      sync.Mutex
+	keyMu          sync.Mutex
+	inFlight       map[string][]delivery
+	parked         int
 	workerCount    int
 	handlerTimeout time.Duration
+	retryPolicy    RetryPolicy
+	deadLetters    DeadLetterStore
+	store          EventStore
+	producer       string
 	logger         logger.Logger
 	mu             sync.RWMutex
+	queueMu        sync.RWMutex
+	queueClosed    bool
+	closing        atomic.Bool
+	draining       chan struct{}
+	retryMu        sync.Mutex
+	retries        map[*time.Timer]delivery
+	leftovers      []delivery
+	idleMu         sync.Mutex
+	outstanding    int
+	idle           chan struct{}
+	busy           atomic.Int32
+	pauseMu        sync.Mutex
+	paused         map[string]bool
+	held           []delivery
 	wg             sync.WaitGroup
 	ctx            context.Context
 	cancel         context.CancelFunc
 }
 
+// delivery is a queued event
+// A fresh publish targets every subscription; retries and redrives target one
+type delivery struct {
+	event    Event
+	envelope Envelope
+	sub      *subscription
+	attempt  int
+	tracker  *ackTracker
+}
+
+// ackTracker calls the publisher's AckFunc once every subscription is done
+type ackTracker struct {
+	mu        sync.Mutex
+	remaining int
+	errs      []error
+	ack       AckFunc
+}
+
+// newAckTracker returns nil when the publisher wants no acknowledgement
+func newAckTracker(ack AckFunc) *ackTracker {
+	if ack == nil {
+		return nil
+	}
+	return &ackTracker{ack: ack}
+}
+
+// done records the final outcome of one subscription
+func (t *ackTracker) done(err error) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	if err != nil {
+		t.errs = append(t.errs, err)
+	}
+	t.remaining--
+	finished := t.remaining == 0
+	t.mu.Unlock()
+
+	if finished {
+		t.ack(errors.Join(t.errs...))
+	}
+}
+
 // Config holds configuration for the memory event bus
 type Config struct {
 	WorkerCount    int
 	ChannelBuffer  int
 	HandlerTimeout time.Duration
+	// RetryPolicy applies to subscriptions without their own policy
+	RetryPolicy RetryPolicy
+	// DeadLetters receives events whose handlers gave up; defaults to an in-memory store
+	DeadLetters DeadLetterStore
+	// Producer is recorded in the envelope of published events
+	Producer string
+	// Store records every published event before it is delivered; optional
+	Store EventStore
+	// Middleware wraps every handler, outside the subscription's own middleware;
+	// nil means DefaultMiddleware, an empty slice disables it
+	Middleware []HandlerMiddleware
+	// OrderingKey enables ordered delivery, usually with OrderingKeyOf
+	// Events with the same non-empty key are handled one at a time in publish
+	// order, and a failing event holds back the later ones of its key until its
+	// retries are done; other events still run in parallel
+	OrderingKey func(Event) string
+	// Overflow decides what a publish does when the queue is full; defaults
+	// to OverflowBlock. Retries and redrives always wait for room
+	Overflow OverflowPolicy
+	// BlockTimeout bounds the wait of OverflowBlock; defaults to DefaultBlockTimeout
+	BlockTimeout time.Duration
+	// Spill holds the events of OverflowSpill; defaults to an in-memory store
+	Spill SpillStore
 }
 
 // NewMemoryEventBus creates a new in-memory event bus with default config
-func NewMemoryEventBus(log logger.Logger) EventBus {
+func NewMemoryEventBus(log logger.Logger) *MemoryEventBus {
 	return NewMemoryEventBusWithConfig(log, Config{
 		WorkerCount:    DefaultWorkerCount,
 		ChannelBuffer:  DefaultChannelBuffer,
 		HandlerTimeout: DefaultHandlerTimeout,
+		RetryPolicy:    DefaultRetryPolicy(),
 	})
 }
 
 // NewMemoryEventBusWithConfig creates a new in-memory event bus with custom config
-func NewMemoryEventBusWithConfig(log logger.Logger, cfg Config) EventBus {
+func NewMemoryEventBusWithConfig(log logger.Logger, cfg Config) *MemoryEventBus {
+	if cfg.RetryPolicy.MaxAttempts <= 0 {
+		cfg.RetryPolicy.MaxAttempts = 1
+	}
+	if cfg.DeadLetters == nil {
+		cfg.DeadLetters = NewMemoryDeadLetterStore()
+	}
+	if cfg.Producer == "" {
+		cfg.Producer = DefaultProducer
+	}
+	if cfg.Middleware == nil {
+		cfg.Middleware = DefaultMiddleware(log)
+	}
+	if cfg.Overflow == "" {
+		cfg.Overflow = OverflowBlock
+	}
+	if cfg.BlockTimeout <= 0 {
+		cfg.BlockTimeout = DefaultBlockTimeout
+	}
+	if cfg.Overflow == OverflowSpill && cfg.Spill == nil {
+		cfg.Spill = NewMemorySpillStore()
+	}
+
+	// Replaced in Start; set here so publishing before Start cannot dereference nil
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &MemoryEventBus{
-		handlers:       make(map[string][]EventHandler),
-		eventQueue:     make(chan Event, cfg.ChannelBuffer),
+		matched:        make(map[string][]*subscription),
+		queue:          make(chan delivery, cfg.ChannelBuffer),
+		inFlight:       make(map[string][]delivery),
+		overflow:       cfg.Overflow,
+		blockTimeout:   cfg.BlockTimeout,
+		spill:          cfg.Spill,
+		spillAcks:      make(map[string]*ackTracker),
+		spillSignal:    make(chan struct{}, 1),
+		orderingKey:    cfg.OrderingKey,
+		middleware:     cfg.Middleware,
 		workerCount:    cfg.WorkerCount,
 		handlerTimeout: cfg.HandlerTimeout,
+		retryPolicy:    cfg.RetryPolicy,
+		deadLetters:    cfg.DeadLetters,
+		store:          cfg.Store,
+		producer:       cfg.Producer,
+		retries:        make(map[*time.Timer]delivery),
+		paused:         make(map[string]bool),
+		draining:       make(chan struct{}),
+		idle:           make(chan struct{}),
 		logger:         log,
+		ctx:            ctx,
+		cancel:         cancel,
 	}
 }
 
@@ -61,6 +220,20 @@ func NewMemoryEventBusWithConfig(log logger.Logger, cfg Config) EventBus {
 func (eb *MemoryEventBus) Start(ctx context.Context) error {
 	eb.ctx, eb.cancel = context.WithCancel(ctx)
 
+	if eb.spill != nil {
+		// Events spilled before the last stop are delivered first
+		spilled, err := eb.spill.Len(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count spilled events: %w", err)
+		}
+		eb.spillMu.Lock()
+		eb.spilled = spilled
+		eb.spillMu.Unlock()
+		eb.track(spilled)
+		eb.refilled = make(chan struct{})
+		go eb.refill()
+	}
+
 	// Start worker pool
 	for i := 0; i < eb.workerCount; i++ {
 		eb.wg.Add(1)
@@ -69,52 +242,717 @@ func (eb *MemoryEventBus) Start(ctx context.Context) error {
 
 	eb.logger.Info("Event bus started",
 		logger.Int("workers", eb.workerCount),
-		logger.Int("buffer_size", cap(eb.eventQueue)),
+		logger.Int("buffer_size", eb.bufferSize()),
+		logger.Any("ordered", eb.ordered()),
+		logger.String("overflow", string(eb.overflow)),
 	)
 	return nil
 }
 
-// Stop gracefully shuts down the event bus
-func (eb *MemoryEventBus) Stop() error {
-	if eb.cancel != nil {
+// Stop stops accepting publishes and lets the workers finish the queued events
+// Handler contexts are cancelled only once ctx is done; events still queued
+// then, and pending retries, are reported and dead-lettered so they can be
+// redriven. Events published with an AckFunc are only logged, their
+// publisher redelivers them
+func (eb *MemoryEventBus) Stop(ctx context.Context) error {
+	if eb.closing.Swap(true) {
+		return nil
+	}
+	close(eb.draining)
+
+	// A retry whose timer already fired but has not taken its entry yet is
+	// taken here too; its callback finds the entry gone and leaves it to Stop
+	eb.retryMu.Lock()
+	var leftovers []delivery
+	for timer, d := range eb.retries {
+		timer.Stop()
+		leftovers = append(leftovers, d)
+	}
+	eb.retries = nil
+	eb.retryMu.Unlock()
+
+	// Waits for publishes blocked on a full queue; workers keep draining it
+	// as they never take queueMu
+	eb.queueMu.Lock()
+	eb.queueClosed = true
+	// Workers drain what is buffered, then exit
+	close(eb.queue)
+	eb.queueMu.Unlock()
+
+	// Events still spilled stay in the spill store for the next start
+	if eb.refilled != nil {
+		<-eb.refilled
+	}
+	if depth := eb.QueueDepth(); depth.Spilled > 0 {
+		eb.logger.Warn("Spilled events left for the next start",
+			logger.Int("spilled", depth.Spilled),
+		)
+	}
+
+	eb.logger.Info("Draining event bus",
+		logger.Int("queued", eb.queued()),
+		logger.Int("pending_retries", len(leftovers)),
+	)
+
+	drained := make(chan struct{})
+	go func() {
+		eb.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		// Out of time: interrupt running handlers and take what is left
 		eb.cancel()
+		<-drained
 	}
+	eb.cancel()
 
-	// Close event queue to signal workers to stop
-	close(eb.eventQueue)
+	leftovers = append(leftovers, eb.leftovers...)
+	eb.pauseMu.Lock()
+	leftovers = append(leftovers, eb.held...)
+	eb.held = nil
+	eb.pauseMu.Unlock()
+	for d := range eb.queue {
+		leftovers = append(leftovers, d)
+	}
+	eb.keyMu.Lock()
+	for _, waiting := range eb.inFlight {
+		leftovers = append(leftovers, waiting...)
+	}
+	eb.inFlight = make(map[string][]delivery)
+	eb.parked = 0
+	eb.keyMu.Unlock()
 
-	// Wait for all workers to finish processing
-	eb.wg.Wait()
+	unprocessed := 0
+	for _, d := range leftovers {
+		unprocessed += eb.abandon(d)
+	}
+
+	if unprocessed > 0 {
+		eb.logger.Warn("Event bus stopped with unprocessed events",
+			logger.Int("unprocessed", unprocessed),
+		)
+		return fmt.Errorf("%w: %d events left unprocessed", ErrDrainIncomplete, unprocessed)
+	}
 
 	eb.logger.Info("Event bus stopped")
 	return nil
 }
 
 // Publish sends an event to all registered handlers
+// The envelope is derived from ctx, see NewEnvelope
+// It returns ErrBusStopped once Stop was called
 func (eb *MemoryEventBus) Publish(ctx context.Context, event Event) error {
-	select {
-	case eb.eventQueue <- event:
-		eb.logger.Debug("Event published",
-			logger.String("event_type", event.EventType()),
+	return eb.PublishWithAck(ctx, event, nil)
+}
+
+// PublishWithAck sends an event to all registered handlers and calls ack once
+// each of them succeeded or gave up, with the errors of those that gave up
+func (eb *MemoryEventBus) PublishWithAck(ctx context.Context, event Event, ack AckFunc) error {
+	if eb.stopping() {
+		return ErrBusStopped
+	}
+
+	env := NewEnvelope(ctx, event, eb.producer)
+	if eb.store != nil {
+		if err := eb.store.Append(ctx, event, env); err != nil {
+			return fmt.Errorf("failed to store %s event: %w", event.EventType(), err)
+		}
+	}
+
+	if err := eb.admit(ctx, delivery{event: event, envelope: env, tracker: newAckTracker(ack)}); err != nil {
+		return err
+	}
+
+	eb.logger.Debug("Event published",
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+	)
+	return nil
+}
+
+// Subscribe registers handler under its function name, see Subscribe
+// Subscription errors are logged; use SubscribeNamed to handle them
+func (eb *MemoryEventBus) Subscribe(eventType string, handler EventHandler) {
+	if _, err := Subscribe(eb, eventType, handler); err != nil {
+		eb.logger.Error("Failed to subscribe handler", logger.String("event_type", eventType), logger.Error(err))
+	}
+}
+
+// SubscribeNamed registers a handler for an event type or a pattern such as
+// "recipe.*" or "*", see MatchEventType
+// Names are unique among subscriptions receiving the same event types so dead
+// letters can be redriven to their handler
+func (eb *MemoryEventBus) SubscribeNamed(eventType, name string, handler EventHandler, opts ...SubscribeOption) (Subscription, error) {
+	cfg := ApplyNamedSubscribeOptions(name, handler, eb.retryPolicy, opts...)
+	sub := &subscription{
+		name:      cfg.Name,
+		eventType: eventType,
+		handler:   handler,
+		call:      WrapHandler(handler, eb.middleware, cfg.Middleware),
+		retry:     cfg.Retry,
+		timeout:   cfg.HandlerTimeout(eb.handlerTimeout),
+		bus:       eb,
+	}
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if nameTaken(eb.subs, eventType, sub.name) {
+		return nil, fmt.Errorf("%w: %s on %s", ErrSubscriptionExists, sub.name, eventType)
+	}
+
+	eb.subs = append(eb.subs, sub)
+	// Resolved again on the next event of each type
+	eb.matched = make(map[string][]*subscription)
+
+	eb.logger.Info("Handler subscribed",
+		logger.String("event_type", eventType),
+		logger.String("handler", sub.name),
+		logger.Int("total_handlers", len(eb.subs)),
+	)
+	return sub, nil
+}
+
+// unsubscribe removes sub; its pending retries are dropped when they come due
+func (eb *MemoryEventBus) unsubscribe(sub *subscription) {
+	if sub.removed.Swap(true) {
+		return
+	}
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	for i, s := range eb.subs {
+		if s == sub {
+			eb.subs = append(eb.subs[:i:i], eb.subs[i+1:]...)
+			break
+		}
+	}
+	eb.matched = make(map[string][]*subscription)
+
+	eb.logger.Info("Handler unsubscribed",
+		logger.String("event_type", sub.eventType),
+		logger.String("handler", sub.name),
+		logger.Int("total_handlers", len(eb.subs)),
+	)
+}
+
+// DeadLetters returns the events handlers gave up on, oldest first
+func (eb *MemoryEventBus) DeadLetters(ctx context.Context) ([]DeadLetter, error) {
+	return eb.deadLetters.List(ctx)
+}
+
+// Redrive delivers a dead letter to its handler again, with fresh attempts
+func (eb *MemoryEventBus) Redrive(ctx context.Context, id string) error {
+	letter, err := eb.deadLetters.Remove(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	sub := eb.findSubscription(letter.Event.EventType(), letter.Handler)
+
+	if sub == nil {
+		_ = eb.deadLetters.Add(ctx, letter)
+		return fmt.Errorf("handler %s is no longer subscribed to %s", letter.Handler, letter.Event.EventType())
+	}
+
+	if err := eb.enqueue(ctx, delivery{event: letter.Event, envelope: letter.Envelope, sub: sub, attempt: 1}); err != nil {
+		_ = eb.deadLetters.Add(ctx, letter)
+		return err
+	}
+
+	eb.logger.Info("Dead letter redriven",
+		logger.String("dead_letter_id", id),
+		logger.String("event_type", letter.Event.EventType()),
+		logger.String("handler", letter.Handler),
+	)
+	return nil
+}
+
+// findSubscription looks up a subscription receiving eventType by name
+func (eb *MemoryEventBus) findSubscription(eventType, name string) *subscription {
+	for _, sub := range eb.subscriptionsFor(eventType) {
+		if sub.name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// subscriptionsFor returns the subscriptions receiving eventType in
+// subscription order
+// Patterns are matched once per event type; later lookups hit the cache
+func (eb *MemoryEventBus) subscriptionsFor(eventType string) []*subscription {
+	eb.mu.RLock()
+	subs, ok := eb.matched[eventType]
+	eb.mu.RUnlock()
+	if ok {
+		return subs
+	}
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if subs, ok := eb.matched[eventType]; ok {
+		return subs
+	}
+	for _, sub := range eb.subs {
+		if MatchEventType(sub.eventType, eventType) {
+			subs = append(subs, sub)
+		}
+	}
+	eb.matched[eventType] = subs
+	return subs
+}
+
+// enqueue queues a delivery unless the bus is stopping, waiting for room
+// until ctx is done
+func (eb *MemoryEventBus) enqueue(ctx context.Context, d delivery) error {
+	return eb.send(ctx, d, -1)
+}
+
+// admit queues a published delivery, applying the overflow policy when the
+// queue is full
+func (eb *MemoryEventBus) admit(ctx context.Context, d delivery) error {
+	var err error
+	switch eb.overflow {
+	case OverflowFail:
+		err = eb.send(ctx, d, 0)
+	case OverflowSpill:
+		// Once events are spilled new ones queue up behind them
+		if eb.QueueDepth().Spilled > 0 {
+			return eb.spillDelivery(ctx, d)
+		}
+		if err = eb.send(ctx, d, 0); errors.Is(err, ErrQueueFull) {
+			return eb.spillDelivery(ctx, d)
+		}
+	default:
+		err = eb.send(ctx, d, eb.blockTimeout)
+	}
+
+	if errors.Is(err, ErrQueueFull) {
+		eb.logger.Warn("Event queue full, publish rejected",
+			logger.String("event_type", d.event.EventType()),
+			logger.String("event_id", d.envelope.EventID),
+			logger.String("overflow", string(eb.overflow)),
 		)
+	}
+	return err
+}
+
+// send queues a delivery, waiting at most wait for room in a full queue
+// A negative wait waits until ctx is done
+func (eb *MemoryEventBus) send(ctx context.Context, d delivery, wait time.Duration) error {
+	eb.queueMu.RLock()
+	defer eb.queueMu.RUnlock()
+
+	if eb.queueClosed {
+		return ErrBusStopped
+	}
+
+	// Counted before sending so a fast worker cannot finish it first
+	eb.track(1)
+
+	select {
+	case eb.queue <- d:
+		return nil
+	default:
+	}
+
+	if wait == 0 {
+		eb.track(-1)
+		return eb.queueFull()
+	}
+
+	var timeout <-chan time.Time
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case eb.queue <- d:
 		return nil
+	case <-timeout:
+		eb.track(-1)
+		return eb.queueFull()
 	case <-ctx.Done():
+		eb.track(-1)
 		return fmt.Errorf("publish cancelled: %w", ctx.Err())
 	case <-eb.ctx.Done():
-		return fmt.Errorf("event bus stopped: %w", eb.ctx.Err())
+		eb.track(-1)
+		return ErrBusStopped
 	}
 }
 
-// Subscribe registers a handler for a specific event type
-func (eb *MemoryEventBus) Subscribe(eventType string, handler EventHandler) {
-	eb.mu.Lock()
-	defer eb.mu.Unlock()
+func (eb *MemoryEventBus) queueFull() error {
+	return fmt.Errorf("%w: %d of %d queued", ErrQueueFull, len(eb.queue), eb.bufferSize())
+}
 
-	eb.handlers[eventType] = append(eb.handlers[eventType], handler)
-	eb.logger.Info("Handler subscribed",
+// spillDelivery stores a published delivery until the queue has room
+// Its AckFunc cannot be stored and is kept in memory; after a restart the
+// publisher redelivers the event as it got no acknowledgement
+func (eb *MemoryEventBus) spillDelivery(ctx context.Context, d delivery) error {
+	eb.spillMu.Lock()
+	defer eb.spillMu.Unlock()
+
+	if err := eb.spill.Push(ctx, d.event, d.envelope); err != nil {
+		return fmt.Errorf("failed to spill %s event: %w", d.event.EventType(), err)
+	}
+	if d.tracker != nil {
+		eb.spillAcks[d.envelope.EventID] = d.tracker
+	}
+	eb.spilled++
+	eb.track(1)
+
+	select {
+	case eb.spillSignal <- struct{}{}:
+	default:
+	}
+
+	eb.logger.Debug("Event spilled",
+		logger.String("event_type", d.event.EventType()),
+		logger.String("event_id", d.envelope.EventID),
+		logger.Int("spilled", eb.spilled),
+	)
+	return nil
+}
+
+// refill moves spilled events to the queue as it gets room, until Stop
+func (eb *MemoryEventBus) refill() {
+	defer close(eb.refilled)
+
+	ticker := time.NewTicker(spillPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-eb.draining:
+			return
+		case <-eb.spillSignal:
+		case <-ticker.C:
+		}
+
+		if err := eb.refillBatch(); err != nil && !eb.stopping() {
+			eb.logger.Error("Failed to refill spilled events", logger.Error(err))
+		}
+	}
+}
+
+// refillBatch queues as many spilled events as there is room for
+func (eb *MemoryEventBus) refillBatch() error {
+	for {
+		room := eb.bufferSize() - eb.queued()
+		if room <= 0 || eb.QueueDepth().Spilled == 0 {
+			return nil
+		}
+
+		batch, err := eb.spill.Pop(eb.ctx, room)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		for i, spilled := range batch {
+			eb.spillMu.Lock()
+			tracker := eb.spillAcks[spilled.Envelope.EventID]
+			delete(eb.spillAcks, spilled.Envelope.EventID)
+			eb.spillMu.Unlock()
+
+			d := delivery{event: spilled.Event, envelope: spilled.Envelope, tracker: tracker}
+			if err := eb.enqueue(eb.ctx, d); err != nil {
+				// Stopping: keep the rest for the next start
+				return eb.unpop(batch[i:], tracker)
+			}
+
+			eb.spillMu.Lock()
+			eb.spilled--
+			eb.spillMu.Unlock()
+			eb.track(-1)
+		}
+	}
+}
+
+// unpop returns events popped from the spill store that could not be queued
+func (eb *MemoryEventBus) unpop(batch []SpilledEvent, tracker *ackTracker) error {
+	ctx := context.WithoutCancel(eb.ctx)
+	for _, spilled := range batch {
+		if err := eb.spill.Push(ctx, spilled.Event, spilled.Envelope); err != nil {
+			return fmt.Errorf("failed to keep spilled %s event: %w", spilled.Event.EventType(), err)
+		}
+	}
+	if tracker != nil {
+		eb.spillMu.Lock()
+		eb.spillAcks[batch[0].Envelope.EventID] = tracker
+		eb.spillMu.Unlock()
+	}
+	return nil
+}
+
+// QueueDepth reports how many events are queued and spilled
+func (eb *MemoryEventBus) QueueDepth() QueueDepth {
+	eb.spillMu.Lock()
+	spilled := eb.spilled
+	eb.spillMu.Unlock()
+
+	return QueueDepth{Queued: eb.queued(), Capacity: eb.bufferSize(), Spilled: spilled}
+}
+
+// Admit returns ErrQueueFull while the queue is full, unless full queues
+// spill
+func (eb *MemoryEventBus) Admit() error {
+	if eb.overflow == OverflowSpill || len(eb.queue) < eb.bufferSize() {
+		return nil
+	}
+	return eb.queueFull()
+}
+
+// Stats reports the queue depth, worker usage, paused event types and the
+// activity of every subscription
+func (eb *MemoryEventBus) Stats() BusStats {
+	stats := BusStats{
+		Queue:         eb.QueueDepth(),
+		Workers:       eb.workerCount,
+		BusyWorkers:   int(eb.busy.Load()),
+		Subscriptions: make(map[string][]HandlerStats),
+	}
+
+	eb.pauseMu.Lock()
+	for eventType := range eb.paused {
+		stats.Paused = append(stats.Paused, eventType)
+	}
+	stats.Held = len(eb.held)
+	eb.pauseMu.Unlock()
+	slices.Sort(stats.Paused)
+
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+
+	for _, sub := range eb.subs {
+		stats.Subscriptions[sub.eventType] = append(stats.Subscriptions[sub.eventType], sub.stats.snapshot(sub.name))
+	}
+	return stats
+}
+
+// Pause holds back deliveries of an event type, or of the types a pattern
+// matches, until Resume is called with the same argument
+// Held deliveries wait outside the queue, so later events of other types and
+// ordering keys keep flowing; WaitIdle and Stop treat them as unprocessed
+func (eb *MemoryEventBus) Pause(eventType string) {
+	eb.pauseMu.Lock()
+	defer eb.pauseMu.Unlock()
+
+	if eb.paused[eventType] {
+		return
+	}
+	eb.paused[eventType] = true
+
+	eb.logger.Info("Event type paused", logger.String("event_type", eventType))
+}
+
+// Resume ends a Pause and queues the deliveries it held back in their
+// original order
+func (eb *MemoryEventBus) Resume(eventType string) {
+	eb.pauseMu.Lock()
+	if !eb.paused[eventType] {
+		eb.pauseMu.Unlock()
+		return
+	}
+	delete(eb.paused, eventType)
+
+	var released []delivery
+	held := eb.held[:0]
+	for _, d := range eb.held {
+		if eb.isPaused(d.event.EventType()) {
+			held = append(held, d)
+		} else {
+			released = append(released, d)
+		}
+	}
+	eb.held = held
+	eb.pauseMu.Unlock()
+
+	eb.logger.Info("Event type resumed",
 		logger.String("event_type", eventType),
-		logger.Int("total_handlers", len(eb.handlers[eventType])),
+		logger.Int("released", len(released)),
 	)
+
+	// Requeued in the background as the queue may be full
+	go func() {
+		for _, d := range released {
+			if err := eb.enqueue(eb.ctx, d); err != nil {
+				eb.abandon(d)
+			}
+			eb.track(-1)
+		}
+	}()
+}
+
+// hold sets d aside if its event type is paused
+func (eb *MemoryEventBus) hold(d delivery) bool {
+	eb.pauseMu.Lock()
+	defer eb.pauseMu.Unlock()
+
+	if !eb.isPaused(d.event.EventType()) {
+		return false
+	}
+	eb.held = append(eb.held, d)
+	return true
+}
+
+// isPaused reports whether a pause matches eventType; callers hold eb.pauseMu
+func (eb *MemoryEventBus) isPaused(eventType string) bool {
+	for pattern := range eb.paused {
+		if MatchEventType(pattern, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyOf returns the ordering key of d, empty when delivery is not ordered
+func (eb *MemoryEventBus) keyOf(d delivery) string {
+	if !eb.ordered() {
+		return ""
+	}
+	return eb.orderingKey(d.event)
+}
+
+// receive takes the next delivery off the queue for a worker
+// A delivery whose key is already being handled is parked behind it instead,
+// so the worker handling that key takes it next. Receiving and claiming
+// happen together so two workers cannot swap the order of one key
+func (eb *MemoryEventBus) receive() (delivery, bool) {
+	eb.receiveMu.Lock()
+	defer eb.receiveMu.Unlock()
+
+	for {
+		select {
+		case d, ok := <-eb.queue:
+			if !ok {
+				return delivery{}, false
+			}
+			if eb.claim(d) {
+				return d, true
+			}
+		case <-eb.ctx.Done():
+			return delivery{}, false
+		}
+	}
+}
+
+// claim marks the key of d as being handled, or parks d if it already is
+func (eb *MemoryEventBus) claim(d delivery) bool {
+	key := eb.keyOf(d)
+	if key == "" {
+		return true
+	}
+
+	eb.keyMu.Lock()
+	defer eb.keyMu.Unlock()
+
+	if waiting, ok := eb.inFlight[key]; ok {
+		eb.inFlight[key] = append(waiting, d)
+		eb.parked++
+		return false
+	}
+	eb.inFlight[key] = nil
+	return true
+}
+
+// release returns the next delivery parked behind d, or frees the key of d
+// when none is waiting
+func (eb *MemoryEventBus) release(d delivery) (delivery, bool) {
+	key := eb.keyOf(d)
+	if key == "" {
+		return delivery{}, false
+	}
+
+	eb.keyMu.Lock()
+	defer eb.keyMu.Unlock()
+
+	waiting := eb.inFlight[key]
+	if len(waiting) == 0 {
+		delete(eb.inFlight, key)
+		return delivery{}, false
+	}
+	eb.inFlight[key] = waiting[1:]
+	eb.parked--
+	return waiting[0], true
+}
+
+// ordered reports whether events with the same key are handled in order
+func (eb *MemoryEventBus) ordered() bool {
+	return eb.orderingKey != nil && eb.workerCount > 1
+}
+
+// queued returns the number of buffered deliveries, including those parked
+// behind another event of their key
+func (eb *MemoryEventBus) queued() int {
+	eb.keyMu.Lock()
+	defer eb.keyMu.Unlock()
+
+	return len(eb.queue) + eb.parked
+}
+
+// bufferSize returns the capacity of the queue
+func (eb *MemoryEventBus) bufferSize() int {
+	return cap(eb.queue)
+}
+
+// track counts queued deliveries, running handlers and pending retries
+func (eb *MemoryEventBus) track(delta int) {
+	eb.idleMu.Lock()
+	defer eb.idleMu.Unlock()
+
+	eb.outstanding += delta
+	if eb.outstanding == 0 {
+		close(eb.idle)
+		eb.idle = make(chan struct{})
+	}
+}
+
+// WaitIdle blocks until no events are queued, being handled or waiting for a
+// retry, including the events handlers publish in turn
+// It returns ErrBusStopped if the bus stops first
+func (eb *MemoryEventBus) WaitIdle(ctx context.Context) error {
+	eb.idleMu.Lock()
+	if eb.outstanding == 0 {
+		eb.idleMu.Unlock()
+		return nil
+	}
+	idle := eb.idle
+	eb.idleMu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-eb.ctx.Done():
+		return ErrBusStopped
+	}
+}
+
+// Ping reports whether the bus accepts events; it returns ErrBusStopped once
+// Stop was called
+func (eb *MemoryEventBus) Ping(ctx context.Context) error {
+	if eb.stopping() {
+		return ErrBusStopped
+	}
+	return nil
+}
+
+// stopping reports whether Stop was called
+func (eb *MemoryEventBus) stopping() bool {
+	return eb.closing.Load()
 }
 
 // worker processes events from the queue
@@ -124,61 +962,196 @@ func (eb *MemoryEventBus) worker(id int) {
 	eb.logger.Debug("Worker started", logger.Int("worker_id", id))
 
 	for {
-		select {
-		case event, ok := <-eb.eventQueue:
-			if !ok {
-				// Channel closed, exit worker
-				eb.logger.Debug("Worker stopped", logger.Int("worker_id", id))
+		d, ok := eb.receive()
+		if !ok {
+			// Queue closed or bus cancelled, exit worker
+			eb.logger.Debug("Worker stopped", logger.Int("worker_id", id))
+			return
+		}
+
+		// Handles the events parked behind d before taking new ones
+		for {
+			// The drain deadline passed while this was queued, leave it to Stop
+			if eb.ctx.Err() != nil {
+				eb.retryMu.Lock()
+				eb.leftovers = append(eb.leftovers, d)
+				eb.retryMu.Unlock()
 				return
 			}
 
-			// Process event
-			eb.processEvent(event)
+			// Stays outstanding until its event type is resumed
+			if !eb.hold(d) {
+				eb.busy.Add(1)
+				eb.processEvent(d)
+				eb.busy.Add(-1)
+				eb.track(-1)
+			}
 
-		case <-eb.ctx.Done():
-			eb.logger.Debug("Worker cancelled", logger.Int("worker_id", id))
-			return
+			if d, ok = eb.release(d); !ok {
+				break
+			}
 		}
 	}
 }
 
-// processEvent dispatches an event to all registered handlers
-func (eb *MemoryEventBus) processEvent(event Event) {
-	eb.mu.RLock()
-	handlers := eb.handlers[event.EventType()]
-	eb.mu.RUnlock()
+// processEvent dispatches a delivery to its subscriptions
+func (eb *MemoryEventBus) processEvent(d delivery) {
+	if d.sub != nil {
+		eb.invoke(d)
+		return
+	}
 
-	if len(handlers) == 0 {
+	subs := eb.subscriptionsFor(d.event.EventType())
+	if len(subs) == 0 {
 		eb.logger.Warn("No handlers registered for event",
-			logger.String("event_type", event.EventType()),
+			logger.String("event_type", d.event.EventType()),
 		)
+		if d.tracker != nil {
+			d.tracker.ack(nil)
+		}
 		return
 	}
 
+	if d.tracker != nil {
+		d.tracker.remaining = len(subs)
+	}
+
 	// Execute all handlers for this event type
-	for i, handler := range handlers {
-		// Create context with timeout for handler execution
-		handlerCtx, cancel := context.WithTimeout(eb.ctx, eb.handlerTimeout)
+	for _, sub := range subs {
+		eb.invoke(delivery{event: d.event, envelope: d.envelope, sub: sub, attempt: 1, tracker: d.tracker})
+	}
+}
 
-		start := time.Now()
-		err := handler(handlerCtx, event)
-		duration := time.Since(start)
+// invoke runs one handler attempt and schedules a retry or dead-letters on failure
+func (eb *MemoryEventBus) invoke(d delivery) {
+	if d.sub.removed.Load() {
+		eb.logger.Debug("Delivery dropped for unsubscribed handler",
+			logger.String("event_type", d.event.EventType()),
+			logger.String("event_id", d.envelope.EventID),
+			logger.String("handler", d.sub.name),
+		)
+		d.tracker.done(nil)
+		return
+	}
 
-		if err != nil {
-			eb.logger.Error("Handler failed",
-				logger.String("event_type", event.EventType()),
-				logger.Int("handler_index", i),
-				logger.Duration("duration", duration),
-				logger.Error(err),
-			)
-		} else {
-			eb.logger.Debug("Handler succeeded",
-				logger.String("event_type", event.EventType()),
-				logger.Int("handler_index", i),
-				logger.Duration("duration", duration),
-			)
+	handlerCtx := WithHandlerInfo(ContextWithEnvelope(eb.ctx, d.envelope), HandlerInfo{
+		Name:        d.sub.name,
+		Attempt:     d.attempt,
+		MaxAttempts: d.sub.retry.MaxAttempts,
+	})
+	handlerCtx, cancel := context.WithTimeout(handlerCtx, d.sub.timeout)
+	defer cancel()
+
+	// Middleware can panic too, CallHandler catches that as well
+	start := time.Now()
+	err := CallHandler(handlerCtx, d.sub.call, d.event)
+	d.sub.stats.observe(time.Since(start), err)
+	LogPanic(handlerCtx, eb.logger, d.event, err)
+	if err == nil {
+		d.tracker.done(nil)
+		return
+	}
+
+	// No retries while draining, the dead letter can be redriven after restart
+	if IsRetryable(err) && d.attempt < d.sub.retry.MaxAttempts && !eb.stopping() {
+		if !eb.ordered() {
+			eb.scheduleRetry(d)
+			return
+		}
+		// Retrying in place keeps later events of the same key waiting
+		if eb.waitBackoff(d.sub.retry.Backoff(d.attempt)) {
+			next := d
+			next.attempt++
+			eb.invoke(next)
+			return
 		}
+	}
+
+	eb.deadLetter(d, err)
+}
 
-		cancel()
+// waitBackoff sleeps for d and reports false if the bus started draining meanwhile
+func (eb *MemoryEventBus) waitBackoff(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-eb.draining:
+		return false
 	}
 }
+
+// scheduleRetry queues the next attempt after the subscription's backoff
+func (eb *MemoryEventBus) scheduleRetry(d delivery) {
+	backoff := d.sub.retry.Backoff(d.attempt)
+	next := d
+	next.attempt++
+
+	eb.retryMu.Lock()
+	defer eb.retryMu.Unlock()
+
+	// Stop already took the pending retries
+	if eb.retries == nil {
+		eb.abandon(next)
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(backoff, func() {
+		eb.retryMu.Lock()
+		if _, ok := eb.retries[timer]; !ok {
+			// Stop already took this retry as a leftover
+			eb.retryMu.Unlock()
+			return
+		}
+		delete(eb.retries, timer)
+		eb.retryMu.Unlock()
+
+		if err := eb.enqueue(eb.ctx, next); err != nil {
+			eb.abandon(next)
+		}
+		eb.track(-1)
+	})
+	eb.retries[timer] = next
+	eb.track(1)
+}
+
+// abandon records a delivery that will not be processed because the bus stopped
+// It returns the number of handler deliveries abandoned
+func (eb *MemoryEventBus) abandon(d delivery) int {
+	if d.tracker != nil {
+		// The publisher did not get an ack and will deliver the event again
+		eb.logger.Warn("Unprocessed event left to its publisher",
+			logger.String("event_type", d.event.EventType()),
+		)
+		return 1
+	}
+
+	subs := []*subscription{d.sub}
+	attempts := d.attempt - 1
+	if d.sub == nil {
+		subs = eb.subscriptionsFor(d.event.EventType())
+		attempts = 0
+	}
+
+	for _, sub := range subs {
+		storeDeadLetter(eb.ctx, eb.deadLetters, eb.logger, d.event, d.envelope, sub, attempts, ErrBusStopped)
+	}
+	return len(subs)
+}
+
+// deadLetter stores the failed event for inspection and redrive
+func (eb *MemoryEventBus) deadLetter(d delivery, cause error) {
+	d.sub.stats.deadLettered.Add(1)
+	storeDeadLetter(eb.ctx, eb.deadLetters, eb.logger, d.event, d.envelope, d.sub, d.attempt, cause)
+	d.tracker.done(fmt.Errorf("%w: handler %s: %w", ErrDeadLettered, d.sub.name, cause))
+}
+
+var (
+	_ AckPublisher    = (*MemoryEventBus)(nil)
+	_ DeadLetterQueue = (*MemoryEventBus)(nil)
+	_ StatsReporter   = (*MemoryEventBus)(nil)
+	_ Pauser          = (*MemoryEventBus)(nil)
+)
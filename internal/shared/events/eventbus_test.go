@@ -0,0 +1,985 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testEvent struct {
+	id string
+}
+
+func (e *testEvent) EventType() string     { return "test.happened" }
+func (e *testEvent) OccurredAt() time.Time { return time.Time{} }
+
+// fastRetries retries quickly so tests do not wait on real backoff
+var fastRetries = events.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+
+// newTestBus starts a bus that is stopped when the test ends
+func newTestBus(t *testing.T, policy events.RetryPolicy) *events.MemoryEventBus {
+	t.Helper()
+
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    2,
+		ChannelBuffer:  10,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    policy,
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	return bus
+}
+
+// publishAndWait publishes the event and returns the ack error
+func publishAndWait(t *testing.T, bus *events.MemoryEventBus, event events.Event) error {
+	t.Helper()
+
+	acked := make(chan error, 1)
+	if err := bus.PublishWithAck(context.Background(), event, func(err error) { acked <- err }); err != nil {
+		t.Fatalf("PublishWithAck() error = %v", err)
+	}
+
+	select {
+	case err := <-acked:
+		return err
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ack")
+		return nil
+	}
+}
+
+func TestMemoryEventBus_RetriesUntilHandlerSucceeds(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		if calls.Add(1) < 3 {
+			return errors.New("temporarily unavailable")
+		}
+		return nil
+	})
+
+	if err := publishAndWait(t, bus, &testEvent{id: "1"}); err != nil {
+		t.Fatalf("expected successful ack, got %v", err)
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 0 {
+		t.Errorf("expected no dead letters, got %d", len(letters))
+	}
+}
+
+func TestMemoryEventBus_DeadLettersAfterMaxAttempts(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("test.happened", "flaky", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return errors.New("still down")
+	})
+
+	err := publishAndWait(t, bus, &testEvent{id: "1"})
+	if !errors.Is(err, events.ErrDeadLettered) {
+		t.Fatalf("expected ErrDeadLettered ack, got %v", err)
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(letters))
+	}
+
+	letter := letters[0]
+	if letter.Handler != "flaky" || letter.Attempts != 3 || letter.LastError != "still down" {
+		t.Errorf("unexpected dead letter: %+v", letter)
+	}
+	if letter.Event.(*testEvent).id != "1" {
+		t.Errorf("expected dead letter to hold the event, got %+v", letter.Event)
+	}
+}
+
+func TestMemoryEventBus_PermanentErrorsAreNotRetried(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return events.Permanent(errors.New("malformed"))
+	})
+
+	if err := publishAndWait(t, bus, &testEvent{id: "1"}); !errors.Is(err, events.ErrDeadLettered) {
+		t.Fatalf("expected ErrDeadLettered ack, got %v", err)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestMemoryEventBus_SubscriptionRetryPolicyOverridesDefault(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return errors.New("down")
+	}, events.WithRetryPolicy(events.NoRetry()))
+
+	_ = publishAndWait(t, bus, &testEvent{id: "1"})
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestMemoryEventBus_SubscriptionTimeoutOverridesDefault(t *testing.T) {
+	// The bus gives handlers 1s, the slow one may take longer
+	bus := newTestBus(t, events.NoRetry())
+
+	var deadlines sync.Map
+	record := func(name string) events.EventHandler {
+		return func(ctx context.Context, event events.Event) error {
+			deadline, _ := ctx.Deadline()
+			deadlines.Store(name, time.Until(deadline))
+			return nil
+		}
+	}
+	bus.SubscribeNamed("test.happened", "fast", record("fast"))
+	bus.SubscribeNamed("test.happened", "slow", record("slow"), events.WithHandlerTimeout(time.Hour))
+
+	if err := publishAndWait(t, bus, &testEvent{id: "1"}); err != nil {
+		t.Fatalf("expected successful ack, got %v", err)
+	}
+
+	if fast, _ := deadlines.Load("fast"); fast.(time.Duration) > time.Second {
+		t.Errorf("expected the bus timeout of 1s, got %v", fast)
+	}
+	if slow, _ := deadlines.Load("slow"); slow.(time.Duration) < time.Minute {
+		t.Errorf("expected the subscription timeout of 1h, got %v", slow)
+	}
+}
+
+func TestMemoryEventBus_AckWaitsForAllHandlers(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	var succeeded atomic.Int32
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		succeeded.Add(1)
+		return nil
+	})
+	bus.SubscribeNamed("test.happened", "broken", func(ctx context.Context, event events.Event) error {
+		return errors.New("down")
+	})
+
+	err := publishAndWait(t, bus, &testEvent{id: "1"})
+	if !errors.Is(err, events.ErrDeadLettered) {
+		t.Fatalf("expected ErrDeadLettered ack, got %v", err)
+	}
+
+	// The healthy handler ran once and was not retried along with the broken one
+	if got := succeeded.Load(); got != 1 {
+		t.Errorf("expected healthy handler to run once, got %d", got)
+	}
+}
+
+func TestMemoryEventBus_Redrive(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	var healthy atomic.Bool
+	handled := make(chan string, 1)
+	bus.SubscribeNamed("test.happened", "exporter", func(ctx context.Context, event events.Event) error {
+		if !healthy.Load() {
+			return errors.New("down")
+		}
+		handled <- event.(*testEvent).id
+		return nil
+	})
+
+	_ = publishAndWait(t, bus, &testEvent{id: "42"})
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(letters))
+	}
+
+	healthy.Store(true)
+	if err := bus.Redrive(context.Background(), letters[0].ID); err != nil {
+		t.Fatalf("Redrive() error = %v", err)
+	}
+
+	select {
+	case id := <-handled:
+		if id != "42" {
+			t.Errorf("expected redriven event 42, got %s", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for redriven event")
+	}
+
+	if letters, _ := bus.DeadLetters(context.Background()); len(letters) != 0 {
+		t.Errorf("expected dead letter to be removed, got %d", len(letters))
+	}
+
+	if err := bus.Redrive(context.Background(), letters[0].ID); !errors.Is(err, events.ErrDeadLetterNotFound) {
+		t.Errorf("expected ErrDeadLetterNotFound on second redrive, got %v", err)
+	}
+}
+
+type otherEvent struct{}
+
+func (e *otherEvent) EventType() string     { return "other.happened" }
+func (e *otherEvent) OccurredAt() time.Time { return time.Time{} }
+
+func TestMemoryEventBus_PanickingHandlerBecomesError(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	bus.SubscribeNamed("test.happened", "panicky", func(ctx context.Context, event events.Event) error {
+		panic("boom")
+	})
+
+	err := publishAndWait(t, bus, &testEvent{id: "1"})
+
+	var panicErr *events.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected PanicError in ack, got %v", err)
+	}
+	if panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+		t.Errorf("expected panic value and stack, got %v with %d byte stack", panicErr.Value, len(panicErr.Stack))
+	}
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 1 || letters[0].Handler != "panicky" {
+		t.Fatalf("expected panicking handler to be dead-lettered, got %+v", letters)
+	}
+}
+
+func TestMemoryEventBus_PanickingHandlerIsRetried(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	var calls atomic.Int32
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		if calls.Add(1) == 1 {
+			panic("first attempt blows up")
+		}
+		return nil
+	})
+
+	if err := publishAndWait(t, bus, &testEvent{id: "1"}); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestMemoryEventBus_KeepsProcessingAfterPanic(t *testing.T) {
+	// A single worker: if the panic killed it, nothing else would be processed
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    1,
+		ChannelBuffer:  10,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    events.NoRetry(),
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer func() { _ = bus.Stop(context.Background()) }()
+
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		var m map[string]int
+		m["nil map write"] = 1
+		return nil
+	})
+
+	handled := make(chan struct{}, 3)
+	bus.SubscribeNamed("other.happened", "handler-2", func(ctx context.Context, event events.Event) error {
+		handled <- struct{}{}
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(context.Background(), &testEvent{id: "boom"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		if err := bus.Publish(context.Background(), &otherEvent{}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-handled:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("bus stopped processing after panic, handled %d of 3 events", i)
+		}
+	}
+}
+
+// newStoppableBus starts a bus the test stops itself
+func newStoppableBus(t *testing.T, workers int, policy events.RetryPolicy) *events.MemoryEventBus {
+	t.Helper()
+
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    workers,
+		ChannelBuffer:  10,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    policy,
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	return bus
+}
+
+func TestMemoryEventBus_Stop_DrainsQueuedEvents(t *testing.T) {
+	bus := newStoppableBus(t, 1, events.NoRetry())
+
+	var handled atomic.Int32
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		time.Sleep(5 * time.Millisecond)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		handled.Add(1)
+		return nil
+	})
+
+	for i := 0; i < 5; i++ {
+		if err := bus.Publish(context.Background(), &testEvent{}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := bus.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	if got := handled.Load(); got != 5 {
+		t.Errorf("expected all 5 queued events to be handled, got %d", got)
+	}
+}
+
+func TestMemoryEventBus_PublishAfterStop(t *testing.T) {
+	bus := newStoppableBus(t, 1, events.NoRetry())
+
+	if err := bus.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() before Stop error = %v", err)
+	}
+	if err := bus.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), &testEvent{}); !errors.Is(err, events.ErrBusStopped) {
+		t.Errorf("expected ErrBusStopped, got %v", err)
+	}
+	if err := bus.Ping(context.Background()); !errors.Is(err, events.ErrBusStopped) {
+		t.Errorf("expected Ping() to return ErrBusStopped, got %v", err)
+	}
+
+	// Stopping twice is harmless
+	if err := bus.Stop(context.Background()); err != nil {
+		t.Errorf("second Stop() error = %v", err)
+	}
+}
+
+func TestMemoryEventBus_Stop_DeadLettersLeftoversAfterDeadline(t *testing.T) {
+	bus := newStoppableBus(t, 1, events.NoRetry())
+
+	started := make(chan struct{}, 1)
+	bus.SubscribeNamed("test.happened", "stuck", func(ctx context.Context, event events.Event) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(context.Background(), &testEvent{}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := bus.Stop(ctx)
+	if !errors.Is(err, events.ErrDrainIncomplete) {
+		t.Fatalf("expected ErrDrainIncomplete, got %v", err)
+	}
+
+	// The interrupted event and the two that never started are kept for redrive
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 3 {
+		t.Fatalf("expected 3 dead letters, got %d", len(letters))
+	}
+
+	notStarted := 0
+	for _, letter := range letters {
+		if letter.Handler != "stuck" {
+			t.Errorf("unexpected handler %q", letter.Handler)
+		}
+		if letter.LastError == events.ErrBusStopped.Error() {
+			notStarted++
+		}
+	}
+	if notStarted != 2 {
+		t.Errorf("expected 2 dead letters for unstarted events, got %d", notStarted)
+	}
+}
+
+func TestMemoryEventBus_Stop_DeadLettersInsteadOfRetrying(t *testing.T) {
+	bus := newStoppableBus(t, 1, events.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour})
+
+	failed := make(chan struct{}, 1)
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		failed <- struct{}{}
+		return errors.New("down")
+	})
+
+	if err := bus.Publish(context.Background(), &testEvent{}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	<-failed
+
+	// Whether the hour-long retry was already scheduled or not, stopping must
+	// not wait for it and must keep the event for redrive
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = bus.Stop(ctx)
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 1 || letters[0].Attempts != 1 {
+		t.Fatalf("expected the event to be dead-lettered after 1 attempt, got %+v", letters)
+	}
+}
+
+func TestMemoryEventBus_Stop_DeadLettersRetriesComingDue(t *testing.T) {
+	// Retries keep coming due while Stop runs; none of them may get lost
+	bus := newStoppableBus(t, 4, events.RetryPolicy{MaxAttempts: 1000, InitialBackoff: time.Millisecond, Multiplier: 1})
+
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		return errors.New("down")
+	})
+
+	const published = 50
+	for i := 0; i < published; i++ {
+		if err := bus.Publish(context.Background(), &testEvent{}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = bus.Stop(ctx)
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != published {
+		t.Fatalf("expected %d dead letters, got %d", published, len(letters))
+	}
+}
+
+func TestMemoryEventBus_HandlersReceiveEnvelope(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	envelopes := make(chan events.Envelope, 2)
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		env, _ := events.EnvelopeFromContext(ctx)
+		envelopes <- env
+		return bus.Publish(ctx, &otherEvent{})
+	})
+	bus.SubscribeNamed("other.happened", "handler-2", func(ctx context.Context, event events.Event) error {
+		env, _ := events.EnvelopeFromContext(ctx)
+		envelopes <- env
+		return nil
+	})
+
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+	if err := bus.Publish(ctx, &testEvent{id: "1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	var got [2]events.Envelope
+	for i := range got {
+		select {
+		case got[i] = <-envelopes:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for handlers")
+		}
+	}
+
+	first, second := got[0], got[1]
+	if first.CorrelationID != "request-1" || second.CorrelationID != "request-1" {
+		t.Errorf("expected correlation ID 'request-1' on both events, got %q and %q",
+			first.CorrelationID, second.CorrelationID)
+	}
+	if second.CausationID != first.EventID {
+		t.Errorf("expected causation ID %q, got %q", first.EventID, second.CausationID)
+	}
+	if first.Producer != events.DefaultProducer {
+		t.Errorf("expected producer %q, got %q", events.DefaultProducer, first.Producer)
+	}
+}
+
+func TestMemoryEventBus_DeadLetterKeepsEnvelope(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		return errors.New("boom")
+	})
+
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+	acked := make(chan error, 1)
+	if err := bus.PublishWithAck(ctx, &testEvent{id: "1"}, func(err error) { acked <- err }); err != nil {
+		t.Fatalf("PublishWithAck() error = %v", err)
+	}
+	<-acked
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(letters))
+	}
+	if letters[0].Envelope.CorrelationID != "request-1" {
+		t.Errorf("expected correlation ID 'request-1', got %q", letters[0].Envelope.CorrelationID)
+	}
+}
+
+type keyedEvent struct {
+	key string
+	seq int
+}
+
+func (e *keyedEvent) EventType() string     { return "test.keyed" }
+func (e *keyedEvent) OccurredAt() time.Time { return time.Time{} }
+func (e *keyedEvent) OrderingKey() string   { return e.key }
+
+// newOrderedBus starts a bus with ordered delivery that is stopped when the test ends
+func newOrderedBus(t *testing.T, workers int) *events.MemoryEventBus {
+	t.Helper()
+
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    workers,
+		ChannelBuffer:  100,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    fastRetries,
+		OrderingKey:    events.OrderingKeyOf,
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	return bus
+}
+
+func TestMemoryEventBus_OrderedDelivery_SameKeyInPublishOrder(t *testing.T) {
+	bus := newOrderedBus(t, 4)
+
+	var mu sync.Mutex
+	seen := map[string][]int{}
+	var running atomic.Int32
+	bus.SubscribeNamed("test.keyed", "handler", func(ctx context.Context, event events.Event) error {
+		e := event.(*keyedEvent)
+		if e.key == "recipe-1" {
+			if running.Add(1) > 1 {
+				t.Errorf("events of recipe-1 handled concurrently")
+			}
+			defer running.Add(-1)
+		}
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		seen[e.key] = append(seen[e.key], e.seq)
+		mu.Unlock()
+		return nil
+	})
+
+	for seq := range 20 {
+		for _, key := range []string{"recipe-1", "recipe-2"} {
+			if err := bus.Publish(context.Background(), &keyedEvent{key: key, seq: seq}); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+		}
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+
+	for _, key := range []string{"recipe-1", "recipe-2"} {
+		if len(seen[key]) != 20 {
+			t.Fatalf("expected 20 events of %s, got %d", key, len(seen[key]))
+		}
+		for i, seq := range seen[key] {
+			if seq != i {
+				t.Fatalf("events of %s out of order: %v", key, seen[key])
+			}
+		}
+	}
+}
+
+func TestMemoryEventBus_OrderedDelivery_DifferentKeysRunInParallel(t *testing.T) {
+	bus := newOrderedBus(t, 8)
+
+	// Each handler waits for the other, which only works if both run at once
+	arrived := make(chan string, 2)
+	release := make(chan struct{})
+	bus.SubscribeNamed("test.keyed", "handler", func(ctx context.Context, event events.Event) error {
+		arrived <- event.(*keyedEvent).key
+		<-release
+		return nil
+	})
+
+	for _, key := range []string{"recipe-1", "recipe-3"} {
+		if err := bus.Publish(context.Background(), &keyedEvent{key: key}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	for range 2 {
+		select {
+		case <-arrived:
+		case <-time.After(2 * time.Second):
+			t.Fatal("events with different keys were not handled in parallel")
+		}
+	}
+	close(release)
+}
+
+func TestMemoryEventBus_OrderedDelivery_RetryHoldsBackLaterEvents(t *testing.T) {
+	bus := newOrderedBus(t, 4)
+
+	var mu sync.Mutex
+	var handled []int
+	var failures atomic.Int32
+	bus.SubscribeNamed("test.keyed", "handler", func(ctx context.Context, event events.Event) error {
+		e := event.(*keyedEvent)
+		if e.seq == 0 && failures.Add(1) < 3 {
+			return errors.New("temporarily unavailable")
+		}
+
+		mu.Lock()
+		handled = append(handled, e.seq)
+		mu.Unlock()
+		return nil
+	})
+
+	for seq := range 3 {
+		if err := bus.Publish(context.Background(), &keyedEvent{key: "recipe-1", seq: seq}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+
+	if len(handled) != 3 || handled[0] != 0 || handled[1] != 1 || handled[2] != 2 {
+		t.Errorf("expected [0 1 2], got %v", handled)
+	}
+}
+
+func TestMemoryEventBus_OrderedDelivery_HandlerPublishesSameKey(t *testing.T) {
+	// Arrange: one slot per worker, so a per-worker queue would fill up
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    4,
+		ChannelBuffer:  4,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    events.NoRetry(),
+		OrderingKey:    events.OrderingKeyOf,
+		BlockTimeout:   100 * time.Millisecond,
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	var mu sync.Mutex
+	var handled []int
+	publishErrs := make(chan error, 3)
+	bus.SubscribeNamed("test.keyed", "handler", func(ctx context.Context, event events.Event) error {
+		e := event.(*keyedEvent)
+		if e.seq == 0 {
+			for seq := 1; seq <= 3; seq++ {
+				publishErrs <- bus.Publish(ctx, &keyedEvent{key: e.key, seq: seq})
+			}
+		}
+
+		mu.Lock()
+		handled = append(handled, e.seq)
+		mu.Unlock()
+		return nil
+	})
+
+	// Act
+	if err := bus.Publish(context.Background(), &keyedEvent{key: "recipe-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+
+	// Assert
+	close(publishErrs)
+	for err := range publishErrs {
+		if err != nil {
+			t.Errorf("expected follow-up publish to succeed, got %v", err)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handled) != 4 || handled[0] != 0 || handled[1] != 1 || handled[2] != 2 || handled[3] != 3 {
+		t.Errorf("expected [0 1 2 3], got %v", handled)
+	}
+}
+
+func TestMemoryEventBus_PatternSubscriptions(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	var mu sync.Mutex
+	seen := map[string][]string{}
+	record := func(name string) events.EventHandler {
+		return func(ctx context.Context, event events.Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			seen[name] = append(seen[name], event.EventType())
+			return nil
+		}
+	}
+	bus.SubscribeNamed("test.happened", "exact", record("exact"))
+	bus.SubscribeNamed("test.*", "prefix", record("prefix"))
+	bus.SubscribeNamed("*", "all", record("all"))
+
+	for _, event := range []events.Event{&testEvent{}, &keyedEvent{}, &otherEvent{}} {
+		if err := bus.Publish(context.Background(), event); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := len(seen["exact"]); got != 1 {
+		t.Errorf("expected exact subscription to see 1 event, got %v", seen["exact"])
+	}
+	if got := len(seen["prefix"]); got != 2 {
+		t.Errorf("expected test.* to see 2 events, got %v", seen["prefix"])
+	}
+	if got := len(seen["all"]); got != 3 {
+		t.Errorf("expected * to see 3 events, got %v", seen["all"])
+	}
+}
+
+func TestMemoryEventBus_PatternSubscriptionAddedLater(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error { return nil })
+
+	if err := publishAndWait(t, bus, &testEvent{}); err != nil {
+		t.Fatalf("expected successful ack, got %v", err)
+	}
+
+	// Subscribing after the event type was resolved still takes effect
+	var calls atomic.Int32
+	bus.SubscribeNamed("*", "handler-2", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return nil
+	})
+	if err := publishAndWait(t, bus, &testEvent{}); err != nil {
+		t.Fatalf("expected successful ack, got %v", err)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected wildcard handler to run once, got %d", got)
+	}
+}
+
+func TestMemoryEventBus_RedrivesPatternSubscription(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	bus.SubscribeNamed("test.*", "webhook", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		if !healthy.Load() {
+			return errors.New("webhook unreachable")
+		}
+		return nil
+	})
+	// Same name on an unrelated pattern is allowed
+	bus.SubscribeNamed("other.*", "webhook", func(ctx context.Context, event events.Event) error { return nil })
+
+	if err := publishAndWait(t, bus, &testEvent{}); !errors.Is(err, events.ErrDeadLettered) {
+		t.Fatalf("expected ErrDeadLettered, got %v", err)
+	}
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 1 || letters[0].Handler != "webhook" {
+		t.Fatalf("expected one dead letter of webhook, got %+v", letters)
+	}
+
+	healthy.Store(true)
+	if err := bus.Redrive(context.Background(), letters[0].ID); err != nil {
+		t.Fatalf("Redrive() error = %v", err)
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestMemoryEventBus_Unsubscribe(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	var calls atomic.Int32
+	sub, err := bus.SubscribeNamed("test.happened", "counter", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if sub.Name() != "counter" || sub.EventType() != "test.happened" {
+		t.Errorf("expected counter on test.happened, got %s on %s", sub.Name(), sub.EventType())
+	}
+
+	if err := publishAndWait(t, bus, &testEvent{}); err != nil {
+		t.Fatalf("expected successful ack, got %v", err)
+	}
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	if err := publishAndWait(t, bus, &testEvent{}); err != nil {
+		t.Fatalf("expected successful ack, got %v", err)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call before unsubscribing, got %d", got)
+	}
+
+	// The name is free again
+	if _, err := bus.SubscribeNamed("test.happened", "counter", func(ctx context.Context, event events.Event) error { return nil }); err != nil {
+		t.Errorf("Subscribe() after Unsubscribe error = %v", err)
+	}
+}
+
+func TestMemoryEventBus_UnsubscribeDropsPendingRetries(t *testing.T) {
+	bus := newTestBus(t, events.RetryPolicy{MaxAttempts: 3, InitialBackoff: 50 * time.Millisecond, Multiplier: 1})
+
+	var calls atomic.Int32
+	failed := make(chan struct{}, 1)
+	sub, _ := bus.SubscribeNamed("test.happened", "flaky", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		failed <- struct{}{}
+		return errors.New("temporary failure")
+	})
+
+	acked := make(chan error, 1)
+	if err := bus.PublishWithAck(context.Background(), &testEvent{}, func(err error) { acked <- err }); err != nil {
+		t.Fatalf("PublishWithAck() error = %v", err)
+	}
+	<-failed
+	sub.Unsubscribe()
+
+	select {
+	case err := <-acked:
+		if err != nil {
+			t.Errorf("expected dropped retry to ack without error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ack")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected no retry after unsubscribing, got %d calls", got)
+	}
+	if letters, _ := bus.DeadLetters(context.Background()); len(letters) != 0 {
+		t.Errorf("expected no dead letters, got %+v", letters)
+	}
+}
+
+func TestMemoryEventBus_RejectsTakenName(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+	noop := func(ctx context.Context, event events.Event) error { return nil }
+
+	if _, err := bus.SubscribeNamed("test.*", "audit", noop); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if _, err := bus.SubscribeNamed("test.happened", "audit", noop); !errors.Is(err, events.ErrSubscriptionExists) {
+		t.Errorf("expected ErrSubscriptionExists for an overlapping pattern, got %v", err)
+	}
+	if _, err := bus.SubscribeNamed("other.happened", "audit", noop); err != nil {
+		t.Errorf("expected name to be free on an unrelated event type, got %v", err)
+	}
+}
+
+func TestMemoryEventBus_NameDefaultsToOption(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+	noop := func(ctx context.Context, event events.Event) error { return nil }
+
+	named, _ := bus.SubscribeNamed("test.happened", "", noop, events.WithHandlerName("from-option"))
+	if named.Name() != "from-option" {
+		t.Errorf("expected from-option, got %s", named.Name())
+	}
+
+	explicit, _ := bus.SubscribeNamed("test.happened", "explicit", noop, events.WithHandlerName("ignored"))
+	if explicit.Name() != "explicit" {
+		t.Errorf("expected the explicit name to win, got %s", explicit.Name())
+	}
+}
+
+func TestSubscribe_SuffixesTakenNames(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+	noop := func(ctx context.Context, event events.Event) error { return nil }
+
+	first, err := events.Subscribe(bus, "test.happened", noop, events.WithHandlerName("audit"))
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	second, err := events.Subscribe(bus, "test.happened", noop, events.WithHandlerName("audit"))
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if first.Name() != "audit" || second.Name() != "audit#2" {
+		t.Errorf("expected audit and audit#2, got %s and %s", first.Name(), second.Name())
+	}
+}
+
+func TestMemoryEventBus_SubscribeKeepsTheUnnamedSignature(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	var handled atomic.Int32
+	handler := func(ctx context.Context, event events.Event) error {
+		handled.Add(1)
+		return nil
+	}
+	bus.Subscribe("test.happened", handler)
+	bus.Subscribe("test.happened", handler)
+
+	if err := publishAndWait(t, bus, &testEvent{}); err != nil {
+		t.Fatalf("publish error = %v", err)
+	}
+	if got := handled.Load(); got != 2 {
+		t.Errorf("expected both subscriptions to be called, got %d", got)
+	}
+}
@@ -0,0 +1,312 @@
+// Package eventstest records what happens on an event bus so tests can
+// assert on published and handled events
+package eventstest
+
+import (
+	"context"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// DefaultWaitTimeout is how long the Wait helpers wait before failing the test
+const DefaultWaitTimeout = 5 * time.Second
+
+// Published is an event as published on the bus
+type Published struct {
+	Event    events.Event
+	Envelope events.Envelope
+}
+
+// Handled is the outcome of one handler invocation
+type Handled struct {
+	Event    events.Event
+	Envelope events.Envelope
+	Handler  string
+	Attempt  int
+	// Err is nil when the handler succeeded
+	Err error
+}
+
+// Recorder records published events as an events.EventStore and handler
+// invocations as middleware; pass it as both when configuring a bus
+// It works with any bus, the Wait helpers make it usable with asynchronous ones
+type Recorder struct {
+	mu        sync.Mutex
+	published []Published
+	ids       map[string]bool
+	handled   []Handled
+	// changed is closed and replaced whenever something is recorded
+	changed chan struct{}
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		ids:     make(map[string]bool),
+		changed: make(chan struct{}),
+	}
+}
+
+// NewBus starts a synchronous bus recording into a new recorder
+// The bus does not retry and is stopped when the test ends
+func NewBus(t testing.TB) (*events.SyncEventBus, *Recorder) {
+	t.Helper()
+
+	recorder := NewRecorder()
+	bus := events.NewSyncEventBusWithConfig(logger.NewNoopLogger(), events.SyncConfig{
+		RetryPolicy: events.NoRetry(),
+		Store:       recorder,
+		Middleware:  []events.HandlerMiddleware{recorder.Middleware()},
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	return bus, recorder
+}
+
+// Append records a published event; redeliveries of an event ID are ignored
+func (r *Recorder) Append(ctx context.Context, event events.Event, env events.Envelope) error {
+	r.record(func() {
+		if r.ids[env.EventID] {
+			return
+		}
+		r.ids[env.EventID] = true
+		r.published = append(r.published, Published{Event: event, Envelope: env})
+	})
+	return nil
+}
+
+// Load returns the recorded events matching filter in publish order
+func (r *Recorder) Load(ctx context.Context, filter events.EventFilter) ([]events.StoredEvent, error) {
+	var matched []events.StoredEvent
+	for i, p := range r.Published() {
+		if filter.Limit > 0 && len(matched) == filter.Limit {
+			break
+		}
+		se := events.StoredEvent{
+			Sequence:    int64(i + 1),
+			AggregateID: events.AggregateIDOf(p.Event),
+			Event:       p.Event,
+			Envelope:    p.Envelope,
+		}
+		if filter.Matches(se) {
+			matched = append(matched, se)
+		}
+	}
+	return matched, nil
+}
+
+// LastSequence returns the sequence of the newest recorded event
+func (r *Recorder) LastSequence(ctx context.Context) (int64, error) {
+	return int64(len(r.Published())), nil
+}
+
+// Middleware records the outcome of every handler invocation
+// Install it as the outermost middleware so it sees what the bus sees
+func (r *Recorder) Middleware() events.HandlerMiddleware {
+	return func(next events.EventHandler) events.EventHandler {
+		return func(ctx context.Context, event events.Event) error {
+			err := next(ctx, event)
+
+			info, _ := events.HandlerInfoFromContext(ctx)
+			env, _ := events.EnvelopeFromContext(ctx)
+			r.record(func() {
+				r.handled = append(r.handled, Handled{
+					Event:    event,
+					Envelope: env,
+					Handler:  info.Name,
+					Attempt:  info.Attempt,
+					Err:      err,
+				})
+			})
+			return err
+		}
+	}
+}
+
+// Published returns the events published so far, in publish order
+func (r *Recorder) Published() []Published {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Published(nil), r.published...)
+}
+
+// Handled returns the handler invocations so far, in the order they finished
+func (r *Recorder) Handled() []Handled {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Handled(nil), r.handled...)
+}
+
+// AssertPublished fails the test unless an event of eventType was published
+// for the aggregate, and returns the first one
+// An empty aggregateID matches every event of the type
+func (r *Recorder) AssertPublished(t testing.TB, eventType, aggregateID string) Published {
+	t.Helper()
+
+	p, ok := r.findPublished(eventType, aggregateID)
+	if !ok {
+		t.Fatalf("expected %s event for %q to be published, got %s", eventType, aggregateID, r.summary())
+	}
+	return p
+}
+
+// AssertNotPublished fails the test if an event of eventType was published
+// for the aggregate
+func (r *Recorder) AssertNotPublished(t testing.TB, eventType, aggregateID string) {
+	t.Helper()
+
+	if _, ok := r.findPublished(eventType, aggregateID); ok {
+		t.Fatalf("expected no %s event for %q, got %s", eventType, aggregateID, r.summary())
+	}
+}
+
+// WaitPublished waits until an event of eventType was published for the
+// aggregate, and fails the test after DefaultWaitTimeout
+func (r *Recorder) WaitPublished(t testing.TB, eventType, aggregateID string) Published {
+	t.Helper()
+
+	var p Published
+	r.wait(t, func() (ok bool) {
+		p, ok = r.findPublished(eventType, aggregateID)
+		return ok
+	}, "%s event for %q to be published", eventType, aggregateID)
+	return p
+}
+
+// WaitHandled waits until a handler succeeded with an event of eventType for
+// the aggregate, and fails the test after DefaultWaitTimeout
+// With several subscriptions to the type, any one of them succeeding counts;
+// use WaitHandledBy to wait for a particular one
+func (r *Recorder) WaitHandled(t testing.TB, eventType, aggregateID string) Handled {
+	t.Helper()
+
+	return r.WaitHandledBy(t, "", eventType, aggregateID)
+}
+
+// WaitHandledBy waits until the named handler succeeded with an event of
+// eventType for the aggregate; an empty handler matches every handler
+func (r *Recorder) WaitHandledBy(t testing.TB, handler, eventType, aggregateID string) Handled {
+	t.Helper()
+
+	var h Handled
+	r.wait(t, func() (ok bool) {
+		h, ok = r.findHandled(handler, eventType, aggregateID, false)
+		return ok
+	}, "%s event for %q to be handled", eventType, aggregateID)
+	return h
+}
+
+// WaitFailed waits until a handler failed with an event of eventType for the
+// aggregate, and returns that invocation with its error
+func (r *Recorder) WaitFailed(t testing.TB, eventType, aggregateID string) Handled {
+	t.Helper()
+
+	var h Handled
+	r.wait(t, func() (ok bool) {
+		h, ok = r.findHandled("", eventType, aggregateID, true)
+		return ok
+	}, "%s event for %q to fail", eventType, aggregateID)
+	return h
+}
+
+// record applies change and wakes up the waiting helpers
+func (r *Recorder) record(change func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	change()
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// wait polls cond after every change until it holds or the timeout passes
+func (r *Recorder) wait(t testing.TB, cond func() bool, format string, args ...any) {
+	t.Helper()
+
+	timeout := time.After(DefaultWaitTimeout)
+	for {
+		r.mu.Lock()
+		changed := r.changed
+		r.mu.Unlock()
+
+		if cond() {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-timeout:
+			args = append(args, r.summary())
+			t.Fatalf("timed out waiting for "+format+", got %s", args...)
+			return
+		}
+	}
+}
+
+func (r *Recorder) findPublished(eventType, aggregateID string) (Published, bool) {
+	for _, p := range r.Published() {
+		if matches(p.Event, eventType, aggregateID) {
+			return p, true
+		}
+	}
+	return Published{}, false
+}
+
+func (r *Recorder) findHandled(handler, eventType, aggregateID string, failed bool) (Handled, bool) {
+	for _, h := range r.Handled() {
+		if (h.Err != nil) != failed || (handler != "" && h.Handler != handler) {
+			continue
+		}
+		if matches(h.Event, eventType, aggregateID) {
+			return h, true
+		}
+	}
+	return Handled{}, false
+}
+
+func matches(event events.Event, eventType, aggregateID string) bool {
+	if event.EventType() != eventType {
+		return false
+	}
+	return aggregateID == "" || events.AggregateIDOf(event) == aggregateID
+}
+
+// summary lists the recorded events for failure messages
+func (r *Recorder) summary() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	published := make([]string, 0, len(r.published))
+	for _, p := range r.published {
+		published = append(published, describe(p.Event))
+	}
+
+	handled := make([]string, 0, len(r.handled))
+	for _, h := range r.handled {
+		outcome := h.Handler + "(" + describe(h.Event) + ")"
+		if h.Err != nil {
+			outcome += ": " + h.Err.Error()
+		}
+		handled = append(handled, outcome)
+	}
+
+	return "published [" + strings.Join(published, ", ") + "], handled [" + strings.Join(handled, ", ") + "]"
+}
+
+func describe(event events.Event) string {
+	if id := events.AggregateIDOf(event); id != "" {
+		return event.EventType() + " " + id
+	}
+	return event.EventType()
+}
+
+var _ events.EventStore = (*Recorder)(nil)
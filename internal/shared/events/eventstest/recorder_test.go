@@ -0,0 +1,81 @@
+package eventstest_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/events/eventstest"
+	"recipe-processor/internal/shared/logger"
+	"testing"
+	"time"
+)
+
+type orderEvent struct {
+	orderID string
+}
+
+func (e *orderEvent) EventType() string     { return "order.placed" }
+func (e *orderEvent) OccurredAt() time.Time { return time.Time{} }
+func (e *orderEvent) AggregateID() string   { return e.orderID }
+
+func TestRecorder_RecordsPublishedAndHandledEvents(t *testing.T) {
+	bus, recorder := eventstest.NewBus(t)
+	bus.SubscribeNamed("order.placed", "ship", func(ctx context.Context, event events.Event) error {
+		if event.(*orderEvent).orderID == "bad" {
+			return errors.New("no address")
+		}
+		return nil
+	})
+
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+	_ = bus.Publish(ctx, &orderEvent{orderID: "order-1"})
+	_ = bus.Publish(ctx, &orderEvent{orderID: "bad"})
+
+	published := recorder.AssertPublished(t, "order.placed", "order-1")
+	if published.Envelope.CorrelationID != "request-1" {
+		t.Errorf("expected envelope to be recorded, got %+v", published.Envelope)
+	}
+	recorder.AssertNotPublished(t, "order.placed", "order-2")
+
+	handled := recorder.WaitHandledBy(t, "ship", "order.placed", "order-1")
+	if handled.Attempt != 1 || handled.Envelope.EventID != published.Envelope.EventID {
+		t.Errorf("unexpected invocation %+v", handled)
+	}
+
+	failed := recorder.WaitFailed(t, "order.placed", "bad")
+	if failed.Err == nil || failed.Handler != "ship" {
+		t.Errorf("expected failed invocation of ship, got %+v", failed)
+	}
+
+	stored, _ := recorder.Load(context.Background(), events.EventFilter{AggregateID: "bad"})
+	if len(stored) != 1 || stored[0].Sequence != 2 {
+		t.Errorf("expected the second event from Load, got %+v", stored)
+	}
+}
+
+func TestRecorder_WaitHandledOnAsynchronousBus(t *testing.T) {
+	recorder := eventstest.NewRecorder()
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    2,
+		ChannelBuffer:  10,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    events.NoRetry(),
+		Store:          recorder,
+		Middleware:     []events.HandlerMiddleware{recorder.Middleware()},
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	release := make(chan struct{})
+	bus.SubscribeNamed("order.placed", "ship", func(ctx context.Context, event events.Event) error {
+		<-release
+		return nil
+	})
+
+	_ = bus.Publish(context.Background(), &orderEvent{orderID: "order-1"})
+	close(release)
+
+	recorder.WaitHandled(t, "order.placed", "order-1")
+}
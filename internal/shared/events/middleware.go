@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"recipe-processor/internal/shared/logger"
+	"time"
+)
+
+// HandlerMiddleware wraps an EventHandler with a cross-cutting concern such
+// as logging, metrics or tracing
+type HandlerMiddleware func(EventHandler) EventHandler
+
+// Chain wraps handler in middleware; the first middleware runs outermost
+func Chain(handler EventHandler, middleware ...HandlerMiddleware) EventHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// WrapHandler builds the handler a bus invokes for a subscription
+// The global middleware runs outside the subscription's own, and both see a
+// panic of handler as a *PanicError
+func WrapHandler(handler EventHandler, global, own []HandlerMiddleware) EventHandler {
+	recovering := func(ctx context.Context, event Event) error {
+		return CallHandler(ctx, handler, event)
+	}
+
+	middleware := make([]HandlerMiddleware, 0, len(global)+len(own))
+	middleware = append(middleware, global...)
+	middleware = append(middleware, own...)
+	return Chain(recovering, middleware...)
+}
+
+// DefaultMiddleware is the global middleware of a bus configured without any
+func DefaultMiddleware(log logger.Logger) []HandlerMiddleware {
+	return []HandlerMiddleware{LoggingMiddleware(log)}
+}
+
+// HandlerInfo describes the handler invocation a middleware runs in
+type HandlerInfo struct {
+	// Name is the subscription name, see WithHandlerName
+	Name string
+	// Attempt counts the invocations for this event, starting at 1
+	Attempt     int
+	MaxAttempts int
+}
+
+type handlerInfoKey struct{}
+
+// WithHandlerInfo attaches the invocation details to a handler context
+func WithHandlerInfo(ctx context.Context, info HandlerInfo) context.Context {
+	return context.WithValue(ctx, handlerInfoKey{}, info)
+}
+
+// HandlerInfoFromContext returns the invocation details set by the bus
+func HandlerInfoFromContext(ctx context.Context) (HandlerInfo, bool) {
+	info, ok := ctx.Value(handlerInfoKey{}).(HandlerInfo)
+	return info, ok
+}
+
+// LoggingMiddleware logs the outcome and duration of every handler invocation
+// The stack of a panic is logged by the bus, see LogPanic
+func LoggingMiddleware(log logger.Logger) HandlerMiddleware {
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, event Event) error {
+			start := time.Now()
+			err := next(ctx, event)
+			duration := time.Since(start)
+
+			info, _ := HandlerInfoFromContext(ctx)
+			env, _ := EnvelopeFromContext(ctx)
+
+			if err == nil {
+				log.Debug("Handler succeeded",
+					logger.String("event_type", event.EventType()),
+					logger.String("event_id", env.EventID),
+					logger.String("handler", info.Name),
+					logger.Int("attempt", info.Attempt),
+					logger.Duration("duration", duration),
+				)
+				return nil
+			}
+
+			log.Error("Handler failed",
+				logger.String("event_type", event.EventType()),
+				logger.String("event_id", env.EventID),
+				logger.String("correlation_id", env.CorrelationID),
+				logger.String("handler", info.Name),
+				logger.Int("attempt", info.Attempt),
+				logger.Int("max_attempts", info.MaxAttempts),
+				logger.Any("retryable", IsRetryable(err)),
+				logger.Duration("duration", duration),
+				logger.Error(err),
+			)
+			return err
+		}
+	}
+}
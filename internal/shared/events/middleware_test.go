@@ -0,0 +1,215 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMiddleware appends name to calls before and after the handler runs
+func recordingMiddleware(mu *sync.Mutex, calls *[]string, name string) events.HandlerMiddleware {
+	return func(next events.EventHandler) events.EventHandler {
+		return func(ctx context.Context, event events.Event) error {
+			mu.Lock()
+			*calls = append(*calls, name+" before")
+			mu.Unlock()
+
+			err := next(ctx, event)
+
+			mu.Lock()
+			*calls = append(*calls, name+" after")
+			mu.Unlock()
+			return err
+		}
+	}
+}
+
+func TestChain_FirstMiddlewareRunsOutermost(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	handler := events.Chain(func(ctx context.Context, event events.Event) error {
+		calls = append(calls, "handler")
+		return nil
+	}, recordingMiddleware(&mu, &calls, "outer"), recordingMiddleware(&mu, &calls, "inner"))
+
+	if err := handler(context.Background(), &testEvent{}); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	want := []string{"outer before", "inner before", "handler", "inner after", "outer after"}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, calls)
+		}
+	}
+}
+
+func TestMemoryEventBus_GlobalMiddlewareWrapsSubscriptionMiddleware(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    1,
+		ChannelBuffer:  10,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    events.NoRetry(),
+		Middleware:     []events.HandlerMiddleware{recordingMiddleware(&mu, &calls, "global")},
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		mu.Lock()
+		calls = append(calls, "handler")
+		mu.Unlock()
+		return nil
+	}, events.WithMiddleware(recordingMiddleware(&mu, &calls, "subscription")))
+
+	if err := publishAndWait(t, bus, &testEvent{}); err != nil {
+		t.Fatalf("expected successful ack, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"global before", "subscription before", "handler", "subscription after", "global after"}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, calls)
+		}
+	}
+}
+
+func TestMemoryEventBus_MiddlewareSeesHandlerInfoAndPanics(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	var mu sync.Mutex
+	var infos []events.HandlerInfo
+	var panics int
+	observe := func(next events.EventHandler) events.EventHandler {
+		return func(ctx context.Context, event events.Event) error {
+			err := next(ctx, event)
+
+			info, _ := events.HandlerInfoFromContext(ctx)
+			var panicErr *events.PanicError
+			mu.Lock()
+			infos = append(infos, info)
+			if errors.As(err, &panicErr) {
+				panics++
+			}
+			mu.Unlock()
+			return err
+		}
+	}
+
+	bus.SubscribeNamed("test.happened", "exploding", func(ctx context.Context, event events.Event) error {
+		panic("boom")
+	}, events.WithMiddleware(observe))
+
+	if err := publishAndWait(t, bus, &testEvent{}); !errors.Is(err, events.ErrDeadLettered) {
+		t.Fatalf("expected ErrDeadLettered, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(infos) != 3 || panics != 3 {
+		t.Fatalf("expected 3 observed panics, got %d infos and %d panics", len(infos), panics)
+	}
+	for i, info := range infos {
+		if info.Name != "exploding" || info.Attempt != i+1 || info.MaxAttempts != 3 {
+			t.Errorf("unexpected handler info %+v", info)
+		}
+	}
+}
+
+func TestMemoryEventBus_PanickingMiddlewareIsRecovered(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		return nil
+	}, events.WithMiddleware(func(next events.EventHandler) events.EventHandler {
+		return func(ctx context.Context, event events.Event) error {
+			panic("broken middleware")
+		}
+	}))
+
+	if err := publishAndWait(t, bus, &testEvent{}); !errors.Is(err, events.ErrDeadLettered) {
+		t.Fatalf("expected ErrDeadLettered, got %v", err)
+	}
+}
+
+// recordingLogger keeps the fields of every error it logs, by message
+type recordingLogger struct {
+	logger.NoopLogger
+	mu     sync.Mutex
+	errors map[string][]logger.Field
+}
+
+func (l *recordingLogger) Error(msg string, fields ...logger.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors[msg] = fields
+}
+
+func (l *recordingLogger) With(fields ...logger.Field) logger.Logger {
+	return l
+}
+
+func (l *recordingLogger) field(msg, key string) (any, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, field := range l.errors[msg] {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+func TestMemoryEventBus_LogsPanicStackWithoutMiddleware(t *testing.T) {
+	// Arrange
+	log := &recordingLogger{errors: make(map[string][]logger.Field)}
+	bus := events.NewMemoryEventBusWithConfig(log, events.Config{
+		WorkerCount:    1,
+		ChannelBuffer:  10,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    events.NoRetry(),
+		Middleware:     []events.HandlerMiddleware{},
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	bus.SubscribeNamed("test.happened", "exploding", func(ctx context.Context, event events.Event) error {
+		panic("boom")
+	})
+
+	// Act
+	if err := publishAndWait(t, bus, &testEvent{}); !errors.Is(err, events.ErrDeadLettered) {
+		t.Fatalf("expected ErrDeadLettered, got %v", err)
+	}
+
+	// Assert
+	stack, ok := log.field("Handler panicked", "stack")
+	if !ok {
+		t.Fatalf("expected the panic to be logged, got %v", log.errors)
+	}
+	if s, _ := stack.(string); !strings.Contains(s, "TestMemoryEventBus_LogsPanicStackWithoutMiddleware") {
+		t.Errorf("expected the stack of the handler, got %q", s)
+	}
+	if handler, _ := log.field("Handler panicked", "handler"); handler != "exploding" {
+		t.Errorf("expected handler 'exploding', got %v", handler)
+	}
+}
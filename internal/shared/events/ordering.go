@@ -0,0 +1,17 @@
+package events
+
+// OrderedEvent is implemented by events that must be handled in publish
+// order relative to the other events with the same key
+type OrderedEvent interface {
+	OrderingKey() string
+}
+
+// OrderingKeyOf returns the ordering key of event: its OrderingKey if it has
+// one, its aggregate ID otherwise, and empty for events that need no ordering
+// Pass it as Config.OrderingKey to keep the events of one aggregate in order
+func OrderingKeyOf(event Event) string {
+	if e, ok := event.(OrderedEvent); ok {
+		return e.OrderingKey()
+	}
+	return AggregateIDOf(event)
+}
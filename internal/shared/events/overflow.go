@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultBlockTimeout is how long a publish waits for room in a full queue
+const DefaultBlockTimeout = 5 * time.Second
+
+// ErrQueueFull is returned when a publish found the queue full and the
+// overflow policy gave up on it; callers should retry later
+var ErrQueueFull = errors.New("event queue full")
+
+// OverflowPolicy decides what a publish does when the queue is full
+type OverflowPolicy string
+
+const (
+	// OverflowBlock waits up to the block timeout for room, then fails with ErrQueueFull
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowFail fails with ErrQueueFull right away
+	OverflowFail OverflowPolicy = "fail"
+	// OverflowSpill stores the event in a SpillStore and queues it once there is room
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+// Admitter is implemented by buses with a bounded queue
+// Admit returns ErrQueueFull while publishing would fail or have to wait for
+// room, so callers can turn work away before accepting it
+type Admitter interface {
+	Admit() error
+}
+
+// QueueDepth is a snapshot of how full a bus queue is
+type QueueDepth struct {
+	Queued   int `json:"queued"`
+	Capacity int `json:"capacity"`
+	// Spilled counts the events waiting in the spill store
+	Spilled int `json:"spilled"`
+}
+
+// SpilledEvent is an event waiting in a SpillStore
+type SpilledEvent struct {
+	Event    Event
+	Envelope Envelope
+}
+
+// SpillStore holds published events the queue had no room for
+// Events are popped in the order they were pushed
+type SpillStore interface {
+	Push(ctx context.Context, event Event, env Envelope) error
+	// Pop removes and returns up to n of the oldest events
+	Pop(ctx context.Context, n int) ([]SpilledEvent, error)
+	Len(ctx context.Context) (int, error)
+}
+
+// MemorySpillStore is an in-memory spill store for tests
+// Unlike a persistent store it loses its events when the process stops
+type MemorySpillStore struct {
+	mu     sync.Mutex
+	events []SpilledEvent
+}
+
+// NewMemorySpillStore creates an empty in-memory spill store
+func NewMemorySpillStore() *MemorySpillStore {
+	return &MemorySpillStore{}
+}
+
+// Push appends an event
+func (s *MemorySpillStore) Push(ctx context.Context, event Event, env Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, SpilledEvent{Event: event, Envelope: env})
+	return nil
+}
+
+// Pop removes and returns up to n of the oldest events
+func (s *MemorySpillStore) Pop(ctx context.Context, n int) ([]SpilledEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n = min(n, len(s.events))
+	popped := make([]SpilledEvent, n)
+	copy(popped, s.events)
+	s.events = s.events[n:]
+	return popped, nil
+}
+
+// Len returns the number of spilled events
+func (s *MemorySpillStore) Len(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.events), nil
+}
+
+var _ SpillStore = (*MemorySpillStore)(nil)
@@ -0,0 +1,193 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newSaturatedBus returns a bus with one worker stuck in its handler and a
+// full queue of one event; closing release lets the worker continue
+func newSaturatedBus(t *testing.T, cfg events.Config) (bus *events.MemoryEventBus, release chan struct{}, handled func() []string) {
+	t.Helper()
+
+	cfg.WorkerCount = 1
+	cfg.ChannelBuffer = 1
+	cfg.HandlerTimeout = 5 * time.Second
+	cfg.RetryPolicy = events.NoRetry()
+	bus = events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), cfg)
+
+	var mu sync.Mutex
+	var ids []string
+	release = make(chan struct{})
+	started := make(chan struct{}, 1)
+	_, _ = bus.SubscribeNamed("test.happened", "recorder", func(ctx context.Context, event events.Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		ids = append(ids, event.(*testEvent).id)
+		return nil
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	if err := bus.Publish(context.Background(), &testEvent{id: "running"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	<-started
+	if err := bus.Publish(context.Background(), &testEvent{id: "queued"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	handled = func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), ids...)
+	}
+	return bus, release, handled
+}
+
+func TestMemoryEventBus_OverflowFailRejectsRightAway(t *testing.T) {
+	bus, release, _ := newSaturatedBus(t, events.Config{Overflow: events.OverflowFail})
+	defer close(release)
+
+	start := time.Now()
+	err := bus.Publish(context.Background(), &testEvent{id: "rejected"})
+	if !errors.Is(err, events.ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("expected an immediate rejection, took %v", elapsed)
+	}
+
+	if err := bus.Admit(); !errors.Is(err, events.ErrQueueFull) {
+		t.Errorf("expected Admit() to report ErrQueueFull, got %v", err)
+	}
+	if depth := bus.QueueDepth(); depth.Queued != 1 || depth.Capacity != 1 {
+		t.Errorf("expected 1 of 1 queued, got %+v", depth)
+	}
+}
+
+func TestMemoryEventBus_OverflowBlockGivesUpAfterTimeout(t *testing.T) {
+	bus, release, _ := newSaturatedBus(t, events.Config{BlockTimeout: 50 * time.Millisecond})
+	defer close(release)
+
+	start := time.Now()
+	err := bus.Publish(context.Background(), &testEvent{id: "rejected"})
+	if !errors.Is(err, events.ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected publish to wait for the block timeout, took %v", elapsed)
+	}
+}
+
+func TestMemoryEventBus_OverflowBlockSucceedsOnceThereIsRoom(t *testing.T) {
+	bus, release, handled := newSaturatedBus(t, events.Config{BlockTimeout: 5 * time.Second})
+
+	published := make(chan error, 1)
+	go func() { published <- bus.Publish(context.Background(), &testEvent{id: "waited"}) }()
+	close(release)
+
+	if err := <-published; err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+	if got := handled(); len(got) != 3 {
+		t.Errorf("expected 3 handled events, got %v", got)
+	}
+}
+
+func TestMemoryEventBus_OverflowSpillDeliversInOrder(t *testing.T) {
+	bus, release, handled := newSaturatedBus(t, events.Config{Overflow: events.OverflowSpill})
+
+	acked := make(chan error, 3)
+	for _, id := range []string{"spilled-1", "spilled-2", "spilled-3"} {
+		if err := bus.PublishWithAck(context.Background(), &testEvent{id: id}, func(err error) { acked <- err }); err != nil {
+			t.Fatalf("PublishWithAck() error = %v", err)
+		}
+	}
+	if depth := bus.QueueDepth(); depth.Spilled != 3 {
+		t.Errorf("expected 3 spilled events, got %+v", depth)
+	}
+	if err := bus.Admit(); err != nil {
+		t.Errorf("expected a spilling bus to admit, got %v", err)
+	}
+
+	close(release)
+	for range 3 {
+		select {
+		case err := <-acked:
+			if err != nil {
+				t.Errorf("expected successful ack, got %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for ack")
+		}
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+
+	want := []string{"running", "queued", "spilled-1", "spilled-2", "spilled-3"}
+	got := handled()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestMemoryEventBus_SpilledEventsSurviveRestart(t *testing.T) {
+	spill := events.NewMemorySpillStore()
+	if err := spill.Push(context.Background(), &testEvent{id: "left-over"}, events.Envelope{EventID: "event-1"}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	bus := newTestBusWithConfig(t, events.Config{Overflow: events.OverflowSpill, Spill: spill})
+	received := make(chan string, 1)
+	_, _ = bus.SubscribeNamed("test.happened", "recorder", func(ctx context.Context, event events.Event) error {
+		received <- event.(*testEvent).id
+		return nil
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	select {
+	case id := <-received:
+		if id != "left-over" {
+			t.Errorf("expected left-over, got %s", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the spilled event")
+	}
+}
+
+// newTestBusWithConfig creates a bus that is stopped when the test ends;
+// the caller subscribes and starts it
+func newTestBusWithConfig(t *testing.T, cfg events.Config) *events.MemoryEventBus {
+	t.Helper()
+
+	cfg.WorkerCount = 2
+	cfg.ChannelBuffer = 10
+	cfg.HandlerTimeout = time.Second
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), cfg)
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+	return bus
+}
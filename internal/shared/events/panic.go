@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/shared/logger"
+	"runtime/debug"
+)
+
+// PanicError is the handler error reported when a handler panics
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("handler panicked: %v", e.Value)
+}
+
+// CallHandler runs the handler, turning a panic into a *PanicError
+// so one misbehaving handler cannot take down the worker
+func CallHandler(ctx context.Context, handler EventHandler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return handler(ctx, event)
+}
+
+// LogPanic logs the stack of a handler panic; err is what CallHandler
+// returned for event, ctx the handler context. Buses call it themselves so
+// the stack is logged whatever middleware is configured
+func LogPanic(ctx context.Context, log logger.Logger, event Event, err error) {
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		return
+	}
+
+	info, _ := HandlerInfoFromContext(ctx)
+	env, _ := EnvelopeFromContext(ctx)
+
+	log.Error("Handler panicked",
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+		logger.String("handler", info.Name),
+		logger.Int("attempt", info.Attempt),
+		logger.Any("panic", panicErr.Value),
+		logger.String("stack", string(panicErr.Stack)),
+	)
+}
@@ -0,0 +1,38 @@
+package events
+
+import "strings"
+
+// Wildcard is the subscription pattern matching every event type
+// A pattern ending in ".*", such as "recipe.*", matches every event type
+// with that prefix
+const Wildcard = "*"
+
+// IsPattern reports whether eventType is a subscription pattern rather than
+// a single event type
+func IsPattern(eventType string) bool {
+	return eventType == Wildcard || strings.HasSuffix(eventType, "."+Wildcard)
+}
+
+// MatchEventType reports whether the subscription pattern matches eventType
+// Patterns without a wildcard match only the identical event type
+func MatchEventType(pattern, eventType string) bool {
+	if pattern == Wildcard {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, Wildcard); ok && strings.HasSuffix(prefix, ".") {
+		return strings.HasPrefix(eventType, prefix)
+	}
+	return pattern == eventType
+}
+
+// patternsOverlap reports whether some event type matches both patterns
+func patternsOverlap(a, b string) bool {
+	if !IsPattern(a) {
+		return MatchEventType(b, a)
+	}
+	if !IsPattern(b) {
+		return MatchEventType(a, b)
+	}
+	prefixA, prefixB := strings.TrimSuffix(a, Wildcard), strings.TrimSuffix(b, Wildcard)
+	return strings.HasPrefix(prefixA, prefixB) || strings.HasPrefix(prefixB, prefixA)
+}
@@ -0,0 +1,50 @@
+package events_test
+
+import (
+	"recipe-processor/internal/shared/events"
+	"testing"
+)
+
+func TestMatchEventType(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		eventType string
+		want      bool
+	}{
+		{"recipe.submitted", "recipe.submitted", true},
+		{"recipe.submitted", "recipe.parsed", false},
+		{"*", "recipe.submitted", true},
+		{"*", "user.created", true},
+		{"recipe.*", "recipe.submitted", true},
+		{"recipe.*", "recipe.export.failed", true},
+		{"recipe.*", "recipes.submitted", false},
+		{"recipe.*", "recipe", false},
+		{"recipe*", "recipe.submitted", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.eventType, func(t *testing.T) {
+			if got := events.MatchEventType(tt.pattern, tt.eventType); got != tt.want {
+				t.Errorf("MatchEventType(%q, %q) = %v, want %v", tt.pattern, tt.eventType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPattern(t *testing.T) {
+	tests := []struct {
+		eventType string
+		want      bool
+	}{
+		{"recipe.submitted", false},
+		{"*", true},
+		{"recipe.*", true},
+		{"recipe*", false},
+	}
+
+	for _, tt := range tests {
+		if got := events.IsPattern(tt.eventType); got != tt.want {
+			t.Errorf("IsPattern(%q) = %v, want %v", tt.eventType, got, tt.want)
+		}
+	}
+}
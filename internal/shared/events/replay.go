@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// ReplayHeader marks events republished by Replay
+	ReplayHeader = "replay"
+	// replayBatchSize is how many stored events Replay loads at a time
+	replayBatchSize = 100
+)
+
+// Replay republishes the stored events matching filter, oldest first, and
+// returns how many were published
+// Events appended while replaying, including the replayed copies when the bus
+// records into store, are not replayed
+// Replayed events get a new event ID, keep the correlation ID of the original
+// and name it as their cause, so handlers see them as fresh deliveries
+func Replay(ctx context.Context, store EventStore, bus EventBus, filter EventFilter) (int, error) {
+	limit := filter.Limit
+	replayed := 0
+
+	last, err := store.LastSequence(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read last sequence: %w", err)
+	}
+	if last == 0 {
+		return 0, nil
+	}
+	if filter.UpToSequence == 0 || filter.UpToSequence > last {
+		filter.UpToSequence = last
+	}
+
+	for limit == 0 || replayed < limit {
+		page := filter
+		page.Limit = replayBatchSize
+		if limit > 0 {
+			page.Limit = min(replayBatchSize, limit-replayed)
+		}
+
+		stored, err := store.Load(ctx, page)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to load events: %w", err)
+		}
+		if len(stored) == 0 {
+			break
+		}
+
+		for _, se := range stored {
+			replayCtx := WithHeaders(ContextWithEnvelope(ctx, se.Envelope), map[string]string{ReplayHeader: "true"})
+			if err := bus.Publish(replayCtx, se.Event); err != nil {
+				return replayed, fmt.Errorf("failed to replay event %s: %w", se.Envelope.EventID, err)
+			}
+			replayed++
+			filter.AfterSequence = se.Sequence
+		}
+	}
+
+	return replayed, nil
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is how often a handler is invoked before the event is dead-lettered
+	DefaultMaxAttempts = 3
+	// DefaultInitialBackoff is the delay before the first retry
+	DefaultInitialBackoff = time.Second
+	// DefaultMaxBackoff caps the delay between retries
+	DefaultMaxBackoff = 30 * time.Second
+	// DefaultBackoffMultiplier is the growth factor of the delay per attempt
+	DefaultBackoffMultiplier = 2.0
+	// DefaultJitter is the fraction of each delay that is randomized
+	DefaultJitter = 0.2
+)
+
+// ErrPermanent marks handler errors that retrying cannot fix
+var ErrPermanent = errors.New("permanent failure")
+
+// RetryableError lets errors decide whether the failed handler should be retried
+type RetryableError interface {
+	Retryable() bool
+}
+
+// Permanent wraps err so the bus dead-letters the event without retrying
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string   { return e.err.Error() }
+func (e *permanentError) Unwrap() []error { return []error{ErrPermanent, e.err} }
+func (e *permanentError) Retryable() bool { return false }
+
+// IsRetryable reports whether a handler failing with err should be retried
+// Errors are retryable unless they wrap ErrPermanent or a RetryableError says otherwise
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, ErrPermanent) {
+		return false
+	}
+
+	var re RetryableError
+	if errors.As(err, &re) {
+		return re.Retryable()
+	}
+
+	return true
+}
+
+// WillRetry reports whether the bus retries the handler running in ctx when it
+// fails with err; outside a bus invocation nothing is retried
+func WillRetry(ctx context.Context, err error) bool {
+	info, ok := HandlerInfoFromContext(ctx)
+	return ok && IsRetryable(err) && info.Attempt < info.MaxAttempts
+}
+
+// RetryPolicy controls how a failed handler is retried
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt; 1 disables retries
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is the fraction of the delay, between 0 and 1, that is randomized
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the policy used when a subscription sets none
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    DefaultMaxAttempts,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+		Multiplier:     DefaultBackoffMultiplier,
+		Jitter:         DefaultJitter,
+	}
+}
+
+// NoRetry is a policy that dead-letters on the first failure
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// Backoff returns the delay before the given retry, 1 being the first retry
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,96 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+	"testing"
+	"time"
+)
+
+type statusError struct{ retryable bool }
+
+func (e *statusError) Error() string   { return "status error" }
+func (e *statusError) Retryable() bool { return e.retryable }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("timeout"), true},
+		{"permanent", events.Permanent(errors.New("bad input")), false},
+		{"wrapped permanent", fmt.Errorf("handler: %w", events.Permanent(errors.New("bad input"))), false},
+		{"retryable interface", fmt.Errorf("call: %w", &statusError{retryable: true}), true},
+		{"non-retryable interface", fmt.Errorf("call: %w", &statusError{retryable: false}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := events.IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWillRetry(t *testing.T) {
+	cause := errors.New("timeout")
+	second := events.WithHandlerInfo(context.Background(), events.HandlerInfo{Attempt: 2, MaxAttempts: 3})
+	last := events.WithHandlerInfo(context.Background(), events.HandlerInfo{Attempt: 3, MaxAttempts: 3})
+
+	if !events.WillRetry(second, cause) {
+		t.Error("expected a retryable error before the last attempt to be retried")
+	}
+	if events.WillRetry(second, events.Permanent(cause)) {
+		t.Error("expected a permanent error not to be retried")
+	}
+	if events.WillRetry(last, cause) {
+		t.Error("expected the last attempt not to be retried")
+	}
+	if events.WillRetry(context.Background(), cause) {
+		t.Error("expected no retry outside a bus invocation")
+	}
+}
+
+func TestPermanent_KeepsCause(t *testing.T) {
+	cause := errors.New("bad input")
+	err := events.Permanent(cause)
+
+	if !errors.Is(err, cause) || !errors.Is(err, events.ErrPermanent) {
+		t.Errorf("expected error to wrap both cause and ErrPermanent, got %v", err)
+	}
+	if err.Error() != "bad input" {
+		t.Errorf("Error() = %q, want cause message", err.Error())
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := events.RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := policy.Backoff(i + 1); got != w {
+			t.Errorf("Backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffJitter(t *testing.T) {
+	policy := events.RetryPolicy{InitialBackoff: time.Second, Multiplier: 2, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		got := policy.Backoff(2)
+		if got < time.Second || got > 2*time.Second {
+			t.Fatalf("Backoff(2) = %v, want within [1s, 2s]", got)
+		}
+	}
+}
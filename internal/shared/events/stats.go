@@ -0,0 +1,85 @@
+package events
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// StatsReporter is implemented by buses that report their activity
+type StatsReporter interface {
+	Stats() BusStats
+}
+
+// Pauser is implemented by buses that can hold back the events of a type
+// at runtime, for example while the system a handler calls is down
+type Pauser interface {
+	// Pause holds back deliveries of the event type or pattern until it is resumed
+	Pause(eventType string)
+	// Resume delivers the held back events and the later ones again
+	Resume(eventType string)
+}
+
+// BusStats is a snapshot of a bus's activity
+type BusStats struct {
+	Queue       QueueDepth
+	Workers     int
+	BusyWorkers int
+	// Paused lists the paused event types and patterns, Held counts the
+	// deliveries they hold back
+	Paused []string
+	Held   int
+	// Subscriptions maps each subscribed event type or pattern to its handlers
+	Subscriptions map[string][]HandlerStats
+}
+
+// HandlerStats counts the invocations of one subscription since it was made
+type HandlerStats struct {
+	Name string
+	// Succeeded and Failed count invocations by outcome, so a retried event
+	// counts once per attempt; together they are the invocations so far
+	Succeeded      int64
+	Failed         int64
+	DeadLettered   int64
+	AverageLatency time.Duration
+	MaxLatency     time.Duration
+}
+
+// handlerStats collects HandlerStats while handlers run
+type handlerStats struct {
+	succeeded    atomic.Int64
+	failed       atomic.Int64
+	deadLettered atomic.Int64
+	totalLatency atomic.Int64
+	maxLatency   atomic.Int64
+}
+
+// observe records the outcome of one invocation
+func (s *handlerStats) observe(latency time.Duration, err error) {
+	if err == nil {
+		s.succeeded.Add(1)
+	} else {
+		s.failed.Add(1)
+	}
+
+	s.totalLatency.Add(int64(latency))
+	for {
+		current := s.maxLatency.Load()
+		if int64(latency) <= current || s.maxLatency.CompareAndSwap(current, int64(latency)) {
+			return
+		}
+	}
+}
+
+func (s *handlerStats) snapshot(name string) HandlerStats {
+	stats := HandlerStats{
+		Name:         name,
+		Succeeded:    s.succeeded.Load(),
+		Failed:       s.failed.Load(),
+		DeadLettered: s.deadLettered.Load(),
+		MaxLatency:   time.Duration(s.maxLatency.Load()),
+	}
+	if calls := stats.Succeeded + stats.Failed; calls > 0 {
+		stats.AverageLatency = time.Duration(s.totalLatency.Load() / calls)
+	}
+	return stats
+}
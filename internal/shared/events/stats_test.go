@@ -0,0 +1,118 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryEventBus_StatsCountsHandlerOutcomes(t *testing.T) {
+	bus := newTestBus(t, events.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	bus.SubscribeNamed("test.happened", "ok", func(ctx context.Context, event events.Event) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	bus.SubscribeNamed("test.*", "broken", func(ctx context.Context, event events.Event) error {
+		return errors.New("broken")
+	})
+
+	_ = publishAndWait(t, bus, &testEvent{id: "1"})
+
+	stats := bus.Stats()
+	if stats.Workers != 2 || stats.BusyWorkers != 0 {
+		t.Errorf("expected 2 idle workers, got %d of %d busy", stats.BusyWorkers, stats.Workers)
+	}
+
+	ok := stats.Subscriptions["test.happened"]
+	if len(ok) != 1 || ok[0].Name != "ok" || ok[0].Succeeded != 1 || ok[0].Failed != 0 {
+		t.Fatalf("unexpected stats for test.happened: %+v", ok)
+	}
+	if ok[0].AverageLatency < time.Millisecond || ok[0].MaxLatency < ok[0].AverageLatency {
+		t.Errorf("expected latency of at least 1ms, got %+v", ok[0])
+	}
+
+	broken := stats.Subscriptions["test.*"]
+	if len(broken) != 1 || broken[0].Failed != 2 || broken[0].DeadLettered != 1 || broken[0].Succeeded != 0 {
+		t.Errorf("expected 2 failed attempts and 1 dead letter, got %+v", broken)
+	}
+}
+
+func TestMemoryEventBus_StatsReportsBusyWorkers(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	bus.SubscribeNamed("test.happened", "slow", func(ctx context.Context, event events.Event) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	_ = bus.Publish(context.Background(), &testEvent{id: "1"})
+	<-started
+
+	if busy := bus.Stats().BusyWorkers; busy != 1 {
+		t.Errorf("expected 1 busy worker, got %d", busy)
+	}
+	close(release)
+}
+
+func TestMemoryEventBus_PauseHoldsEventsUntilResumed(t *testing.T) {
+	bus := newTestBus(t, events.NoRetry())
+
+	var paused, other atomic.Int32
+	bus.SubscribeNamed("test.happened", "paused", func(ctx context.Context, event events.Event) error {
+		paused.Add(1)
+		return nil
+	})
+	bus.SubscribeNamed("other.happened", "other", func(ctx context.Context, event events.Event) error {
+		other.Add(1)
+		return nil
+	})
+
+	bus.Pause("test.*")
+	_ = bus.Publish(context.Background(), &testEvent{id: "1"})
+	_ = bus.Publish(context.Background(), &testEvent{id: "2"})
+	if err := publishAndWait(t, bus, &otherEvent{}); err != nil {
+		t.Fatalf("expected other events to keep flowing, got %v", err)
+	}
+
+	waitUntil(t, func() bool { return bus.Stats().Held == 2 })
+	stats := bus.Stats()
+	if len(stats.Paused) != 1 || stats.Paused[0] != "test.*" {
+		t.Errorf("expected test.* to be paused, got %v", stats.Paused)
+	}
+	if paused.Load() != 0 || other.Load() != 1 {
+		t.Fatalf("expected only the other event to be handled, got %d paused and %d other", paused.Load(), other.Load())
+	}
+
+	bus.Resume("test.*")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := bus.WaitIdle(ctx); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+	if paused.Load() != 2 {
+		t.Errorf("expected held events to be handled after resume, got %d", paused.Load())
+	}
+	if held := bus.Stats().Held; held != 0 {
+		t.Errorf("expected nothing held after resume, got %d", held)
+	}
+}
+
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,139 @@
+package events
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// AggregateEvent is implemented by events that belong to an aggregate,
+// such as a recipe; event stores key them by the aggregate ID
+type AggregateEvent interface {
+	AggregateID() string
+}
+
+// AggregateIDOf returns the aggregate ID of event, empty if it has none
+func AggregateIDOf(event Event) string {
+	if e, ok := event.(AggregateEvent); ok {
+		return e.AggregateID()
+	}
+	return ""
+}
+
+// StoredEvent is an event as recorded in an EventStore
+type StoredEvent struct {
+	// Sequence orders events in the store, starting at 1
+	Sequence    int64
+	AggregateID string
+	Event       Event
+	Envelope    Envelope
+}
+
+// EventFilter selects stored events; zero fields match everything
+type EventFilter struct {
+	AggregateID string
+	EventTypes  []string
+	// From and To bound OccurredAt; From is inclusive, To exclusive
+	From time.Time
+	To   time.Time
+	// AfterSequence skips events up to and including this sequence
+	AfterSequence int64
+	// UpToSequence skips events after this sequence; 0 means no bound
+	UpToSequence int64
+	// Limit caps the number of events returned; 0 means no limit
+	Limit int
+}
+
+// Matches reports whether the stored event passes the filter, ignoring Limit
+func (f EventFilter) Matches(se StoredEvent) bool {
+	if se.Sequence <= f.AfterSequence {
+		return false
+	}
+	if f.UpToSequence > 0 && se.Sequence > f.UpToSequence {
+		return false
+	}
+	if f.AggregateID != "" && se.AggregateID != f.AggregateID {
+		return false
+	}
+	if len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, se.Event.EventType()) {
+		return false
+	}
+	occurredAt := se.Event.OccurredAt()
+	if !f.From.IsZero() && occurredAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !occurredAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// EventStore is an append-only log of published events
+type EventStore interface {
+	// Append records a published event; appending an event ID again is a no-op
+	// so publishers that redeliver do not duplicate history
+	Append(ctx context.Context, event Event, env Envelope) error
+	// Load returns the events matching filter in append order
+	Load(ctx context.Context, filter EventFilter) ([]StoredEvent, error)
+	// LastSequence returns the sequence of the newest event, 0 when empty
+	LastSequence(ctx context.Context) (int64, error)
+}
+
+// MemoryEventStore keeps events in memory, for tests and single-process setups
+type MemoryEventStore struct {
+	mu     sync.RWMutex
+	events []StoredEvent
+	ids    map[string]bool
+}
+
+// NewMemoryEventStore creates an empty in-memory event store
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{ids: make(map[string]bool)}
+}
+
+// Append records a published event
+func (s *MemoryEventStore) Append(ctx context.Context, event Event, env Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ids[env.EventID] {
+		return nil
+	}
+	s.ids[env.EventID] = true
+
+	s.events = append(s.events, StoredEvent{
+		Sequence:    int64(len(s.events) + 1),
+		AggregateID: AggregateIDOf(event),
+		Event:       event,
+		Envelope:    env,
+	})
+	return nil
+}
+
+// Load returns the events matching filter in append order
+func (s *MemoryEventStore) Load(ctx context.Context, filter EventFilter) ([]StoredEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched []StoredEvent
+	for _, se := range s.events {
+		if filter.Limit > 0 && len(matched) == filter.Limit {
+			break
+		}
+		if filter.Matches(se) {
+			matched = append(matched, se)
+		}
+	}
+	return matched, nil
+}
+
+// LastSequence returns the sequence of the newest event, 0 when empty
+func (s *MemoryEventStore) LastSequence(ctx context.Context) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return int64(len(s.events)), nil
+}
+
+var _ EventStore = (*MemoryEventStore)(nil)
@@ -0,0 +1,223 @@
+package events_test
+
+import (
+	"context"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recipeEvent belongs to an aggregate and has a fixed occurrence time
+type recipeEvent struct {
+	recipeID string
+	at       time.Time
+}
+
+func (e *recipeEvent) EventType() string     { return "test.recipe" }
+func (e *recipeEvent) OccurredAt() time.Time { return e.at }
+func (e *recipeEvent) AggregateID() string   { return e.recipeID }
+
+func appendEvents(t *testing.T, store events.EventStore, evts ...events.Event) {
+	t.Helper()
+
+	for _, event := range evts {
+		env := events.NewEnvelope(context.Background(), event, "tests")
+		if err := store.Append(context.Background(), event, env); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+}
+
+func TestMemoryEventStore_LoadFilters(t *testing.T) {
+	store := events.NewMemoryEventStore()
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	appendEvents(t, store,
+		&recipeEvent{recipeID: "recipe-1", at: base},
+		&recipeEvent{recipeID: "recipe-2", at: base.Add(time.Hour)},
+		&otherEvent{},
+		&recipeEvent{recipeID: "recipe-1", at: base.Add(2 * time.Hour)},
+	)
+
+	tests := []struct {
+		name   string
+		filter events.EventFilter
+		want   []int64
+	}{
+		{name: "everything", filter: events.EventFilter{}, want: []int64{1, 2, 3, 4}},
+		{name: "by aggregate", filter: events.EventFilter{AggregateID: "recipe-1"}, want: []int64{1, 4}},
+		{name: "by type", filter: events.EventFilter{EventTypes: []string{"other.happened"}}, want: []int64{3}},
+		{name: "by time window", filter: events.EventFilter{From: base.Add(time.Hour), To: base.Add(2 * time.Hour)}, want: []int64{2}},
+		{name: "after sequence with limit", filter: events.EventFilter{AfterSequence: 1, Limit: 2}, want: []int64{2, 3}},
+		{name: "up to sequence", filter: events.EventFilter{AfterSequence: 1, UpToSequence: 3}, want: []int64{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, err := store.Load(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			got := make([]int64, len(stored))
+			for i, se := range stored {
+				got[i] = se.Sequence
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected sequences %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected sequences %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryEventStore_AppendIgnoresKnownEventIDs(t *testing.T) {
+	store := events.NewMemoryEventStore()
+	event := &testEvent{id: "1"}
+	env := events.NewEnvelope(context.Background(), event, "tests")
+
+	_ = store.Append(context.Background(), event, env)
+	_ = store.Append(context.Background(), event, env)
+
+	stored, _ := store.Load(context.Background(), events.EventFilter{})
+	if len(stored) != 1 {
+		t.Errorf("expected 1 stored event, got %d", len(stored))
+	}
+}
+
+func TestMemoryEventBus_AppendsPublishedEventsToStore(t *testing.T) {
+	store := events.NewMemoryEventStore()
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    1,
+		ChannelBuffer:  10,
+		HandlerTimeout: time.Second,
+		Store:          store,
+	})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+	if err := bus.Publish(ctx, &recipeEvent{recipeID: "recipe-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	stored, _ := store.Load(context.Background(), events.EventFilter{})
+	if len(stored) != 1 {
+		t.Fatalf("expected 1 stored event, got %d", len(stored))
+	}
+	if stored[0].AggregateID != "recipe-1" || stored[0].Envelope.CorrelationID != "request-1" {
+		t.Errorf("unexpected stored event %+v", stored[0])
+	}
+}
+
+func TestReplay_RepublishesMatchingEvents(t *testing.T) {
+	store := events.NewMemoryEventStore()
+	appendEvents(t, store,
+		&recipeEvent{recipeID: "recipe-1"},
+		&recipeEvent{recipeID: "recipe-2"},
+		&recipeEvent{recipeID: "recipe-1"},
+	)
+	originals, _ := store.Load(context.Background(), events.EventFilter{AggregateID: "recipe-1"})
+
+	bus := newTestBus(t, fastRetries)
+	received := make(chan events.Envelope, 3)
+	bus.SubscribeNamed("test.recipe", "handler", func(ctx context.Context, event events.Event) error {
+		env, _ := events.EnvelopeFromContext(ctx)
+		received <- env
+		return nil
+	})
+
+	n, err := events.Replay(context.Background(), store, bus, events.EventFilter{AggregateID: "recipe-1"})
+	if err != nil || n != 2 {
+		t.Fatalf("Replay() = %d, %v; want 2, nil", n, err)
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+
+	close(received)
+	i := 0
+	for env := range received {
+		original := originals[i].Envelope
+		if env.CausationID != original.EventID || env.CorrelationID != original.CorrelationID {
+			t.Errorf("replay %d: expected cause %s in correlation %s, got %+v", i, original.EventID, original.CorrelationID, env)
+		}
+		if env.Headers[events.ReplayHeader] != "true" {
+			t.Errorf("replay %d: expected replay header, got %v", i, env.Headers)
+		}
+		i++
+	}
+	if i != 2 {
+		t.Errorf("expected 2 replayed deliveries, got %d", i)
+	}
+}
+
+func TestReplay_StopsAtEventsAppendedWhileReplaying(t *testing.T) {
+	store := events.NewMemoryEventStore()
+	appendEvents(t, store, &recipeEvent{recipeID: "recipe-1"})
+
+	// The bus records into the store it replays from, like cmd/replay
+	bus := newTestBusWithConfig(t, events.Config{RetryPolicy: fastRetries, Store: store})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	var handled atomic.Int32
+	bus.SubscribeNamed("test.recipe", "handler", func(ctx context.Context, event events.Event) error {
+		handled.Add(1)
+		return nil
+	})
+
+	n, err := events.Replay(context.Background(), store, bus, events.EventFilter{})
+	if err != nil || n != 1 {
+		t.Fatalf("Replay() = %d, %v; want 1, nil", n, err)
+	}
+	if err := bus.WaitIdle(context.Background()); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+
+	if got := handled.Load(); got != 1 {
+		t.Errorf("expected 1 delivery, got %d", got)
+	}
+	if last, _ := store.LastSequence(context.Background()); last != 2 {
+		t.Errorf("expected the original and one replayed copy, got %d events", last)
+	}
+}
+
+func TestMemoryEventBus_WaitIdleIncludesChainedEventsAndRetries(t *testing.T) {
+	bus := newTestBus(t, fastRetries)
+
+	var handled atomic.Int32
+	var failures atomic.Int32
+	bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		return bus.Publish(ctx, &otherEvent{})
+	})
+	bus.SubscribeNamed("other.happened", "handler-2", func(ctx context.Context, event events.Event) error {
+		if failures.Add(1) == 1 {
+			return context.DeadlineExceeded
+		}
+		handled.Add(1)
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), &testEvent{id: "1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := bus.WaitIdle(ctx); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+
+	if got := handled.Load(); got != 1 {
+		t.Errorf("expected the chained event to be handled after a retry, got %d", got)
+	}
+}
@@ -0,0 +1,179 @@
+package events
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrSubscriptionExists is returned when subscribing a name already used by
+// a subscription receiving some of the same event types
+var ErrSubscriptionExists = errors.New("subscription name already in use")
+
+// Subscription is a handler registered on a bus
+type Subscription interface {
+	Name() string
+	// EventType is the event type or pattern subscribed to
+	EventType() string
+	// Unsubscribe stops delivering events to the handler; calling it again is a no-op
+	// Deliveries already running finish, queued ones are dropped
+	Unsubscribe()
+}
+
+// Subscribe registers handler on bus under the name set with WithHandlerName,
+// or its function name, suffixed with "#n" when that name is already taken
+// Buses implement EventBus.Subscribe with it
+//
+// Deprecated: call EventBus.SubscribeNamed with an explicit name
+func Subscribe(bus EventBus, eventType string, handler EventHandler, opts ...SubscribeOption) (Subscription, error) {
+	base := ApplySubscribeOptions(handler, RetryPolicy{}, opts...).Name
+	name := base
+	for n := 2; ; n++ {
+		sub, err := bus.SubscribeNamed(eventType, name, handler, opts...)
+		if !errors.Is(err, ErrSubscriptionExists) {
+			return sub, err
+		}
+		name = base + "#" + strconv.Itoa(n)
+	}
+}
+
+// SubscribeOption customizes a single subscription
+type SubscribeOption func(*subscriptionOptions)
+
+type subscriptionOptions struct {
+	name       string
+	retry      *RetryPolicy
+	timeout    time.Duration
+	middleware []HandlerMiddleware
+}
+
+// WithRetryPolicy overrides the bus retry policy for this subscription
+func WithRetryPolicy(policy RetryPolicy) SubscribeOption {
+	return func(o *subscriptionOptions) {
+		o.retry = &policy
+	}
+}
+
+// WithHandlerTimeout overrides the bus handler timeout for this subscription,
+// for handlers such as LLM calls that take longer than most
+func WithHandlerTimeout(timeout time.Duration) SubscribeOption {
+	return func(o *subscriptionOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithHandlerName sets the name identifying the handler in logs and dead letters
+// when none is passed to EventBus.SubscribeNamed
+// It defaults to the handler's function name
+func WithHandlerName(name string) SubscribeOption {
+	return func(o *subscriptionOptions) {
+		o.name = name
+	}
+}
+
+// WithMiddleware wraps this subscription's handler in middleware, inside the
+// bus-wide middleware
+func WithMiddleware(middleware ...HandlerMiddleware) SubscribeOption {
+	return func(o *subscriptionOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// SubscriptionConfig is the outcome of applying SubscribeOptions
+// Bus implementations outside this package use it to honour the options
+type SubscriptionConfig struct {
+	Name  string
+	Retry RetryPolicy
+	// Timeout is set with WithHandlerTimeout, zero means the bus default
+	Timeout    time.Duration
+	Middleware []HandlerMiddleware
+}
+
+// HandlerTimeout returns Timeout, or fallback, the bus default, when unset
+func (c SubscriptionConfig) HandlerTimeout(fallback time.Duration) time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return fallback
+}
+
+// ApplySubscribeOptions resolves opts for handler; retry is the bus default
+func ApplySubscribeOptions(handler EventHandler, retry RetryPolicy, opts ...SubscribeOption) SubscriptionConfig {
+	return ApplyNamedSubscribeOptions("", handler, retry, opts...)
+}
+
+// ApplyNamedSubscribeOptions is ApplySubscribeOptions with the name passed to
+// EventBus.SubscribeNamed, which takes precedence over WithHandlerName
+func ApplyNamedSubscribeOptions(name string, handler EventHandler, retry RetryPolicy, opts ...SubscribeOption) SubscriptionConfig {
+	var options subscriptionOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	cfg := SubscriptionConfig{Name: name, Retry: retry, Timeout: options.timeout, Middleware: options.middleware}
+	if cfg.Name == "" {
+		cfg.Name = options.name
+	}
+	if cfg.Name == "" {
+		cfg.Name = handlerName(handler)
+	}
+	if options.retry != nil {
+		cfg.Retry = *options.retry
+	}
+	return cfg
+}
+
+// subscription is a handler registered for an event type
+type subscription struct {
+	name      string
+	eventType string
+	handler   EventHandler
+	// call is handler wrapped in the middleware chain
+	call    EventHandler
+	retry   RetryPolicy
+	timeout time.Duration
+	removed atomic.Bool
+	stats   handlerStats
+	bus     interface{ unsubscribe(*subscription) }
+}
+
+func (s *subscription) Name() string      { return s.name }
+func (s *subscription) EventType() string { return s.eventType }
+
+func (s *subscription) Unsubscribe() {
+	s.bus.unsubscribe(s)
+}
+
+// nameTaken reports whether a subscription overlapping pattern uses name
+func nameTaken(subs []*subscription, pattern, name string) bool {
+	for _, sub := range subs {
+		if sub.name == name && patternsOverlap(sub.eventType, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// optionsName returns the name set with WithHandlerName, empty if none
+func optionsName(opts ...SubscribeOption) string {
+	var options subscriptionOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options.name
+}
+
+// handlerName derives a readable name such as "recipe.(*ParseRecipeHandler).Handle"
+// from a handler function
+func handlerName(handler any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
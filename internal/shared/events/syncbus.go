@@ -0,0 +1,328 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/shared/logger"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SyncEventBus delivers events inline: Publish returns once every handler ran,
+// including the handlers of the events published by those handlers
+// It exists for tests, which can then check the outcome of a publish without
+// waiting for workers
+type SyncEventBus struct {
+	subs           []*subscription
+	middleware     []HandlerMiddleware
+	handlerTimeout time.Duration
+	retryPolicy    RetryPolicy
+	deadLetters    DeadLetterStore
+	store          EventStore
+	producer       string
+	logger         logger.Logger
+	mu             sync.RWMutex
+	queueMu        sync.Mutex
+	queue          []syncDelivery
+	dispatching    bool
+	stopped        atomic.Bool
+	ctx            context.Context
+	cancel         context.CancelFunc
+}
+
+// syncDelivery is an event waiting for the dispatch in progress to finish
+// A fresh publish targets every subscription, a redrive targets one
+type syncDelivery struct {
+	event    Event
+	envelope Envelope
+	sub      *subscription
+	ack      AckFunc
+}
+
+// SyncConfig holds configuration for the synchronous event bus
+type SyncConfig struct {
+	HandlerTimeout time.Duration
+	// RetryPolicy applies to subscriptions without their own policy; retries
+	// run right away, the backoff is ignored
+	RetryPolicy RetryPolicy
+	// DeadLetters receives events whose handlers gave up; defaults to an in-memory store
+	DeadLetters DeadLetterStore
+	// Producer is recorded in the envelope of published events
+	Producer string
+	// Store records every published event before it is delivered; optional
+	Store EventStore
+	// Middleware wraps every handler, outside the subscription's own middleware;
+	// nil means DefaultMiddleware, an empty slice disables it
+	Middleware []HandlerMiddleware
+}
+
+// NewSyncEventBus creates a synchronous event bus that does not retry
+func NewSyncEventBus(log logger.Logger) *SyncEventBus {
+	return NewSyncEventBusWithConfig(log, SyncConfig{
+		HandlerTimeout: DefaultHandlerTimeout,
+		RetryPolicy:    NoRetry(),
+	})
+}
+
+// NewSyncEventBusWithConfig creates a synchronous event bus with custom config
+func NewSyncEventBusWithConfig(log logger.Logger, cfg SyncConfig) *SyncEventBus {
+	if cfg.HandlerTimeout <= 0 {
+		cfg.HandlerTimeout = DefaultHandlerTimeout
+	}
+	if cfg.RetryPolicy.MaxAttempts <= 0 {
+		cfg.RetryPolicy.MaxAttempts = 1
+	}
+	if cfg.DeadLetters == nil {
+		cfg.DeadLetters = NewMemoryDeadLetterStore()
+	}
+	if cfg.Producer == "" {
+		cfg.Producer = DefaultProducer
+	}
+	if cfg.Middleware == nil {
+		cfg.Middleware = DefaultMiddleware(log)
+	}
+
+	// Replaced in Start; handlers may run before it is called
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &SyncEventBus{
+		middleware:     cfg.Middleware,
+		handlerTimeout: cfg.HandlerTimeout,
+		retryPolicy:    cfg.RetryPolicy,
+		deadLetters:    cfg.DeadLetters,
+		store:          cfg.Store,
+		producer:       cfg.Producer,
+		logger:         log,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// Start sets the context handlers run under; the bus needs no workers
+func (b *SyncEventBus) Start(ctx context.Context) error {
+	b.cancel()
+	b.ctx, b.cancel = context.WithCancel(ctx)
+	return nil
+}
+
+// Stop makes later publishes fail with ErrBusStopped
+// Nothing is queued once the publishes in progress returned
+func (b *SyncEventBus) Stop(ctx context.Context) error {
+	if b.stopped.Swap(true) {
+		return nil
+	}
+	b.cancel()
+	return nil
+}
+
+// Ping reports ErrBusStopped once Stop was called
+func (b *SyncEventBus) Ping(ctx context.Context) error {
+	if b.stopped.Load() {
+		return ErrBusStopped
+	}
+	return nil
+}
+
+// Publish delivers an event to all registered handlers before returning
+// Handler failures are dead-lettered rather than returned, as on the other buses
+func (b *SyncEventBus) Publish(ctx context.Context, event Event) error {
+	return b.PublishWithAck(ctx, event, nil)
+}
+
+// PublishWithAck delivers an event to all registered handlers and calls ack
+// with the errors of those that gave up
+// A publish made while another one is delivering, usually by a handler, is
+// queued behind it and delivered before that other publish returns
+func (b *SyncEventBus) PublishWithAck(ctx context.Context, event Event, ack AckFunc) error {
+	if b.stopped.Load() {
+		return ErrBusStopped
+	}
+
+	env := NewEnvelope(ctx, event, b.producer)
+	if b.store != nil {
+		if err := b.store.Append(ctx, event, env); err != nil {
+			return fmt.Errorf("failed to store %s event: %w", event.EventType(), err)
+		}
+	}
+
+	b.logger.Debug("Event published",
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+	)
+
+	b.dispatch(syncDelivery{event: event, envelope: env, ack: ack})
+	return nil
+}
+
+// Subscribe registers handler under its function name, see Subscribe
+// Subscription errors are logged; use SubscribeNamed to handle them
+func (b *SyncEventBus) Subscribe(eventType string, handler EventHandler) {
+	if _, err := Subscribe(b, eventType, handler); err != nil {
+		b.logger.Error("Failed to subscribe handler", logger.String("event_type", eventType), logger.Error(err))
+	}
+}
+
+// SubscribeNamed registers a handler for an event type or a pattern such as
+// "recipe.*" or "*", see MatchEventType
+func (b *SyncEventBus) SubscribeNamed(eventType, name string, handler EventHandler, opts ...SubscribeOption) (Subscription, error) {
+	cfg := ApplyNamedSubscribeOptions(name, handler, b.retryPolicy, opts...)
+	sub := &subscription{
+		name:      cfg.Name,
+		eventType: eventType,
+		handler:   handler,
+		call:      WrapHandler(handler, b.middleware, cfg.Middleware),
+		retry:     cfg.Retry,
+		timeout:   cfg.HandlerTimeout(b.handlerTimeout),
+		bus:       b,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if nameTaken(b.subs, eventType, sub.name) {
+		return nil, fmt.Errorf("%w: %s on %s", ErrSubscriptionExists, sub.name, eventType)
+	}
+	b.subs = append(b.subs, sub)
+	return sub, nil
+}
+
+func (b *SyncEventBus) unsubscribe(sub *subscription) {
+	if sub.removed.Swap(true) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, s := range b.subs {
+		if s == sub {
+			b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+			break
+		}
+	}
+}
+
+// DeadLetters returns the events handlers gave up on, oldest first
+func (b *SyncEventBus) DeadLetters(ctx context.Context) ([]DeadLetter, error) {
+	return b.deadLetters.List(ctx)
+}
+
+// Redrive delivers a dead letter to its handler again, with fresh attempts
+func (b *SyncEventBus) Redrive(ctx context.Context, id string) error {
+	letter, err := b.deadLetters.Remove(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	var sub *subscription
+	for _, s := range b.subscriptionsFor(letter.Event.EventType()) {
+		if s.name == letter.Handler {
+			sub = s
+			break
+		}
+	}
+	if sub == nil {
+		_ = b.deadLetters.Add(ctx, letter)
+		return fmt.Errorf("handler %s is no longer subscribed to %s", letter.Handler, letter.Event.EventType())
+	}
+
+	b.dispatch(syncDelivery{event: letter.Event, envelope: letter.Envelope, sub: sub})
+	return nil
+}
+
+// subscriptionsFor returns the subscriptions receiving eventType in
+// subscription order
+func (b *SyncEventBus) subscriptionsFor(eventType string) []*subscription {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var subs []*subscription
+	for _, sub := range b.subs {
+		if MatchEventType(sub.eventType, eventType) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// dispatch queues d and, unless a dispatch is already in progress, delivers
+// the queue until it is empty
+func (b *SyncEventBus) dispatch(d syncDelivery) {
+	b.queueMu.Lock()
+	b.queue = append(b.queue, d)
+	if b.dispatching {
+		b.queueMu.Unlock()
+		return
+	}
+	b.dispatching = true
+
+	for len(b.queue) > 0 {
+		next := b.queue[0]
+		b.queue = b.queue[1:]
+		b.queueMu.Unlock()
+
+		b.deliver(next)
+
+		b.queueMu.Lock()
+	}
+	b.dispatching = false
+	b.queueMu.Unlock()
+}
+
+// deliver runs the handlers of d one after the other, then acknowledges it
+func (b *SyncEventBus) deliver(d syncDelivery) {
+	subs := []*subscription{d.sub}
+	if d.sub == nil {
+		subs = b.subscriptionsFor(d.event.EventType())
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		if err := b.invoke(d.event, d.envelope, sub); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if d.ack != nil {
+		d.ack(errors.Join(errs...))
+	}
+}
+
+// invoke runs the handler until it succeeds or gives up, and dead-letters
+// the event in the latter case
+func (b *SyncEventBus) invoke(event Event, env Envelope, sub *subscription) error {
+	for attempt := 1; ; attempt++ {
+		if sub.removed.Load() {
+			return nil
+		}
+
+		handlerCtx := WithHandlerInfo(ContextWithEnvelope(b.ctx, env), HandlerInfo{
+			Name:        sub.name,
+			Attempt:     attempt,
+			MaxAttempts: sub.retry.MaxAttempts,
+		})
+		handlerCtx, cancel := context.WithTimeout(handlerCtx, sub.timeout)
+		err := CallHandler(handlerCtx, sub.call, event)
+		cancel()
+		LogPanic(handlerCtx, b.logger, event, err)
+
+		if err == nil {
+			return nil
+		}
+		if IsRetryable(err) && attempt < sub.retry.MaxAttempts && !b.stopped.Load() {
+			continue
+		}
+
+		storeDeadLetter(b.ctx, b.deadLetters, b.logger, event, env, sub, attempt, err)
+		return fmt.Errorf("%w: handler %s: %w", ErrDeadLettered, sub.name, err)
+	}
+}
+
+var (
+	_ EventBus        = (*SyncEventBus)(nil)
+	_ AckPublisher    = (*SyncEventBus)(nil)
+	_ DeadLetterQueue = (*SyncEventBus)(nil)
+)
@@ -0,0 +1,159 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"testing"
+)
+
+func newSyncBus(t *testing.T, policy events.RetryPolicy) *events.SyncEventBus {
+	t.Helper()
+
+	bus := events.NewSyncEventBusWithConfig(logger.NewNoopLogger(), events.SyncConfig{RetryPolicy: policy})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	return bus
+}
+
+func TestSyncEventBus_PublishRunsHandlersBeforeReturning(t *testing.T) {
+	bus := newSyncBus(t, events.NoRetry())
+
+	var handled []string
+	bus.SubscribeNamed("test.happened", "first", func(ctx context.Context, event events.Event) error {
+		handled = append(handled, "first:"+event.(*testEvent).id)
+		return nil
+	})
+	bus.SubscribeNamed("test.*", "second", func(ctx context.Context, event events.Event) error {
+		handled = append(handled, "second:"+event.(*testEvent).id)
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), &testEvent{id: "1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if len(handled) != 2 || handled[0] != "first:1" || handled[1] != "second:1" {
+		t.Errorf("expected both handlers in subscription order, got %v", handled)
+	}
+}
+
+func TestSyncEventBus_ChainedEventsRunAfterTheCurrentOne(t *testing.T) {
+	bus := newSyncBus(t, events.NoRetry())
+
+	var handled []string
+	var causation string
+	var parentID string
+	bus.SubscribeNamed("test.happened", "chain", func(ctx context.Context, event events.Event) error {
+		env, _ := events.EnvelopeFromContext(ctx)
+		parentID = env.EventID
+		if err := bus.Publish(ctx, &otherEvent{}); err != nil {
+			return err
+		}
+		handled = append(handled, "chain")
+		return nil
+	})
+	bus.SubscribeNamed("test.happened", "sibling", func(ctx context.Context, event events.Event) error {
+		handled = append(handled, "sibling")
+		return nil
+	})
+	bus.SubscribeNamed("other.happened", "other", func(ctx context.Context, event events.Event) error {
+		env, _ := events.EnvelopeFromContext(ctx)
+		causation = env.CausationID
+		handled = append(handled, "other")
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), &testEvent{id: "1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	want := []string{"chain", "sibling", "other"}
+	if len(handled) != len(want) {
+		t.Fatalf("expected %v, got %v", want, handled)
+	}
+	for i := range want {
+		if handled[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, handled)
+		}
+	}
+	if causation != parentID {
+		t.Errorf("expected chained event caused by %q, got %q", parentID, causation)
+	}
+}
+
+func TestSyncEventBus_RetriesThenDeadLetters(t *testing.T) {
+	bus := newSyncBus(t, fastRetries)
+
+	calls := 0
+	bus.SubscribeNamed("test.happened", "flaky", func(ctx context.Context, event events.Event) error {
+		calls++
+		return errors.New("still broken")
+	})
+
+	var ackErr error
+	if err := bus.PublishWithAck(context.Background(), &testEvent{id: "1"}, func(err error) { ackErr = err }); err != nil {
+		t.Fatalf("PublishWithAck() error = %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+	if !errors.Is(ackErr, events.ErrDeadLettered) {
+		t.Errorf("expected dead-lettered ack, got %v", ackErr)
+	}
+
+	letters, _ := bus.DeadLetters(context.Background())
+	if len(letters) != 1 || letters[0].Handler != "flaky" || letters[0].Attempts != 3 {
+		t.Fatalf("expected one dead letter after 3 attempts, got %+v", letters)
+	}
+
+	calls = 0
+	if err := bus.Redrive(context.Background(), letters[0].ID); err != nil {
+		t.Fatalf("Redrive() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected redrive to run 3 fresh attempts, got %d", calls)
+	}
+}
+
+func TestSyncEventBus_Unsubscribe(t *testing.T) {
+	bus := newSyncBus(t, events.NoRetry())
+
+	calls := 0
+	sub, _ := bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error {
+		calls++
+		return nil
+	})
+	sub.Unsubscribe()
+
+	if err := bus.Publish(context.Background(), &testEvent{id: "1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no calls after unsubscribe, got %d", calls)
+	}
+
+	if _, err := bus.SubscribeNamed("test.happened", "handler", func(ctx context.Context, event events.Event) error { return nil }); err != nil {
+		t.Errorf("expected the name to be free again, got %v", err)
+	}
+}
+
+func TestSyncEventBus_PublishAfterStop(t *testing.T) {
+	bus := newSyncBus(t, events.NoRetry())
+
+	if err := bus.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), &testEvent{id: "1"}); !errors.Is(err, events.ErrBusStopped) {
+		t.Errorf("expected ErrBusStopped, got %v", err)
+	}
+	if err := bus.Ping(context.Background()); !errors.Is(err, events.ErrBusStopped) {
+		t.Errorf("expected Ping to report ErrBusStopped, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrEventTypeMismatch is reported when a typed handler receives an event of
+// another Go type than it handles
+var ErrEventTypeMismatch = errors.New("event type mismatch")
+
+// TypeMismatchError is the handler error of a typed handler given an event
+// it cannot handle; retrying cannot fix it, so the event is dead-lettered
+type TypeMismatchError struct {
+	// Want is the Go type the handler accepts, Got the one it received
+	Want string
+	Got  string
+	// EventType is the event type of the received event
+	EventType string
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("handler for %s expects %s, got %s", e.EventType, e.Want, e.Got)
+}
+
+func (e *TypeMismatchError) Unwrap() []error { return []error{ErrEventTypeMismatch, ErrPermanent} }
+func (e *TypeMismatchError) Retryable() bool { return false }
+
+// TypedHandler adapts a handler of one event type to an EventHandler
+// An event of another Go type fails with a *TypeMismatchError
+func TypedHandler[T Event](handler func(ctx context.Context, event T) error) EventHandler {
+	return func(ctx context.Context, event Event) error {
+		typed, ok := event.(T)
+		if !ok {
+			return &TypeMismatchError{
+				Want:      reflect.TypeFor[T]().String(),
+				Got:       fmt.Sprintf("%T", event),
+				EventType: event.EventType(),
+			}
+		}
+		return handler(ctx, typed)
+	}
+}
+
+// SubscribeTyped registers handler for the event type of T, so the compiler
+// checks that the handler and its subscription agree
+// An empty name defaults to WithHandlerName or the handler's function name
+func SubscribeTyped[T Event](bus EventBus, name string, handler func(ctx context.Context, event T) error, opts ...SubscribeOption) (Subscription, error) {
+	eventType, err := EventTypeFor[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = optionsName(opts...)
+	}
+	if name == "" {
+		name = handlerName(handler)
+	}
+
+	return bus.SubscribeNamed(eventType, name, TypedHandler(handler), opts...)
+}
+
+// EventTypeFor returns the event type of T by calling EventType on its zero
+// value, or for pointer types on a pointer to a zero value
+// It fails for interface types, which have no single event type
+func EventTypeFor[T Event]() (string, error) {
+	typ := reflect.TypeFor[T]()
+	switch typ.Kind() {
+	case reflect.Interface:
+		return "", fmt.Errorf("cannot derive the event type of interface %s", typ)
+	case reflect.Pointer:
+		return reflect.New(typ.Elem()).Interface().(T).EventType(), nil
+	default:
+		var zero T
+		return zero.EventType(), nil
+	}
+}
@@ -0,0 +1,88 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"testing"
+	"time"
+)
+
+// valueEvent is a non-pointer event type
+type valueEvent struct{}
+
+func (valueEvent) EventType() string     { return "test.value" }
+func (valueEvent) OccurredAt() time.Time { return time.Time{} }
+
+func TestSubscribeTyped_DerivesEventTypeAndAssertsEvent(t *testing.T) {
+	bus := newSyncBus(t, events.NoRetry())
+
+	var got *testEvent
+	sub, err := events.SubscribeTyped(bus, "typed", func(ctx context.Context, event *testEvent) error {
+		got = event
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+
+	if sub.EventType() != "test.happened" || sub.Name() != "typed" {
+		t.Errorf("expected typed on test.happened, got %s on %s", sub.Name(), sub.EventType())
+	}
+
+	_ = bus.Publish(context.Background(), &testEvent{id: "1"})
+	if got == nil || got.id != "1" {
+		t.Errorf("expected handler to receive the typed event, got %+v", got)
+	}
+}
+
+func TestSubscribeTyped_NameDefaultsToHandlerFunction(t *testing.T) {
+	bus := newSyncBus(t, events.NoRetry())
+
+	sub, err := events.SubscribeTyped(bus, "", handleTestEvent)
+	if err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+	if sub.Name() != "events_test.handleTestEvent" {
+		t.Errorf("expected function name, got %q", sub.Name())
+	}
+
+	sub, err = events.SubscribeTyped(bus, "", handleTestEvent, events.WithHandlerName("named"))
+	if err != nil {
+		t.Fatalf("SubscribeTyped() error = %v", err)
+	}
+	if sub.Name() != "named" {
+		t.Errorf("expected WithHandlerName to win over the function name, got %q", sub.Name())
+	}
+}
+
+func handleTestEvent(ctx context.Context, event *testEvent) error { return nil }
+
+func TestTypedHandler_MismatchIsPermanent(t *testing.T) {
+	handler := events.TypedHandler(func(ctx context.Context, event *testEvent) error { return nil })
+
+	err := handler(context.Background(), &otherEvent{})
+
+	var mismatch *events.TypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected *TypeMismatchError, got %v", err)
+	}
+	if mismatch.Want != "*events_test.testEvent" || mismatch.Got != "*events_test.otherEvent" || mismatch.EventType != "other.happened" {
+		t.Errorf("unexpected mismatch %+v", mismatch)
+	}
+	if !errors.Is(err, events.ErrEventTypeMismatch) || events.IsRetryable(err) {
+		t.Errorf("expected a permanent ErrEventTypeMismatch, got %v", err)
+	}
+}
+
+func TestEventTypeFor(t *testing.T) {
+	if got, err := events.EventTypeFor[*testEvent](); err != nil || got != "test.happened" {
+		t.Errorf("EventTypeFor[*testEvent]() = %q, %v", got, err)
+	}
+	if got, err := events.EventTypeFor[valueEvent](); err != nil || got != "test.value" {
+		t.Errorf("EventTypeFor[valueEvent]() = %q, %v", got, err)
+	}
+	if _, err := events.EventTypeFor[events.Event](); err == nil {
+		t.Error("expected an error for an interface type")
+	}
+}
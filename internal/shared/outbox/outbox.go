@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is an event waiting in the outbox to be dispatched
+type Message struct {
+	ID        string
+	EventType string
+	Payload   []byte
+	Envelope  events.Envelope
+	CreatedAt time.Time
+	Attempts  int
+	LastError string
+}
+
+// NewMessage encodes event as a new outbox message
+// The envelope is built from ctx now, so the event keeps the correlation of
+// the request that raised it when the relay publishes it later, and records
+// the schema version the payload was encoded with so the relay upcasts it
+func NewMessage(ctx context.Context, encoder events.Encoder, event events.Event) (Message, error) {
+	payload, version, err := encoder.Encode(event)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+
+	env := events.NewEnvelope(ctx, event, "")
+	env.SchemaVersion = version
+
+	return Message{
+		ID:        uuid.New().String(),
+		EventType: event.EventType(),
+		Payload:   payload,
+		Envelope:  env,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NewMessages encodes every event as a new outbox message
+func NewMessages(ctx context.Context, encoder events.Encoder, evts ...events.Event) ([]Message, error) {
+	messages := make([]Message, 0, len(evts))
+	for _, event := range evts {
+		msg, err := NewMessage(ctx, encoder, event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// Store holds outbox messages until they are delivered
+// Messages are written by the repositories, in the same transaction as the
+// state change that raised them
+type Store interface {
+	// Pending returns undelivered messages that are due at now, oldest first
+	Pending(ctx context.Context, now time.Time, limit int) ([]Message, error)
+	// MarkDelivered records that all handlers processed the message
+	MarkDelivered(ctx context.Context, id string, at time.Time) error
+	// MarkFailed records a failed delivery attempt; the message is due again
+	// at retryAt, or never again when retryAt is zero
+	MarkFailed(ctx context.Context, id string, reason string, retryAt time.Time) error
+}
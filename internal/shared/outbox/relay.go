@@ -0,0 +1,246 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is how often the relay looks for pending messages
+	DefaultPollInterval = 500 * time.Millisecond
+	// DefaultBatchSize is the maximum number of messages dispatched per poll
+	DefaultBatchSize = 100
+	// DefaultMaxAttempts is how often a message is dispatched before it is parked
+	DefaultMaxAttempts = 5
+	// DefaultRetryDelay is the delay before a failed message is dispatched again
+	DefaultRetryDelay = 5 * time.Second
+)
+
+// Config holds configuration for the outbox relay
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	RetryDelay   time.Duration
+}
+
+// Relay dispatches pending outbox messages to the event bus
+// A message is marked delivered only once the bus confirms that every handler
+// succeeded; messages left pending by a crash are dispatched again on startup
+// Messages the bus dead-lettered are parked, they are redriven from the bus
+type Relay struct {
+	store    Store
+	bus      events.EventBus
+	decoder  events.Decoder
+	logger   logger.Logger
+	config   Config
+	mu       sync.Mutex
+	inflight map[string]bool
+	wg       sync.WaitGroup
+	cancel   context.CancelFunc
+}
+
+// NewRelay creates a new outbox relay
+// Zero config values fall back to the defaults
+func NewRelay(store Store, bus events.EventBus, decoder events.Decoder, log logger.Logger, cfg Config) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = DefaultRetryDelay
+	}
+
+	return &Relay{
+		store:    store,
+		bus:      bus,
+		decoder:  decoder,
+		logger:   log,
+		config:   cfg,
+		inflight: make(map[string]bool),
+	}
+}
+
+// Start dispatches pending messages in the background until Stop is called
+func (r *Relay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.wg.Add(1)
+	go r.run(ctx)
+
+	r.logger.Info("Outbox relay started",
+		logger.Duration("poll_interval", r.config.PollInterval),
+	)
+}
+
+// Stop stops polling and waits for the current batch to be handed to the bus
+// Messages still being handled stay pending and are redelivered on next start
+func (r *Relay) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+
+	r.logger.Info("Outbox relay stopped")
+}
+
+func (r *Relay) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := r.DispatchPending(ctx)
+		switch {
+		case err == nil || ctx.Err() != nil:
+		case errors.Is(err, events.ErrQueueFull):
+			// Backpressure: the rest of the batch waits for the next poll
+			r.logger.Warn("Event bus queue full, outbox dispatch deferred", logger.Error(err))
+		default:
+			r.logger.Error("Outbox dispatch failed", logger.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending hands one batch of due messages to the event bus
+// It returns the number of messages dispatched
+func (r *Relay) DispatchPending(ctx context.Context) (int, error) {
+	messages, err := r.store.Pending(ctx, time.Now(), r.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load pending messages: %w", err)
+	}
+
+	dispatched := 0
+	for _, msg := range messages {
+		if !r.claim(msg.ID) {
+			continue
+		}
+
+		if err := r.dispatch(ctx, msg); err != nil {
+			r.release(msg.ID)
+			return dispatched, err
+		}
+		dispatched++
+	}
+
+	return dispatched, nil
+}
+
+// dispatch publishes a single message; the outcome is recorded asynchronously
+// when the bus supports acknowledgements
+func (r *Relay) dispatch(ctx context.Context, msg Message) error {
+	event, err := r.decoder.Decode(msg.EventType, msg.Envelope.SchemaVersion, msg.Payload)
+	if err != nil {
+		// Retrying cannot fix a payload we cannot read
+		r.logger.Error("Parking undecodable outbox message",
+			logger.String("message_id", msg.ID),
+			logger.String("event_type", msg.EventType),
+			logger.Error(err),
+		)
+		r.complete(ctx, msg, fmt.Errorf("decode: %w", err), true)
+		return nil
+	}
+
+	// Publish under the envelope recorded with the message, not a fresh one
+	publishCtx := events.WithOutgoingEnvelope(ctx, msg.Envelope)
+
+	if publisher, ok := r.bus.(events.AckPublisher); ok {
+		ack := func(err error) { r.complete(context.WithoutCancel(ctx), msg, err, false) }
+		if err := publisher.PublishWithAck(publishCtx, event, ack); err != nil {
+			return fmt.Errorf("failed to publish message %s: %w", msg.ID, err)
+		}
+		return nil
+	}
+
+	// Without acknowledgements a successful publish is the best we can know
+	if err := r.bus.Publish(publishCtx, event); err != nil {
+		return fmt.Errorf("failed to publish message %s: %w", msg.ID, err)
+	}
+	r.complete(ctx, msg, nil, false)
+	return nil
+}
+
+// complete records the delivery outcome and releases the message
+func (r *Relay) complete(ctx context.Context, msg Message, deliveryErr error, park bool) {
+	defer r.release(msg.ID)
+
+	if deliveryErr == nil {
+		if err := r.store.MarkDelivered(ctx, msg.ID, time.Now()); err != nil {
+			r.logger.Error("Failed to mark outbox message delivered",
+				logger.String("message_id", msg.ID),
+				logger.Error(err),
+			)
+		}
+		return
+	}
+
+	// The bus already retried and kept the event for redrive
+	if errors.Is(deliveryErr, events.ErrDeadLettered) {
+		park = true
+	}
+
+	var retryAt time.Time
+	if !park && msg.Attempts+1 < r.config.MaxAttempts {
+		retryAt = time.Now().Add(r.config.RetryDelay)
+	}
+
+	if retryAt.IsZero() {
+		r.logger.Error("Outbox message gave up",
+			logger.String("message_id", msg.ID),
+			logger.String("event_type", msg.EventType),
+			logger.Int("attempts", msg.Attempts+1),
+			logger.Error(deliveryErr),
+		)
+	} else {
+		r.logger.Warn("Outbox message delivery failed, will retry",
+			logger.String("message_id", msg.ID),
+			logger.String("event_type", msg.EventType),
+			logger.Int("attempts", msg.Attempts+1),
+			logger.Error(deliveryErr),
+		)
+	}
+
+	if err := r.store.MarkFailed(ctx, msg.ID, deliveryErr.Error(), retryAt); err != nil {
+		r.logger.Error("Failed to record outbox delivery failure",
+			logger.String("message_id", msg.ID),
+			logger.Error(err),
+		)
+	}
+}
+
+// claim marks the message as in flight, so a poll running before the
+// acknowledgement arrives does not dispatch it twice
+func (r *Relay) claim(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inflight[id] {
+		return false
+	}
+	r.inflight[id] = true
+	return true
+}
+
+func (r *Relay) release(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.inflight, id)
+}
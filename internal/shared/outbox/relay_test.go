@@ -0,0 +1,297 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/memory"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"recipe-processor/internal/shared/outbox"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// submit stores a submitted recipe and its event in the repository's outbox
+func submit(t *testing.T, repo *memory.RecipeRepository, id string) {
+	t.Helper()
+
+	text, _ := domain.NewRecipeText("Pancakes")
+	lifecycle, _ := domain.NewRecipeLifecycle(id, text, time.Now())
+	if err := repo.SaveWithEvents(context.Background(), lifecycle, domain.NewRecipeSubmitted(id, "Pancakes")); err != nil {
+		t.Fatalf("SaveWithEvents() error = %v", err)
+	}
+}
+
+// newRegistry registers the only event type these tests enqueue
+func newRegistry(t *testing.T) *events.Registry {
+	t.Helper()
+
+	registry := events.NewRegistry()
+	if err := registry.Register(func() events.Event { return &domain.RecipeSubmitted{} }); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	return registry
+}
+
+// startBus starts a memory event bus that is stopped when the test ends
+func startBus(t *testing.T) *events.MemoryEventBus {
+	t.Helper()
+
+	bus := events.NewMemoryEventBus(logger.NewNoopLogger())
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+	return bus
+}
+
+// waitFor polls cond until it holds or the test times out
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func pendingCount(t *testing.T, store outbox.Store, at time.Time) int {
+	t.Helper()
+
+	messages, err := store.Pending(context.Background(), at, 0)
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	return len(messages)
+}
+
+func TestRelay_DispatchPending_MarksDeliveredAfterHandlersSucceed(t *testing.T) {
+	repo := memory.NewRecipeRepository(newRegistry(t))
+	submit(t, repo, "recipe-1")
+
+	bus := startBus(t)
+	received := make(chan *domain.RecipeSubmitted, 1)
+	release := make(chan struct{})
+	bus.SubscribeNamed(domain.EventTypeRecipeSubmitted, "handler", func(ctx context.Context, event events.Event) error {
+		received <- event.(*domain.RecipeSubmitted)
+		<-release
+		return nil
+	})
+
+	relay := outbox.NewRelay(repo.Outbox(), bus, newRegistry(t), logger.NewNoopLogger(), outbox.Config{})
+
+	n, err := relay.DispatchPending(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("DispatchPending() = %d, %v; want 1, nil", n, err)
+	}
+
+	event := <-received
+	if event.RecipeID != "recipe-1" || event.RecipeText != "Pancakes" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	// Still in flight: pending, but not dispatched a second time
+	if got := pendingCount(t, repo.Outbox(), time.Now()); got != 1 {
+		t.Errorf("expected message to stay pending until handled, got %d pending", got)
+	}
+	if n, _ := relay.DispatchPending(context.Background()); n != 0 {
+		t.Errorf("expected in-flight message not to be dispatched again, got %d", n)
+	}
+
+	close(release)
+	waitFor(t, "message to be delivered", func() bool {
+		return pendingCount(t, repo.Outbox(), time.Now()) == 0
+	})
+}
+
+func TestRelay_DispatchPending_KeepsEnvelopeOfStoredMessage(t *testing.T) {
+	repo := memory.NewRecipeRepository(newRegistry(t))
+	text, _ := domain.NewRecipeText("Pancakes")
+	lifecycle, _ := domain.NewRecipeLifecycle("recipe-1", text, time.Now())
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+	if err := repo.SaveWithEvents(ctx, lifecycle, domain.NewRecipeSubmitted("recipe-1", "Pancakes")); err != nil {
+		t.Fatalf("SaveWithEvents() error = %v", err)
+	}
+	messages, _ := repo.Outbox().Pending(context.Background(), time.Now(), 0)
+
+	bus := startBus(t)
+	received := make(chan events.Envelope, 1)
+	bus.SubscribeNamed(domain.EventTypeRecipeSubmitted, "handler", func(ctx context.Context, event events.Event) error {
+		env, _ := events.EnvelopeFromContext(ctx)
+		received <- env
+		return nil
+	})
+
+	relay := outbox.NewRelay(repo.Outbox(), bus, newRegistry(t), logger.NewNoopLogger(), outbox.Config{})
+	if _, err := relay.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("DispatchPending() error = %v", err)
+	}
+
+	env := <-received
+	if env.CorrelationID != "request-1" {
+		t.Errorf("expected correlation ID 'request-1', got %q", env.CorrelationID)
+	}
+	if env.EventID != messages[0].Envelope.EventID {
+		t.Errorf("expected event ID %q, got %q", messages[0].Envelope.EventID, env.EventID)
+	}
+}
+
+// nackBus acknowledges every event with an error, like a broker rejecting it
+type nackBus struct {
+	calls atomic.Int32
+}
+
+func (b *nackBus) Publish(ctx context.Context, event events.Event) error   { return nil }
+func (b *nackBus) Subscribe(eventType string, handler events.EventHandler) {}
+func (b *nackBus) SubscribeNamed(eventType, name string, handler events.EventHandler, opts ...events.SubscribeOption) (events.Subscription, error) {
+	return nil, nil
+}
+func (b *nackBus) Start(ctx context.Context) error { return nil }
+func (b *nackBus) Stop(ctx context.Context) error  { return nil }
+
+func (b *nackBus) PublishWithAck(ctx context.Context, event events.Event, ack events.AckFunc) error {
+	b.calls.Add(1)
+	go ack(errors.New("parser unavailable"))
+	return nil
+}
+
+func TestRelay_DispatchPending_RetriesFailedMessagesThenParks(t *testing.T) {
+	repo := memory.NewRecipeRepository(newRegistry(t))
+	submit(t, repo, "recipe-1")
+
+	bus := &nackBus{}
+	calls := &bus.calls
+
+	relay := outbox.NewRelay(repo.Outbox(), bus, newRegistry(t), logger.NewNoopLogger(), outbox.Config{
+		MaxAttempts: 2,
+		RetryDelay:  50 * time.Millisecond,
+	})
+
+	if _, err := relay.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("DispatchPending() error = %v", err)
+	}
+	waitFor(t, "first failure to be recorded", func() bool {
+		messages, _ := repo.Outbox().Pending(context.Background(), time.Now().Add(time.Hour), 0)
+		return len(messages) == 1 && messages[0].Attempts == 1
+	})
+
+	messages, _ := repo.Outbox().Pending(context.Background(), time.Now().Add(time.Hour), 0)
+	if messages[0].LastError != "parser unavailable" {
+		t.Errorf("LastError = %q, want handler error", messages[0].LastError)
+	}
+
+	// Second and last attempt once the retry delay passed: the message is parked
+	waitFor(t, "retry to become due", func() bool {
+		return pendingCount(t, repo.Outbox(), time.Now()) == 1
+	})
+	if _, err := relay.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("DispatchPending() error = %v", err)
+	}
+	waitFor(t, "message to be parked", func() bool {
+		return calls.Load() == 2 && pendingCount(t, repo.Outbox(), time.Now().Add(time.Hour)) == 0
+	})
+}
+
+func TestRelay_DispatchPending_ParksDeadLetteredMessages(t *testing.T) {
+	repo := memory.NewRecipeRepository(newRegistry(t))
+	submit(t, repo, "recipe-1")
+
+	bus := events.NewMemoryEventBusWithConfig(logger.NewNoopLogger(), events.Config{
+		WorkerCount:    1,
+		ChannelBuffer:  1,
+		HandlerTimeout: time.Second,
+		RetryPolicy:    events.NoRetry(),
+	})
+	_ = bus.Start(context.Background())
+	defer func() { _ = bus.Stop(context.Background()) }()
+
+	bus.SubscribeNamed(domain.EventTypeRecipeSubmitted, "handler", func(ctx context.Context, event events.Event) error {
+		return errors.New("parser unavailable")
+	})
+
+	relay := outbox.NewRelay(repo.Outbox(), bus, newRegistry(t), logger.NewNoopLogger(), outbox.Config{})
+	if _, err := relay.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("DispatchPending() error = %v", err)
+	}
+
+	waitFor(t, "message to be dead-lettered", func() bool {
+		letters, _ := bus.DeadLetters(context.Background())
+		return len(letters) == 1
+	})
+	waitFor(t, "message to be parked", func() bool {
+		return pendingCount(t, repo.Outbox(), time.Now().Add(time.Hour)) == 0
+	})
+}
+
+func TestRelay_DispatchPending_ParksUndecodableMessages(t *testing.T) {
+	repo := memory.NewRecipeRepository(newRegistry(t))
+	submit(t, repo, "recipe-1")
+
+	bus := startBus(t)
+	// Nothing registered, so the message cannot be decoded
+	relay := outbox.NewRelay(repo.Outbox(), bus, events.NewRegistry(), logger.NewNoopLogger(), outbox.Config{})
+
+	if _, err := relay.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("DispatchPending() error = %v", err)
+	}
+
+	if got := pendingCount(t, repo.Outbox(), time.Now().Add(time.Hour)); got != 0 {
+		t.Errorf("expected undecodable message to be parked, got %d pending", got)
+	}
+}
+
+func TestRelay_Start_RedeliversMessagesLeftByPreviousRun(t *testing.T) {
+	repo := memory.NewRecipeRepository(newRegistry(t))
+	submit(t, repo, "recipe-1")
+	submit(t, repo, "recipe-2")
+
+	bus := startBus(t)
+	received := make(chan string, 2)
+	bus.SubscribeNamed(domain.EventTypeRecipeSubmitted, "handler", func(ctx context.Context, event events.Event) error {
+		received <- event.(*domain.RecipeSubmitted).RecipeID
+		return nil
+	})
+
+	relay := outbox.NewRelay(repo.Outbox(), bus, newRegistry(t), logger.NewNoopLogger(), outbox.Config{
+		PollInterval: 10 * time.Millisecond,
+	})
+	relay.Start(context.Background())
+	defer relay.Stop()
+
+	got := map[string]bool{<-received: true, <-received: true}
+	if !got["recipe-1"] || !got["recipe-2"] {
+		t.Errorf("expected both recipes to be delivered, got %v", got)
+	}
+}
+
+func TestNewMessage_EncodesWithTheRegistry(t *testing.T) {
+	registry := newRegistry(t)
+	ctx := context.Background()
+
+	msg, err := outbox.NewMessage(ctx, registry, domain.NewRecipeSubmitted("recipe-1", "Pancakes"))
+	if err != nil {
+		t.Fatalf("NewMessage() error = %v", err)
+	}
+	if msg.Envelope.SchemaVersion != events.DefaultSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", events.DefaultSchemaVersion, msg.Envelope.SchemaVersion)
+	}
+
+	event, err := registry.Decode(msg.EventType, msg.Envelope.SchemaVersion, msg.Payload)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if submitted, ok := event.(*domain.RecipeSubmitted); !ok || submitted.RecipeID != "recipe-1" {
+		t.Errorf("expected RecipeSubmitted of recipe-1, got %+v", event)
+	}
+
+	// Events nobody could decode are refused when enqueued, not when relayed
+	if _, err := outbox.NewMessage(ctx, registry, domain.NewRecipeParsed("recipe-1", nil)); !errors.Is(err, events.ErrUnknownEventType) {
+		t.Errorf("expected ErrUnknownEventType, got %v", err)
+	}
+}
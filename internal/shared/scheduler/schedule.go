@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"recipe-processor/internal/shared/events"
+	"time"
+)
+
+// ErrNotFound is returned when cancelling an event that is not scheduled,
+// either because it was already published or because the ID is unknown
+var ErrNotFound = errors.New("scheduled event not found")
+
+// ErrInvalidEvent is wrapped by Store.Claim for stored events it could not restore
+var ErrInvalidEvent = errors.New("invalid scheduled event")
+
+// ScheduledEvent is an event waiting to be published
+type ScheduledEvent struct {
+	// ID is the EventID the event is published with
+	ID       string
+	Event    events.Event
+	Envelope events.Envelope
+	DueAt    time.Time
+	// CreatedAt is when the event was scheduled
+	CreatedAt time.Time
+}
+
+// Store keeps scheduled events until they are published or cancelled
+// Implementations must persist them so schedules survive restarts
+type Store interface {
+	Add(ctx context.Context, scheduled ScheduledEvent) error
+	// Claim returns events due at now, earliest first, and hides them from
+	// other Claim calls until now+lease, so each is published by one of the
+	// schedulers sharing the store; limit <= 0 means no limit
+	// Claiming must be atomic across the processes sharing the store
+	// Events that cannot be restored are parked, so they never block the
+	// others, and reported by an error wrapping ErrInvalidEvent that comes
+	// with the valid events
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]ScheduledEvent, error)
+	// Remove deletes a scheduled event, or returns ErrNotFound
+	Remove(ctx context.Context, id string) error
+}
@@ -0,0 +1,199 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is how often the scheduler looks for due events
+	DefaultPollInterval = time.Second
+	// DefaultBatchSize is the maximum number of events published per poll
+	DefaultBatchSize = 100
+	// DefaultClaimLease is how long a polled batch is hidden from other schedulers
+	DefaultClaimLease = time.Minute
+)
+
+// Config holds configuration for the scheduler
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// ClaimLease is how long other schedulers sharing the store skip a batch
+	// this one polled; it should outlast publishing a batch. An event this
+	// scheduler failed to publish is polled again once its claim ends
+	ClaimLease time.Duration
+}
+
+// Scheduler publishes events on the bus at a later time
+// It provides PublishAt and PublishAfter next to the bus rather than on the
+// EventBus interface: it publishes through any bus, so the memory, sync and
+// broker-backed buses share one persistent schedule without each keeping timers
+// Scheduled events are kept in a Store, so they survive restarts; an event
+// that came due while no scheduler ran is published on the next start
+// Any number of schedulers may share a store, such as the API and every
+// worker: each due event is claimed by one of them
+// Events are published at least once: a crash between publishing and
+// removing an event publishes it again, under the same EventID, once its
+// claim ends
+type Scheduler struct {
+	store  Store
+	bus    events.EventBus
+	logger logger.Logger
+	config Config
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewScheduler creates a new scheduler
+// Zero config values fall back to the defaults
+func NewScheduler(store Store, bus events.EventBus, log logger.Logger, cfg Config) *Scheduler {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.ClaimLease <= 0 {
+		cfg.ClaimLease = DefaultClaimLease
+	}
+
+	return &Scheduler{
+		store:  store,
+		bus:    bus,
+		logger: log,
+		config: cfg,
+	}
+}
+
+// PublishAt schedules event to be published at at and returns its ID
+// The envelope is built from ctx now, so the event keeps the correlation of
+// the request or event that scheduled it
+func (s *Scheduler) PublishAt(ctx context.Context, event events.Event, at time.Time) (string, error) {
+	env := events.NewEnvelope(ctx, event, "")
+	// Set by the bus when the event is actually published
+	env.PublishedAt = time.Time{}
+
+	scheduled := ScheduledEvent{
+		ID:        env.EventID,
+		Event:     event,
+		Envelope:  env,
+		DueAt:     at,
+		CreatedAt: time.Now(),
+	}
+	if err := s.store.Add(ctx, scheduled); err != nil {
+		return "", fmt.Errorf("failed to schedule %s event: %w", event.EventType(), err)
+	}
+
+	s.logger.Debug("Event scheduled",
+		logger.String("event_type", event.EventType()),
+		logger.String("event_id", env.EventID),
+		logger.String("correlation_id", env.CorrelationID),
+		logger.Any("due_at", at),
+	)
+	return scheduled.ID, nil
+}
+
+// PublishAfter schedules event to be published once delay has passed
+func (s *Scheduler) PublishAfter(ctx context.Context, event events.Event, delay time.Duration) (string, error) {
+	return s.PublishAt(ctx, event, time.Now().Add(delay))
+}
+
+// Cancel removes a scheduled event; it returns ErrNotFound once the event
+// was published
+func (s *Scheduler) Cancel(ctx context.Context, id string) error {
+	if err := s.store.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Debug("Scheduled event cancelled", logger.String("event_id", id))
+	return nil
+}
+
+// Start publishes due events in the background until Stop is called
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go s.run(ctx)
+
+	s.logger.Info("Scheduler started",
+		logger.Duration("poll_interval", s.config.PollInterval),
+	)
+}
+
+// Stop stops polling and waits for the current batch to be published
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+
+	s.logger.Info("Scheduler stopped")
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PublishDue(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("Publishing scheduled events failed", logger.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishDue claims one batch of due events, publishes them and removes them
+// from the store
+// It returns the number of events published; an event that fails to publish
+// stays scheduled for a poll after its claim ends, without holding back the
+// rest of the batch
+func (s *Scheduler) PublishDue(ctx context.Context) (int, error) {
+	due, err := s.store.Claim(ctx, time.Now(), s.config.ClaimLease, s.config.BatchSize)
+	if err != nil {
+		if !errors.Is(err, ErrInvalidEvent) {
+			return 0, fmt.Errorf("failed to load due events: %w", err)
+		}
+		// Parked by the store, the remaining events are still published
+		s.logger.Error("Skipping invalid scheduled events", logger.Error(err))
+	}
+
+	published := 0
+	var errs []error
+	for _, scheduled := range due {
+		// Publish under the envelope recorded when scheduling, not a fresh one
+		publishCtx := events.WithOutgoingEnvelope(ctx, scheduled.Envelope)
+		if err := s.bus.Publish(publishCtx, scheduled.Event); err != nil {
+			// Left in the store, a poll after the claim ends tries again
+			errs = append(errs, fmt.Errorf("failed to publish scheduled event %s: %w", scheduled.ID, err))
+			continue
+		}
+
+		// Cancelled while being published; nothing left to remove
+		if err := s.store.Remove(ctx, scheduled.ID); err != nil && !errors.Is(err, ErrNotFound) {
+			// Published already; the next poll publishes it again under the same EventID
+			errs = append(errs, fmt.Errorf("failed to remove published event %s: %w", scheduled.ID, err))
+		}
+		published++
+
+		s.logger.Debug("Scheduled event published",
+			logger.String("event_type", scheduled.Event.EventType()),
+			logger.String("event_id", scheduled.ID),
+			logger.Duration("delay", time.Since(scheduled.DueAt)),
+		)
+	}
+
+	return published, errors.Join(errs...)
+}
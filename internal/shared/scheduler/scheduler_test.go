@@ -0,0 +1,232 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"recipe-processor/internal/domain"
+	"recipe-processor/internal/infrastructure/persistence/memory"
+	"recipe-processor/internal/shared/events"
+	"recipe-processor/internal/shared/logger"
+	"recipe-processor/internal/shared/scheduler"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingBus records published events with the envelope they would carry
+type recordingBus struct {
+	mu        sync.Mutex
+	published []events.Envelope
+	err       error
+	// failures fails this many publishes before err applies
+	failures int
+}
+
+func (b *recordingBus) Publish(ctx context.Context, event events.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures > 0 {
+		b.failures--
+		return events.ErrQueueFull
+	}
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, events.NewEnvelope(ctx, event, "test"))
+	return nil
+}
+
+func (b *recordingBus) Subscribe(eventType string, handler events.EventHandler) {}
+func (b *recordingBus) SubscribeNamed(eventType, name string, handler events.EventHandler, opts ...events.SubscribeOption) (events.Subscription, error) {
+	return nil, nil
+}
+func (b *recordingBus) Start(ctx context.Context) error { return nil }
+func (b *recordingBus) Stop(ctx context.Context) error  { return nil }
+
+func (b *recordingBus) envelopes() []events.Envelope {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]events.Envelope(nil), b.published...)
+}
+
+func newScheduler(bus events.EventBus) (*scheduler.Scheduler, *memory.ScheduleStore) {
+	store := memory.NewScheduleStore()
+	return scheduler.NewScheduler(store, bus, logger.NewNoopLogger(), scheduler.Config{PollInterval: 5 * time.Millisecond}), store
+}
+
+func TestScheduler_PublishDue_PublishesOnlyDueEvents(t *testing.T) {
+	bus := &recordingBus{}
+	s, _ := newScheduler(bus)
+	ctx := events.WithCorrelationID(context.Background(), "request-1")
+
+	dueID, err := s.PublishAt(ctx, domain.NewRecipeSubmitted("recipe-1", "Pancakes"), time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("PublishAt() error = %v", err)
+	}
+	if _, err := s.PublishAfter(ctx, domain.NewRecipeSubmitted("recipe-2", "Waffles"), time.Hour); err != nil {
+		t.Fatalf("PublishAfter() error = %v", err)
+	}
+
+	published, err := s.PublishDue(context.Background())
+	if err != nil {
+		t.Fatalf("PublishDue() error = %v", err)
+	}
+	if published != 1 {
+		t.Fatalf("expected 1 published event, got %d", published)
+	}
+
+	envs := bus.envelopes()
+	if envs[0].EventID != dueID || envs[0].CorrelationID != "request-1" {
+		t.Errorf("expected event %s of request-1, got %+v", dueID, envs[0])
+	}
+
+	// Published events are removed from the store
+	if published, _ := s.PublishDue(context.Background()); published != 0 {
+		t.Errorf("expected nothing left to publish, got %d", published)
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	bus := &recordingBus{}
+	s, _ := newScheduler(bus)
+
+	id, _ := s.PublishAt(context.Background(), domain.NewRecipeSubmitted("recipe-1", "Pancakes"), time.Now())
+	if err := s.Cancel(context.Background(), id); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if err := s.Cancel(context.Background(), id); !errors.Is(err, scheduler.ErrNotFound) {
+		t.Errorf("expected ErrNotFound when cancelling twice, got %v", err)
+	}
+
+	if published, _ := s.PublishDue(context.Background()); published != 0 {
+		t.Errorf("expected cancelled event not to be published, got %d", published)
+	}
+}
+
+func TestScheduler_KeepsEventsThePublishFailed(t *testing.T) {
+	bus := &recordingBus{err: events.ErrQueueFull}
+	s, store := newScheduler(bus)
+
+	if _, err := s.PublishAt(context.Background(), domain.NewRecipeSubmitted("recipe-1", "Pancakes"), time.Now()); err != nil {
+		t.Fatalf("PublishAt() error = %v", err)
+	}
+
+	if _, err := s.PublishDue(context.Background()); !errors.Is(err, events.ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+	if scheduled := store.List(); len(scheduled) != 1 {
+		t.Errorf("expected the event to stay scheduled, got %d", len(scheduled))
+	}
+}
+
+func TestScheduler_PublishDue_ContinuesAfterAFailedPublish(t *testing.T) {
+	bus := &recordingBus{failures: 1}
+	s, store := newScheduler(bus)
+
+	if _, err := s.PublishAt(context.Background(), domain.NewRecipeSubmitted("recipe-1", "Pancakes"), time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("PublishAt() error = %v", err)
+	}
+	secondID, err := s.PublishAt(context.Background(), domain.NewRecipeSubmitted("recipe-2", "Waffles"), time.Now())
+	if err != nil {
+		t.Fatalf("PublishAt() error = %v", err)
+	}
+
+	published, err := s.PublishDue(context.Background())
+	if !errors.Is(err, events.ErrQueueFull) || published != 1 {
+		t.Fatalf("PublishDue() = %d, %v; want 1, ErrQueueFull", published, err)
+	}
+	if envs := bus.envelopes(); len(envs) != 1 || envs[0].EventID != secondID {
+		t.Errorf("expected the second event to be published, got %+v", envs)
+	}
+	if scheduled := store.List(); len(scheduled) != 1 || scheduled[0].ID == secondID {
+		t.Errorf("expected only the failed event to stay scheduled, got %+v", scheduled)
+	}
+}
+
+func TestScheduler_SchedulersSharingAStorePublishEachEventOnce(t *testing.T) {
+	bus := &recordingBus{}
+	store := memory.NewScheduleStore()
+	api := scheduler.NewScheduler(store, bus, logger.NewNoopLogger(), scheduler.Config{BatchSize: 3})
+	worker := scheduler.NewScheduler(store, bus, logger.NewNoopLogger(), scheduler.Config{BatchSize: 3})
+
+	for i := range 10 {
+		if _, err := api.PublishAt(context.Background(), domain.NewRecipeSubmitted(fmt.Sprintf("recipe-%d", i), "Pancakes"), time.Now()); err != nil {
+			t.Fatalf("PublishAt() error = %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, s := range []*scheduler.Scheduler{api, worker} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if published, err := s.PublishDue(context.Background()); err != nil || published == 0 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for _, env := range bus.envelopes() {
+		if seen[env.EventID] {
+			t.Errorf("event %s published twice", env.EventID)
+		}
+		seen[env.EventID] = true
+	}
+	if len(seen) != 10 {
+		t.Errorf("expected 10 events published, got %d", len(seen))
+	}
+}
+
+// invalidStore reports an event it could not restore next to the valid ones
+type invalidStore struct {
+	*memory.ScheduleStore
+}
+
+func (s invalidStore) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]scheduler.ScheduledEvent, error) {
+	due, err := s.ScheduleStore.Claim(ctx, now, lease, limit)
+	if err != nil {
+		return nil, err
+	}
+	return due, fmt.Errorf("%w broken: invalid envelope", scheduler.ErrInvalidEvent)
+}
+
+func TestScheduler_PublishDue_SkipsInvalidEvents(t *testing.T) {
+	bus := &recordingBus{}
+	store := invalidStore{memory.NewScheduleStore()}
+	s := scheduler.NewScheduler(store, bus, logger.NewNoopLogger(), scheduler.Config{})
+
+	if _, err := s.PublishAt(context.Background(), domain.NewRecipeSubmitted("recipe-1", "Pancakes"), time.Now()); err != nil {
+		t.Fatalf("PublishAt() error = %v", err)
+	}
+
+	published, err := s.PublishDue(context.Background())
+	if err != nil || published != 1 {
+		t.Fatalf("PublishDue() = %d, %v; want 1, nil", published, err)
+	}
+}
+
+func TestScheduler_StartPublishesInTheBackground(t *testing.T) {
+	bus := &recordingBus{}
+	s, _ := newScheduler(bus)
+	s.Start(context.Background())
+	defer s.Stop()
+
+	if _, err := s.PublishAfter(context.Background(), domain.NewRecipeSubmitted("recipe-1", "Pancakes"), 10*time.Millisecond); err != nil {
+		t.Fatalf("PublishAfter() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(bus.envelopes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the scheduled event")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}